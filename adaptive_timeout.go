@@ -0,0 +1,180 @@
+package redis
+
+import (
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	latencyBuckets = 128
+	// Bucket upper bounds grow by 15% starting at 10 microseconds.
+	latencyBase   = 10 * time.Microsecond
+	latencyGrowth = 1.15
+	// Counts are halved after this many samples so old latencies
+	// are gradually forgotten.
+	latencyDecayEvery = 10000
+)
+
+// latencyHistogram is a log-scale histogram of command latencies.
+type latencyHistogram struct {
+	mu      sync.Mutex
+	buckets [latencyBuckets]uint32
+	count   uint32
+	total   uint64 // all samples ever observed
+}
+
+func latencyBucket(d time.Duration) int {
+	if d <= latencyBase {
+		return 0
+	}
+	i := int(math.Ceil(math.Log(float64(d)/float64(latencyBase)) / math.Log(latencyGrowth)))
+	if i >= latencyBuckets {
+		return latencyBuckets - 1
+	}
+	return i
+}
+
+func latencyBucketBound(i int) time.Duration {
+	return time.Duration(float64(latencyBase) * math.Pow(latencyGrowth, float64(i)))
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	h.mu.Lock()
+	h.buckets[latencyBucket(d)]++
+	h.count++
+	h.total++
+	if h.count >= latencyDecayEvery {
+		h.count = 0
+		for i := range h.buckets {
+			h.buckets[i] /= 2
+			h.count += h.buckets[i]
+		}
+	}
+	h.mu.Unlock()
+}
+
+// percentile returns upper bound of the latency percentile and the
+// total number of observed samples.
+func (h *latencyHistogram) percentile(p float64) (time.Duration, uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count == 0 {
+		return 0, h.total
+	}
+	rank := uint32(math.Ceil(p * float64(h.count)))
+	var seen uint32
+	for i, n := range h.buckets {
+		seen += n
+		if seen >= rank {
+			return latencyBucketBound(i), h.total
+		}
+	}
+	return latencyBucketBound(latencyBuckets - 1), h.total
+}
+
+// AdaptiveTimeout derives read timeouts from observed latency of each
+// command family (e.g. "get" or "hgetall") instead of using a fixed
+// ReadTimeout. Timeout is set to the latency percentile multiplied by
+// Multiplier. AdaptiveTimeout can be shared between clients.
+type AdaptiveTimeout struct {
+	// Latency percentile.
+	// Default is 0.999.
+	Percentile float64
+	// Default is 3.
+	Multiplier float64
+	// Bounds of the timeout. Max defaults to ReadTimeout if it is set.
+	// Default Min is 10 milliseconds.
+	Min, Max time.Duration
+	// The number of samples collected for a command family before
+	// adaptive timeout is used. Until then ReadTimeout is used.
+	// Default is 100.
+	MinSamples int
+
+	mu         sync.RWMutex
+	histograms map[string]*latencyHistogram
+}
+
+func (t *AdaptiveTimeout) getPercentile() float64 {
+	if t.Percentile == 0 {
+		return 0.999
+	}
+	return t.Percentile
+}
+
+func (t *AdaptiveTimeout) getMultiplier() float64 {
+	if t.Multiplier == 0 {
+		return 3
+	}
+	return t.Multiplier
+}
+
+func (t *AdaptiveTimeout) getMin() time.Duration {
+	if t.Min == 0 {
+		return 10 * time.Millisecond
+	}
+	return t.Min
+}
+
+func (t *AdaptiveTimeout) getMinSamples() uint64 {
+	if t.MinSamples == 0 {
+		return 100
+	}
+	return uint64(t.MinSamples)
+}
+
+func (t *AdaptiveTimeout) histogram(family string) *latencyHistogram {
+	t.mu.RLock()
+	h, ok := t.histograms[family]
+	t.mu.RUnlock()
+	if ok {
+		return h
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.histograms == nil {
+		t.histograms = make(map[string]*latencyHistogram)
+	}
+	h, ok = t.histograms[family]
+	if !ok {
+		h = &latencyHistogram{}
+		t.histograms[family] = h
+	}
+	return h
+}
+
+// Timeout returns read timeout for the command family. fallback is
+// returned until enough samples are collected.
+func (t *AdaptiveTimeout) Timeout(family string, fallback time.Duration) time.Duration {
+	p, total := t.histogram(family).percentile(t.getPercentile())
+	if total < t.getMinSamples() {
+		return fallback
+	}
+
+	timeout := time.Duration(float64(p) * t.getMultiplier())
+	if min := t.getMin(); timeout < min {
+		timeout = min
+	}
+	max := t.Max
+	if max == 0 {
+		max = fallback
+	}
+	if max > 0 && timeout > max {
+		timeout = max
+	}
+	return timeout
+}
+
+// observe records latency of the command. Timed out commands are
+// recorded with the timeout, so timeouts grow when latency increases.
+func (t *AdaptiveTimeout) observe(family string, latency time.Duration, err error) {
+	if err != nil {
+		if netErr, ok := err.(net.Error); !ok || !netErr.Timeout() {
+			return
+		}
+	}
+	t.histogram(family).observe(latency)
+}
@@ -0,0 +1,137 @@
+package redis
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var errCorruptedLog = errors.New("redis: append log is corrupted")
+
+// appendLogHeaderLen is the size of the record length prefix.
+const appendLogHeaderLen = 4
+
+var truncateLogScript = NewScript(`
+local v = redis.call("GETRANGE", KEYS[1], 0, tonumber(ARGV[1]) - 1)
+if v == "" then
+  return redis.call("DEL", KEYS[1])
+end
+redis.call("SET", KEYS[1], v, "KEEPTTL")
+return 1
+`)
+
+type appendLogger interface {
+	scripter
+	getRanger
+	Append(key, value string) *IntCmd
+	Del(keys ...string) *IntCmd
+}
+
+// AppendLog stores records in a string using APPEND. Every record is
+// prefixed with its length encoded as 4 byte big-endian integer.
+type AppendLog struct {
+	c   appendLogger
+	key string
+}
+
+// AppendLog returns a log of records stored at key.
+func (c *Client) AppendLog(key string) *AppendLog {
+	return &AppendLog{c: c, key: key}
+}
+
+// AppendLog returns a log of records stored at key.
+func (c *ClusterClient) AppendLog(key string) *AppendLog {
+	return &AppendLog{c: c, key: key}
+}
+
+// Append appends the record to the log and returns its offset.
+func (l *AppendLog) Append(record string) (int64, error) {
+	b := make([]byte, appendLogHeaderLen, appendLogHeaderLen+len(record))
+	binary.BigEndian.PutUint32(b, uint32(len(record)))
+	b = append(b, record...)
+
+	size, err := l.c.Append(l.key, string(b)).Result()
+	if err != nil {
+		return 0, err
+	}
+	return size - int64(len(b)), nil
+}
+
+// Records returns an iterator over records starting at offset, which
+// must be an offset returned by Append or AppendLogIterator.Offset.
+// Records are fetched in chunks of chunkSize bytes.
+func (l *AppendLog) Records(offset, chunkSize int64) *AppendLogIterator {
+	rd := newRangeReader(l.c, l.key, chunkSize)
+	rd.SetOffset(offset)
+	return &AppendLogIterator{
+		rd:     rd,
+		offset: offset,
+	}
+}
+
+// Truncate keeps first size bytes of the log, e.g. offset of the first
+// record to discard. Log is deleted when size is zero.
+func (l *AppendLog) Truncate(size int64) error {
+	if size <= 0 {
+		return l.c.Del(l.key).Err()
+	}
+	return truncateLogScript.Run(l.c, []string{l.key}, []string{formatInt(size)}).Err()
+}
+
+// AppendLogIterator iterates over records of AppendLog.
+type AppendLogIterator struct {
+	rd *RangeReader
+
+	offset int64
+	record string
+	err    error
+}
+
+// Next reads next record. It returns false when there are no more
+// records or an error occurs.
+func (it *AppendLogIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	header := make([]byte, appendLogHeaderLen)
+	if _, err := io.ReadFull(it.rd, header); err != nil {
+		if err != io.EOF {
+			it.setErr(err)
+		}
+		return false
+	}
+
+	b := make([]byte, binary.BigEndian.Uint32(header))
+	if _, err := io.ReadFull(it.rd, b); err != nil {
+		it.setErr(err)
+		return false
+	}
+
+	it.record = string(b)
+	it.offset += int64(appendLogHeaderLen + len(b))
+	return true
+}
+
+func (it *AppendLogIterator) setErr(err error) {
+	if err == io.ErrUnexpectedEOF {
+		err = errCorruptedLog
+	}
+	it.err = err
+}
+
+// Val returns the record read by the last call to Next.
+func (it *AppendLogIterator) Val() string {
+	return it.record
+}
+
+// Offset returns the offset of the next record. It can be saved to
+// resume iteration later.
+func (it *AppendLogIterator) Offset() int64 {
+	return it.offset
+}
+
+// Err returns the error occurred while reading records.
+func (it *AppendLogIterator) Err() error {
+	return it.err
+}
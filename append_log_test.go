@@ -0,0 +1,81 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("AppendLog", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	records := func(it *redis.AppendLogIterator) []string {
+		var vals []string
+		for it.Next() {
+			vals = append(vals, it.Val())
+		}
+		Expect(it.Err()).NotTo(HaveOccurred())
+		return vals
+	}
+
+	It("should append and iterate records", func() {
+		log := client.AppendLog("log")
+
+		offset, err := log.Append("hello")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offset).To(Equal(int64(0)))
+
+		offset, err = log.Append("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offset).To(Equal(int64(9)))
+
+		offset, err = log.Append("world")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offset).To(Equal(int64(13)))
+
+		it := log.Records(0, 3)
+		Expect(records(it)).To(Equal([]string{"hello", "", "world"}))
+		Expect(it.Offset()).To(Equal(int64(22)))
+
+		Expect(records(log.Records(13, 100))).To(Equal([]string{"world"}))
+
+		// Iterator picks up records appended later.
+		_, err = log.Append("!")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records(it)).To(Equal([]string{"!"}))
+	})
+
+	It("should truncate log", func() {
+		log := client.AppendLog("log")
+		_, err := log.Append("hello")
+		Expect(err).NotTo(HaveOccurred())
+		offset, err := log.Append("world")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(log.Truncate(offset)).NotTo(HaveOccurred())
+		Expect(records(log.Records(0, 100))).To(Equal([]string{"hello"}))
+
+		Expect(log.Truncate(0)).NotTo(HaveOccurred())
+		Expect(client.Exists("log").Val()).To(BeFalse())
+	})
+
+	It("should detect corrupted log", func() {
+		Expect(client.Set("log", "\x00\x00\x00\x05hel", 0).Err()).NotTo(HaveOccurred())
+
+		it := client.AppendLog("log").Records(0, 100)
+		Expect(it.Next()).To(BeFalse())
+		Expect(it.Err()).To(MatchError("redis: append log is corrupted"))
+	})
+})
@@ -0,0 +1,156 @@
+// Package audit writes a structured journal of commands processed by
+// Redis. Commands are consumed either from MONITOR or from the
+// replication stream and are written as JSON lines.
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/redis.v3"
+	"gopkg.in/redis.v3/replica"
+)
+
+// Entry is a single line of the journal.
+type Entry struct {
+	Time time.Time `json:"time"`
+	DB   int       `json:"db"`
+	// Address of the client that issued the command. Not available
+	// for replication stream.
+	Addr string `json:"addr,omitempty"`
+	// Lower-cased command name.
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	// Replication offset after the command. Only available for
+	// replication stream.
+	Offset int64 `json:"offset,omitempty"`
+}
+
+// Key returns the first command argument which for most commands is
+// the key.
+func (e *Entry) Key() string {
+	if len(e.Args) > 0 {
+		return e.Args[0]
+	}
+	return ""
+}
+
+// Options are used to configure a journal.
+type Options struct {
+	// Commands to record, e.g. "set" or "del". Names are case
+	// insensitive. Default is to record all commands.
+	Commands []string
+	// Only commands with a key having one of the prefixes are recorded.
+	// Key is assumed to be the first argument of the command. Default
+	// is to record all keys.
+	KeyPrefixes []string
+}
+
+// Journal writes entries as JSON lines. It is safe for concurrent use.
+type Journal struct {
+	opt      *Options
+	commands map[string]struct{}
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJournal returns a journal writing to w.
+func NewJournal(w io.Writer, opt *Options) *Journal {
+	if opt == nil {
+		opt = &Options{}
+	}
+	j := &Journal{
+		opt: opt,
+		enc: json.NewEncoder(w),
+	}
+	if len(opt.Commands) > 0 {
+		j.commands = make(map[string]struct{}, len(opt.Commands))
+		for _, name := range opt.Commands {
+			j.commands[strings.ToLower(name)] = struct{}{}
+		}
+	}
+	return j
+}
+
+func (j *Journal) match(e *Entry) bool {
+	if j.commands != nil {
+		if _, ok := j.commands[e.Command]; !ok {
+			return false
+		}
+	}
+	if len(j.opt.KeyPrefixes) > 0 {
+		if len(e.Args) == 0 {
+			return false
+		}
+		key := e.Key()
+		for _, prefix := range j.opt.KeyPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Write writes entry to the journal unless it is filtered out.
+func (j *Journal) Write(e *Entry) error {
+	if !j.match(e) {
+		return nil
+	}
+	j.mu.Lock()
+	err := j.enc.Encode(e)
+	j.mu.Unlock()
+	return err
+}
+
+// TailMonitor writes commands received from the monitor until an
+// error occurs.
+func (j *Journal) TailMonitor(m *redis.Monitor) error {
+	for {
+		event, err := m.Receive()
+		if err != nil {
+			return err
+		}
+		if len(event.Args) == 0 {
+			continue
+		}
+		err = j.Write(&Entry{
+			Time:    event.Time,
+			DB:      event.DB,
+			Addr:    event.Addr,
+			Command: strings.ToLower(event.Args[0]),
+			Args:    event.Args[1:],
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// TailReplica writes commands received from the replication stream
+// until an error occurs. SELECT commands are not recorded, but are used
+// to track the database of the following commands.
+func (j *Journal) TailReplica(r *replica.Replica) error {
+	var db int
+	return r.Run(func(cmd *replica.Command) error {
+		if cmd.Name() == "select" {
+			if len(cmd.Args) > 1 {
+				db, _ = strconv.Atoi(cmd.Args[1])
+			}
+			return nil
+		}
+		return j.Write(&Entry{
+			Time:    time.Now(),
+			DB:      db,
+			Command: cmd.Name(),
+			Args:    cmd.Args[1:],
+			Offset:  cmd.Offset,
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournalFilter(t *testing.T) {
+	var buf bytes.Buffer
+	j := NewJournal(&buf, &Options{
+		Commands:    []string{"SET", "del"},
+		KeyPrefixes: []string{"user:"},
+	})
+
+	entries := []*Entry{
+		{Command: "set", Args: []string{"user:1", "a"}},
+		{Command: "set", Args: []string{"session:1", "b"}},
+		{Command: "get", Args: []string{"user:1"}},
+		{Command: "del", Args: []string{"user:2"}},
+		{Command: "del"},
+	}
+	for _, e := range entries {
+		if err := j.Write(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, wanted 2: %q", len(lines), lines)
+	}
+
+	var e Entry
+	if err := json.Unmarshal([]byte(lines[1]), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Command != "del" || e.Key() != "user:2" {
+		t.Errorf("got %+v", e)
+	}
+}
+
+func TestJournalFormat(t *testing.T) {
+	var buf bytes.Buffer
+	j := NewJournal(&buf, nil)
+
+	err := j.Write(&Entry{
+		Time:    time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC),
+		DB:      1,
+		Addr:    "127.0.0.1:60866",
+		Command: "incr",
+		Args:    []string{"counter"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wanted := `{"time":"2016-01-02T03:04:05Z","db":1,"addr":"127.0.0.1:60866","command":"incr","args":["counter"]}` + "\n"
+	if buf.String() != wanted {
+		t.Errorf("got %s, wanted %s", buf.String(), wanted)
+	}
+}
@@ -0,0 +1,139 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errBitOpNotKeys = errors.New("redis: BITOP NOT requires exactly one key")
+
+// BitOpChunkedOptions are used to configure BitOpChunked.
+type BitOpChunkedOptions struct {
+	// Number of bytes processed at a time.
+	// Default is 1MB.
+	ChunkSize int64
+	// Progress is called after every chunk with the number of processed
+	// bytes and the total length of the result.
+	Progress func(done, total int64)
+}
+
+func (opt *BitOpChunkedOptions) getChunkSize() int64 {
+	if opt.ChunkSize <= 0 {
+		return 1 << 20
+	}
+	return opt.ChunkSize
+}
+
+type bitOpChunker interface {
+	getRanger
+	StrLen(key string) *IntCmd
+	SetRange(key string, offset int64, value string) *IntCmd
+	Del(keys ...string) *IntCmd
+}
+
+// BitOpChunked is like BITOP, but reads keys with GETRANGE and writes
+// the result with SETRANGE in chunks, so operations on huge bitmaps
+// don't block the server. op is "AND", "OR", "XOR" or "NOT". Unlike
+// BITOP it is not atomic: concurrent writes to keys may be partially
+// reflected in the result and destKey is incomplete until it returns.
+func (c *Client) BitOpChunked(op, destKey string, keys []string, opt *BitOpChunkedOptions) error {
+	return bitOpChunked(c, op, destKey, keys, opt)
+}
+
+// BitOpChunked is like BITOP, but works in chunks and keys may be in
+// different slots. See Client.BitOpChunked.
+func (c *ClusterClient) BitOpChunked(op, destKey string, keys []string, opt *BitOpChunkedOptions) error {
+	return bitOpChunked(c, op, destKey, keys, opt)
+}
+
+func bitOpChunked(c bitOpChunker, op, destKey string, keys []string, opt *BitOpChunkedOptions) error {
+	if opt == nil {
+		opt = &BitOpChunkedOptions{}
+	}
+	op = strings.ToUpper(op)
+	switch op {
+	case "AND", "OR", "XOR":
+		if len(keys) == 0 {
+			return fmt.Errorf("redis: BITOP %s requires at least one key", op)
+		}
+	case "NOT":
+		if len(keys) != 1 {
+			return errBitOpNotKeys
+		}
+	default:
+		return fmt.Errorf("redis: unsupported BITOP operation: %q", op)
+	}
+
+	// Like BITOP the result is as long as the longest key.
+	var total int64
+	destIsKey := false
+	for _, key := range keys {
+		n, err := c.StrLen(key).Result()
+		if err != nil {
+			return err
+		}
+		if n > total {
+			total = n
+		}
+		if key == destKey {
+			destIsKey = true
+		}
+	}
+
+	// When destKey is one of keys it is at least as long as the result
+	// and is overwritten chunk by chunk after the chunk is read.
+	if !destIsKey || total == 0 {
+		if err := c.Del(destKey).Err(); err != nil {
+			return err
+		}
+	}
+
+	chunkSize := opt.getChunkSize()
+	for offset := int64(0); offset < total; offset += chunkSize {
+		size := chunkSize
+		if offset+size > total {
+			size = total - offset
+		}
+
+		result := make([]byte, size)
+		for i, key := range keys {
+			chunk, err := c.GetRange(key, offset, offset+size-1).Result()
+			if err != nil {
+				return err
+			}
+			bitOpChunk(op, result, chunk, i == 0)
+		}
+
+		if err := c.SetRange(destKey, offset, string(result)).Err(); err != nil {
+			return err
+		}
+		if opt.Progress != nil {
+			opt.Progress(offset+size, total)
+		}
+	}
+	return nil
+}
+
+// bitOpChunk applies op to result and chunk. Bytes missing in chunk
+// are zeros.
+func bitOpChunk(op string, result []byte, chunk string, first bool) {
+	for i := range result {
+		var b byte
+		if i < len(chunk) {
+			b = chunk[i]
+		}
+		switch {
+		case op == "NOT":
+			result[i] = ^b
+		case first:
+			result[i] = b
+		case op == "AND":
+			result[i] &= b
+		case op == "OR":
+			result[i] |= b
+		case op == "XOR":
+			result[i] ^= b
+		}
+	}
+}
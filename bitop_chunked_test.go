@@ -0,0 +1,82 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("BitOpChunked", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+
+		Expect(client.Set("key1", "\xff\x0f\xf0\xaa\x55", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Set("key2", "\x0f\xff\x00", 0).Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	for _, op := range []string{"AND", "OR", "XOR"} {
+		op := op
+
+		It("should match BITOP "+op, func() {
+			var progress []int64
+			err := client.BitOpChunked(op, "dest", []string{"key1", "key2"}, &redis.BitOpChunkedOptions{
+				ChunkSize: 2,
+				Progress: func(done, total int64) {
+					Expect(total).To(Equal(int64(5)))
+					progress = append(progress, done)
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress).To(Equal([]int64{2, 4, 5}))
+
+			var want *redis.IntCmd
+			switch op {
+			case "AND":
+				want = client.BitOpAnd("want", "key1", "key2")
+			case "OR":
+				want = client.BitOpOr("want", "key1", "key2")
+			case "XOR":
+				want = client.BitOpXor("want", "key1", "key2")
+			}
+			Expect(want.Err()).NotTo(HaveOccurred())
+			Expect(client.Get("dest").Val()).To(Equal(client.Get("want").Val()))
+		})
+	}
+
+	It("should match BITOP NOT", func() {
+		err := client.BitOpChunked("not", "key2", []string{"key2"}, &redis.BitOpChunkedOptions{
+			ChunkSize: 2,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Get("key2").Val()).To(Equal("\xf0\x00\xff"))
+	})
+
+	It("should overwrite destination", func() {
+		Expect(client.Set("dest", "0123456789", 0).Err()).NotTo(HaveOccurred())
+		err := client.BitOpChunked("AND", "dest", []string{"key2"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Get("dest").Val()).To(Equal("\x0f\xff\x00"))
+
+		err = client.BitOpChunked("AND", "dest", []string{"nokey"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Exists("dest").Val()).To(BeFalse())
+	})
+
+	It("should validate operation", func() {
+		err := client.BitOpChunked("NOT", "dest", []string{"key1", "key2"}, nil)
+		Expect(err).To(MatchError("redis: BITOP NOT requires exactly one key"))
+
+		err = client.BitOpChunked("NAND", "dest", []string{"key1"}, nil)
+		Expect(err).To(MatchError(`redis: unsupported BITOP operation: "NAND"`))
+	})
+})
@@ -0,0 +1,141 @@
+package redis
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Returns the value with its remaining TTL in one round trip.
+var cacheGetScript = NewScript(`
+local val = redis.call("GET", KEYS[1])
+if not val then
+  return nil
+end
+return {val, redis.call("PTTL", KEYS[1])}
+`)
+
+type cacheClient interface {
+	scripter
+	Set(key string, value interface{}, expiration time.Duration) *StatusCmd
+}
+
+// CacheOptions are used to configure a cache.
+type CacheOptions struct {
+	// How long loaded values are cached.
+	// Default is 1 hour.
+	TTL time.Duration
+	// Values hit with remaining TTL below RefreshAhead are reloaded in
+	// background, so hot keys never expire.
+	// Default is to not refresh values ahead.
+	RefreshAhead time.Duration
+}
+
+func (opt *CacheOptions) getTTL() time.Duration {
+	if opt.TTL == 0 {
+		return time.Hour
+	}
+	return opt.TTL
+}
+
+type cacheCall struct {
+	wg  sync.WaitGroup
+	val string
+	err error
+}
+
+// Cache is a cache-aside helper storing values returned by loaders in
+// Redis. Concurrent loads of the same key within the process are
+// deduplicated, so a miss of a hot key calls the loader once.
+type Cache struct {
+	client cacheClient
+	opt    *CacheOptions
+
+	mu    sync.Mutex
+	calls map[string]*cacheCall
+}
+
+// NewCache returns a cache storing values using the client.
+func NewCache(client cacheClient, opt *CacheOptions) *Cache {
+	if opt == nil {
+		opt = &CacheOptions{}
+	}
+	return &Cache{
+		client: client,
+		opt:    opt,
+		calls:  make(map[string]*cacheCall),
+	}
+}
+
+// Get returns the value of the key. On a miss the value is loaded with
+// loader and cached for TTL. On a hit with remaining TTL below
+// RefreshAhead the cached value is returned and loader is called in
+// background to refresh it.
+func (c *Cache) Get(key string, loader func() (string, error)) (string, error) {
+	v, err := cacheGetScript.Run(c.client, []string{key}, nil).Result()
+	if err == Nil {
+		return c.load(key, loader)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	reply := v.([]interface{})
+	val := reply[0].(string)
+	ttl := time.Duration(reply[1].(int64)) * time.Millisecond
+	if ttl >= 0 && ttl < c.opt.RefreshAhead {
+		c.refresh(key, loader)
+	}
+	return val, nil
+}
+
+// load calls loader once for concurrent callers and caches the value.
+func (c *Cache) load(key string, loader func() (string, error)) (string, error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		call.wg.Wait()
+		return call.val, call.err
+	}
+	call := c.start(key)
+	c.mu.Unlock()
+
+	c.do(key, call, loader)
+	return call.val, call.err
+}
+
+// refresh reloads the value in background unless it is being loaded.
+func (c *Cache) refresh(key string, loader func() (string, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.calls[key]; ok {
+		return
+	}
+	call := c.start(key)
+	go func() {
+		c.do(key, call, loader)
+		if call.err != nil {
+			log.Printf("redis: cache refresh of %q failed: %s", key, call.err)
+		}
+	}()
+}
+
+// start registers a load of the key. c.mu must be held.
+func (c *Cache) start(key string) *cacheCall {
+	call := &cacheCall{}
+	call.wg.Add(1)
+	c.calls[key] = call
+	return call
+}
+
+func (c *Cache) do(key string, call *cacheCall, loader func() (string, error)) {
+	call.val, call.err = loader()
+	if call.err == nil {
+		call.err = c.client.Set(key, call.val, c.opt.getTTL()).Err()
+	}
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+	call.wg.Done()
+}
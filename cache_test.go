@@ -0,0 +1,101 @@
+package redis_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("Cache", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should load missing values once", func() {
+		cache := redis.NewCache(client, &redis.CacheOptions{
+			TTL: time.Minute,
+		})
+
+		var loads int32
+		loader := func() (string, error) {
+			atomic.AddInt32(&loads, 1)
+			time.Sleep(50 * time.Millisecond)
+			return "value", nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				val, err := cache.Get("key", loader)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(val).To(Equal("value"))
+			}()
+		}
+		wg.Wait()
+		Expect(atomic.LoadInt32(&loads)).To(Equal(int32(1)))
+
+		val, err := cache.Get("key", loader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(Equal("value"))
+		Expect(atomic.LoadInt32(&loads)).To(Equal(int32(1)))
+		Expect(client.PTTL("key").Val()).To(BeNumerically("~", time.Minute, time.Second))
+	})
+
+	It("should not cache loader errors", func() {
+		cache := redis.NewCache(client, nil)
+
+		_, err := cache.Get("key", func() (string, error) {
+			return "", errors.New("load failed")
+		})
+		Expect(err).To(MatchError("load failed"))
+		Expect(client.Exists("key").Val()).To(BeFalse())
+	})
+
+	It("should refresh values ahead of expiration", func() {
+		cache := redis.NewCache(client, &redis.CacheOptions{
+			TTL:          time.Minute,
+			RefreshAhead: 10 * time.Second,
+		})
+		Expect(client.Set("key", "old", 5*time.Second).Err()).NotTo(HaveOccurred())
+
+		var loads int32
+		loader := func() (string, error) {
+			atomic.AddInt32(&loads, 1)
+			return "new", nil
+		}
+
+		// Stale value is returned while it is refreshed in background.
+		val, err := cache.Get("key", loader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(Equal("old"))
+
+		Eventually(func() string {
+			return client.Get("key").Val()
+		}).Should(Equal("new"))
+		Expect(client.PTTL("key").Val()).To(BeNumerically(">", 10*time.Second))
+
+		val, err = cache.Get("key", loader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(Equal("new"))
+		Expect(atomic.LoadInt32(&loads)).To(Equal(int32(1)))
+	})
+})
@@ -0,0 +1,115 @@
+// +build chaos
+
+// Package chaos triggers controlled failures on test Redis servers to
+// exercise resilience of applications using the client, e.g. stalled
+// servers, paused clients, disabled persistence and dropped
+// connections. It is only built with the chaos build tag, so it can't
+// end up in production binaries by accident:
+//
+//     go test -tags chaos ./...
+//
+// DEBUG SLEEP requires enable-debug-command to be set on Redis >= 7.0.
+package chaos
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/redis.v3"
+)
+
+// Server triggers failures on the server the client is connected to.
+type Server struct {
+	client *redis.Client
+}
+
+// NewServer returns failure triggers using the client. The client
+// should not be the one under test, because some failures close its
+// connections.
+func NewServer(client *redis.Client) *Server {
+	return &Server{client: client}
+}
+
+// Sleep blocks the server for d using DEBUG SLEEP, so all commands
+// including pings time out. It returns immediately and the returned
+// channel receives the result once the server wakes up.
+func (s *Server) Sleep(d time.Duration) <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		cmd := redis.NewStatusCmd("DEBUG", "SLEEP", formatSec(d))
+		s.client.Process(cmd)
+		ch <- cmd.Err()
+	}()
+	return ch
+}
+
+// Pause suspends processing of commands of all clients for d using
+// CLIENT PAUSE. Unlike Sleep the server keeps accepting connections.
+func (s *Server) Pause(d time.Duration) error {
+	return s.client.ClientPause(d).Err()
+}
+
+// PauseWrites suspends processing of write commands for d. Requires
+// Redis 6.2.
+func (s *Server) PauseWrites(d time.Duration) error {
+	return s.client.ClientPauseWrite(d).Err()
+}
+
+// Unpause resumes processing of commands paused by Pause or
+// PauseWrites.
+func (s *Server) Unpause() error {
+	return s.client.ClientUnpause().Err()
+}
+
+// StopAOF turns off the append only file, so writes are not persisted
+// and are lost when the server restarts.
+func (s *Server) StopAOF() error {
+	return s.client.ConfigSet("appendonly", "no").Err()
+}
+
+// StartAOF turns on the append only file again.
+func (s *Server) StartAOF() error {
+	return s.client.ConfigSet("appendonly", "yes").Err()
+}
+
+// KillConnections closes connections of normal clients except the
+// connection used by Server using CLIENT KILL. It returns the number
+// of closed connections.
+func (s *Server) KillConnections() (int64, error) {
+	cmd := redis.NewIntCmd("CLIENT", "KILL", "TYPE", "normal", "SKIPME", "yes")
+	s.client.Process(cmd)
+	return cmd.Result()
+}
+
+// KillNamedConnections closes connections named with the prefix, e.g.
+// connections of a client created with Options.ClientName set to the
+// prefix. It returns the number of closed connections.
+func (s *Server) KillNamedConnections(prefix string) (int64, error) {
+	list, err := s.client.ClientList().Result()
+	if err != nil {
+		return 0, err
+	}
+	infos, err := redis.ParseClientList(list)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int64
+	for _, info := range infos {
+		if info.Name == "" || !strings.HasPrefix(info.Name, prefix) {
+			continue
+		}
+		cmd := redis.NewIntCmd("CLIENT", "KILL", "ID", strconv.FormatInt(info.ID, 10))
+		s.client.Process(cmd)
+		if err := cmd.Err(); err != nil {
+			return n, err
+		}
+		n += cmd.Val()
+	}
+	return n, nil
+}
+
+func formatSec(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
@@ -0,0 +1,119 @@
+// +build chaos
+
+package chaos
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/redis.v3"
+)
+
+func redisClient(t *testing.T, name string) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:       ":6379",
+		ClientName: name,
+	})
+	if err := client.Ping().Err(); err != nil {
+		t.Skipf("redis is not available: %s", err)
+	}
+	return client
+}
+
+func TestSleep(t *testing.T) {
+	admin := redisClient(t, "")
+	defer admin.Close()
+	client := redis.NewClient(&redis.Options{
+		Addr:        ":6379",
+		ReadTimeout: 100 * time.Millisecond,
+	})
+	defer client.Close()
+
+	done := NewServer(admin).Sleep(500 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	if err := client.Ping().Err(); err == nil {
+		t.Fatal("expected ping to time out")
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPause(t *testing.T) {
+	admin := redisClient(t, "")
+	defer admin.Close()
+	client := redis.NewClient(&redis.Options{
+		Addr:        ":6379",
+		ReadTimeout: 100 * time.Millisecond,
+	})
+	defer client.Close()
+
+	s := NewServer(admin)
+	if err := s.PauseWrites(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Get("chaos").Err(); err != nil && err != redis.Nil {
+		t.Fatalf("reads should not be paused: %s", err)
+	}
+	if err := client.Set("chaos", "value", 0).Err(); err == nil {
+		t.Fatal("expected write to time out")
+	}
+	if err := s.Unpause(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAOF(t *testing.T) {
+	admin := redisClient(t, "")
+	defer admin.Close()
+
+	s := NewServer(admin)
+	if err := s.StartAOF(); err != nil {
+		t.Fatal(err)
+	}
+	if got := admin.ConfigGet("appendonly").Val(); got[1] != "yes" {
+		t.Fatalf("got appendonly %v, wanted yes", got[1])
+	}
+	if err := s.StopAOF(); err != nil {
+		t.Fatal(err)
+	}
+	if got := admin.ConfigGet("appendonly").Val(); got[1] != "no" {
+		t.Fatalf("got appendonly %v, wanted no", got[1])
+	}
+}
+
+func TestKillNamedConnections(t *testing.T) {
+	admin := redisClient(t, "")
+	defer admin.Close()
+	client := redisClient(t, "chaos-victim")
+	defer client.Close()
+
+	n, err := NewServer(admin).KillNamedConnections("chaos-victim-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("got %d killed connections, wanted 1", n)
+	}
+	if err := admin.Ping().Err(); err != nil {
+		t.Fatalf("admin connection should not be killed: %s", err)
+	}
+}
+
+func TestKillConnections(t *testing.T) {
+	admin := redisClient(t, "")
+	defer admin.Close()
+	client := redisClient(t, "")
+	defer client.Close()
+
+	n, err := NewServer(admin).KillConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n < 1 {
+		t.Fatalf("got %d killed connections, wanted at least 1", n)
+	}
+	if err := admin.Ping().Err(); err != nil {
+		t.Fatalf("admin connection should not be killed: %s", err)
+	}
+}
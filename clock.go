@@ -0,0 +1,36 @@
+package redis
+
+import "time"
+
+// Clock is a source of time used by the client for idle connection
+// reaping, reconnect backoff, latency measurement of adaptive timeouts
+// and by TTL based helpers. Tests can replace it with a fake clock to
+// make time dependent behaviour deterministic.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func since(clock Clock, tm time.Time) time.Duration {
+	return clock.Now().Sub(tm)
+}
+
+// after returns a channel receiving the time once d elapses on the
+// clock. Other clocks are slept in a goroutine, so fake clocks fire it
+// when they are advanced.
+func after(clock Clock, d time.Duration) <-chan time.Time {
+	if _, ok := clock.(systemClock); ok {
+		return time.After(d)
+	}
+	ch := make(chan time.Time, 1)
+	go func() {
+		clock.Sleep(d)
+		ch <- clock.Now()
+	}()
+	return ch
+}
@@ -0,0 +1,144 @@
+package redis_test
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+// fakeClock is a manually advanced clock. Sleep blocks until the clock
+// is advanced past the wake up time, so loops sleeping on the clock,
+// e.g. reconnect, don't spin.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	advance *sync.Cond
+}
+
+func newFakeClock() *fakeClock {
+	c := &fakeClock{now: time.Unix(1000000000, 0)}
+	c.advance = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wake := c.now.Add(d)
+	for c.now.Before(wake) {
+		c.advance.Wait()
+	}
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.advance.Broadcast()
+	c.mu.Unlock()
+}
+
+var _ = Describe("Clock", func() {
+	var clock *fakeClock
+	var client *redis.Client
+
+	BeforeEach(func() {
+		clock = newFakeClock()
+		client = redis.NewClient(&redis.Options{
+			Addr:        redisAddr,
+			IdleTimeout: time.Minute,
+			Clock:       clock,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should close idle connections using the clock", func() {
+		pool := client.Pool()
+		Expect(pool.Len()).To(Equal(1))
+
+		clock.Advance(30 * time.Second)
+		cn := pool.First()
+		Expect(cn).NotTo(BeNil())
+		Expect(pool.Put(cn)).NotTo(HaveOccurred())
+		Expect(pool.Len()).To(Equal(1))
+
+		clock.Advance(2 * time.Minute)
+		Expect(pool.First()).To(BeNil())
+		Expect(pool.Len()).To(Equal(0))
+	})
+
+	It("should expire presence using the clock", func() {
+		presence := redis.NewPresence(client, "online", &redis.PresenceOptions{
+			TTL:   time.Minute,
+			Clock: clock,
+		})
+		Expect(presence.Heartbeat("alice")).NotTo(HaveOccurred())
+
+		clock.Advance(30 * time.Second)
+		ok, err := presence.IsOnline("alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		clock.Advance(time.Minute)
+		ok, err = presence.IsOnline("alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should refresh TTL watcher using the clock", func() {
+		keys := make(chan string, 1)
+		watcher := redis.NewTTLWatcher(client, func(key string, ttl time.Duration) {
+			keys <- key
+		}, &redis.TTLWatcherOptions{
+			Before:          time.Minute,
+			RefreshInterval: time.Hour,
+		})
+		defer watcher.Close()
+
+		Expect(client.Set("key", "v", 0).Err()).NotTo(HaveOccurred())
+		watcher.Watch("key")
+		Consistently(keys, "100ms").ShouldNot(Receive())
+
+		Expect(client.Expire("key", 30*time.Second).Err()).NotTo(HaveOccurred())
+		clock.Advance(time.Hour)
+		Eventually(keys).Should(Receive(Equal("key")))
+	})
+
+	It("should time out semaphore acquire using the clock", func() {
+		sem := redis.NewSemaphore(client, "sem", 1, &redis.SemaphoreOptions{
+			Clock: clock,
+		})
+		token, err := sem.TryAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).NotTo(BeEmpty())
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := sem.Acquire(0, time.Minute)
+			done <- err
+		}()
+
+		Eventually(func() error {
+			clock.Advance(time.Minute)
+			select {
+			case err := <-done:
+				return err
+			default:
+				return nil
+			}
+		}).Should(Equal(redis.ErrSemaphoreTimeout))
+	})
+})
@@ -1,6 +1,8 @@
 package redis
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"math/rand"
 	"strings"
@@ -9,6 +11,8 @@ import (
 	"time"
 )
 
+var errNoAddrs = errors.New("redis: cluster has no node addresses")
+
 type ClusterClient struct {
 	commandable
 
@@ -20,10 +24,21 @@ type ClusterClient struct {
 	closed    bool
 	clientsMx sync.RWMutex // Protects clients and closed.
 
+	scripts   map[string]*nodeScripts
+	scriptsMx sync.Mutex // Protects scripts.
+
+	replicas replicaStatuses
+
 	opt *ClusterOptions
 
+	endpoints EndpointProvider
+	stopWatch func()
+
 	// Reports where slots reloading is in progress.
 	reloading uint32
+
+	// Number of reads retried on master after replica failure.
+	fallbacks int64
 }
 
 // NewClusterClient returns a new Redis Cluster client as described in
@@ -34,10 +49,18 @@ func NewClusterClient(opt *ClusterOptions) *ClusterClient {
 		slots:   make([][]string, hashSlots),
 		clients: make(map[string]*Client),
 		opt:     opt,
+
+		endpoints: endpointProvider(opt.Endpoints, opt.DiscoverSRV, opt.SRVRefreshInterval),
 	}
 	client.commandable.process = client.process
+	if client.endpoints != nil {
+		client.stopWatch = client.endpoints.Watch(client.addAddrs)
+	}
 	client.reloadSlots()
 	go client.reaper()
+	if opt.ReadOnly {
+		go client.replicaMonitor()
+	}
 	return client
 }
 
@@ -53,6 +76,9 @@ func (c *ClusterClient) Close() error {
 		return nil
 	}
 	c.closed = true
+	if c.stopWatch != nil {
+		c.stopWatch()
+	}
 	c.resetClients()
 	c.setSlots(nil)
 	return nil
@@ -90,6 +116,47 @@ func (c *ClusterClient) getClient(addr string) (*Client, error) {
 	return client, nil
 }
 
+// NodeClient returns the client of the cluster node with the address,
+// e.g. to run CONFIG GET or SLOWLOG GET on that node. The client uses
+// the connection pool managed by the cluster client and must not be
+// closed.
+func (c *ClusterClient) NodeClient(addr string) (*Client, error) {
+	if !c.isNodeAddr(addr) {
+		return nil, fmt.Errorf("redis: %s is not a known cluster node", addr)
+	}
+	return c.getClient(addr)
+}
+
+// OnNode calls fn with the client of the cluster node with the
+// address. See NodeClient.
+func (c *ClusterClient) OnNode(addr string, fn func(client *Client) error) error {
+	client, err := c.NodeClient(addr)
+	if err != nil {
+		return err
+	}
+	return fn(client)
+}
+
+func (c *ClusterClient) isNodeAddr(addr string) bool {
+	if addr == "" {
+		return false
+	}
+	for _, nodeAddr := range c.nodeAddrs() {
+		if nodeAddr == addr {
+			return true
+		}
+	}
+
+	c.slotsMx.RLock()
+	defer c.slotsMx.RUnlock()
+	for _, nodeAddr := range c.addrs {
+		if nodeAddr == addr {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *ClusterClient) slotAddrs(slot int) []string {
 	c.slotsMx.RLock()
 	addrs := c.slots[slot]
@@ -105,8 +172,41 @@ func (c *ClusterClient) slotMasterAddr(slot int) string {
 	return ""
 }
 
+// discoverAddrs adds node addresses returned by the endpoint provider.
+func (c *ClusterClient) discoverAddrs() {
+	if c.endpoints == nil {
+		return
+	}
+	addrs, err := c.endpoints.Addrs()
+	if err != nil {
+		log.Printf("redis: EndpointProvider.Addrs failed: %s", err)
+		return
+	}
+	c.slotsMx.Lock()
+	c.addrs = mergeAddrs(c.addrs, addrs)
+	c.slotsMx.Unlock()
+}
+
+// addAddrs adds node addresses and reloads slots when new nodes are
+// discovered.
+func (c *ClusterClient) addAddrs(addrs []string) {
+	c.slotsMx.Lock()
+	n := len(c.addrs)
+	c.addrs = mergeAddrs(c.addrs, addrs)
+	added := len(c.addrs) > n
+	c.slotsMx.Unlock()
+
+	if added {
+		c.lazyReloadSlots()
+	}
+}
+
 // randomClient returns a Client for the first live node.
 func (c *ClusterClient) randomClient() (client *Client, err error) {
+	c.discoverAddrs()
+	if len(c.addrs) == 0 {
+		return nil, errNoAddrs
+	}
 	for i := 0; i < 10; i++ {
 		n := rand.Intn(len(c.addrs))
 		client, err = c.getClient(c.addrs[n])
@@ -122,11 +222,31 @@ func (c *ClusterClient) randomClient() (client *Client, err error) {
 }
 
 func (c *ClusterClient) process(cmd Cmder) {
+	consistency := Strong
+	if c.opt.ReadOnly {
+		consistency = Eventual
+	}
+	c.processConsistency(cmd, consistency)
+}
+
+func (c *ClusterClient) processConsistency(cmd Cmder, consistency Consistency) {
+	if err := checkCrossSlot(cmd); err != nil {
+		cmd.setErr(err)
+		return
+	}
+
 	var ask bool
 
 	slot := hashSlot(cmd.clusterKey())
 
-	addr := c.slotMasterAddr(slot)
+	var addr string
+	var replica bool
+	if isReadOnlyCommand(cmd.Name()) {
+		addr = c.slotReadAddr(slot, consistency)
+		replica = addr != c.slotMasterAddr(slot)
+	} else {
+		addr = c.slotMasterAddr(slot)
+	}
 	client, err := c.getClient(addr)
 	if err != nil {
 		cmd.setErr(err)
@@ -154,6 +274,17 @@ func (c *ClusterClient) process(cmd Cmder) {
 			return
 		}
 
+		// Retry failed read once on master.
+		if replica && isReplicaError(err) {
+			replica = false
+			atomic.AddInt64(&c.fallbacks, 1)
+			client, err = c.getClient(c.slotMasterAddr(slot))
+			if err != nil {
+				return
+			}
+			continue
+		}
+
 		// On network errors try random node.
 		if isNetworkError(err) {
 			client, err = c.randomClient()
@@ -269,6 +400,140 @@ func (c *ClusterClient) reaper() {
 	}
 }
 
+// masterAddrs returns addresses of the masters serving slots.
+func (c *ClusterClient) masterAddrs() []string {
+	c.slotsMx.RLock()
+	defer c.slotsMx.RUnlock()
+
+	var addrs []string
+	seen := make(map[string]struct{})
+	for _, slotAddrs := range c.slots {
+		if len(slotAddrs) == 0 {
+			continue
+		}
+		addr := slotAddrs[0]
+		if _, ok := seen[addr]; !ok {
+			seen[addr] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// RandomKeys returns up to n distinct random keys sampled from all
+// cluster masters proportionally to the number of keys they store.
+func (c *ClusterClient) RandomKeys(n int) ([]string, error) {
+	addrs := c.masterAddrs()
+	clients := make([]*Client, len(addrs))
+	sizes := make([]int64, len(addrs))
+	var total int64
+	for i, addr := range addrs {
+		client, err := c.getClient(addr)
+		if err != nil {
+			return nil, err
+		}
+		size, err := client.DbSize().Result()
+		if err != nil {
+			return nil, err
+		}
+		clients[i] = client
+		sizes[i] = size
+		total += size
+	}
+	if total == 0 || n <= 0 {
+		return nil, nil
+	}
+
+	var keys []string
+	for i, quota := range sampleQuotas(sizes, total, n) {
+		if quota == 0 {
+			continue
+		}
+		sample, err := sampleKeys(clients[i], quota, sizes[i])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, sample...)
+	}
+	return keys, nil
+}
+
+// sampleQuotas distributes n between nodes proportionally to sizes
+// using the largest remainder method.
+func sampleQuotas(sizes []int64, total int64, n int) []int {
+	if int64(n) > total {
+		n = int(total)
+	}
+
+	quotas := make([]int, len(sizes))
+	remainders := make([]int64, len(sizes))
+	left := n
+	for i, size := range sizes {
+		quotas[i] = int(int64(n) * size / total)
+		remainders[i] = int64(n) * size % total
+		left -= quotas[i]
+	}
+	for ; left > 0; left-- {
+		max := -1
+		for i, r := range remainders {
+			if int64(quotas[i]) < sizes[i] && (max == -1 || r > remainders[max]) {
+				max = i
+			}
+		}
+		quotas[max]++
+		remainders[max] = -1
+	}
+	return quotas
+}
+
+// sampleKeys returns up to n distinct random keys stored on the node.
+func sampleKeys(client *Client, n int, size int64) ([]string, error) {
+	// Scanning is cheaper when most of the keys are sampled.
+	if int64(n)*2 >= size {
+		var keys []string
+		var cursor int64
+		for {
+			var page []string
+			var err error
+			cursor, page, err = client.Scan(cursor, "", 100).Result()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, page...)
+			if cursor == 0 {
+				break
+			}
+		}
+		for i := range keys {
+			j := rand.Intn(i + 1)
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+		if len(keys) > n {
+			keys = keys[:n]
+		}
+		return keys, nil
+	}
+
+	keys := make([]string, 0, n)
+	seen := make(map[string]struct{}, n)
+	// RANDOMKEY may return the same key many times, so the number of
+	// attempts is limited.
+	for attempt := 0; len(keys) < n && attempt < 10*n; attempt++ {
+		key, err := client.RandomKey().Result()
+		if err == Nil {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
 //------------------------------------------------------------------------------
 
 // ClusterOptions are used to configure a cluster client and should be
@@ -276,23 +541,57 @@ func (c *ClusterClient) reaper() {
 type ClusterOptions struct {
 	// A seed list of host:port addresses of cluster nodes.
 	Addrs []string
+	// Optional provider of cluster node addresses used in addition
+	// to Addrs.
+	Endpoints EndpointProvider
+	// Name of DNS SRV record used to discover addresses of cluster
+	// nodes in addition to Addrs. Ignored when Endpoints is set.
+	DiscoverSRV string
+	// Specifies how long addresses discovered via DNS SRV are cached.
+	// Default is 30 seconds.
+	SRVRefreshInterval time.Duration
 
 	// The maximum number of MOVED/ASK redirects to follow before
 	// giving up.
 	// Default is 16
 	MaxRedirects int
 
+	// Enables routing of read-only commands to replica nodes. Reads
+	// from replicas may return stale data. Reads failing on a replica
+	// with LOADING, MASTERDOWN or network errors are retried once on
+	// the master.
+	ReadOnly bool
+	// Interval between recording replication lag of replicas when
+	// ReadOnly is enabled.
+	// Default is 10 seconds.
+	ReplicaStatusInterval time.Duration
+	// Optional hook that is called with the status of every replica
+	// each time replication lag is recorded.
+	OnReplicaStatus func(*ReplicaStatus)
+
 	// Following options are copied from Options struct.
 
-	Password string
+	Password   string
+	ClientName string
 
 	DialTimeout  time.Duration
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 
+	AdaptiveTimeout *AdaptiveTimeout
+
 	PoolSize    int
 	PoolTimeout time.Duration
 	IdleTimeout time.Duration
+
+	OnProcess func(*ProcessInfo)
+	KeyPolicy *KeyPolicy
+
+	DisableDestructiveCommands bool
+
+	Clock      Clock
+	CodecHooks *CodecHooks
+	Encoding   *Encoding
 }
 
 func (opt *ClusterOptions) getMaxRedirects() int {
@@ -305,17 +604,37 @@ func (opt *ClusterOptions) getMaxRedirects() int {
 	return opt.MaxRedirects
 }
 
+func (opt *ClusterOptions) getReplicaStatusInterval() time.Duration {
+	if opt.ReplicaStatusInterval == 0 {
+		return 10 * time.Second
+	}
+	return opt.ReplicaStatusInterval
+}
+
 func (opt *ClusterOptions) clientOptions() *Options {
 	return &Options{
-		Password: opt.Password,
+		Password:   opt.Password,
+		ClientName: opt.ClientName,
+		ReadOnly:   opt.ReadOnly,
 
 		DialTimeout:  opt.DialTimeout,
 		ReadTimeout:  opt.ReadTimeout,
 		WriteTimeout: opt.WriteTimeout,
 
+		AdaptiveTimeout: opt.AdaptiveTimeout,
+
 		PoolSize:    opt.PoolSize,
 		PoolTimeout: opt.PoolTimeout,
 		IdleTimeout: opt.IdleTimeout,
+
+		OnProcess: opt.OnProcess,
+		KeyPolicy: opt.KeyPolicy,
+
+		DisableDestructiveCommands: opt.DisableDestructiveCommands,
+
+		Clock:      opt.Clock,
+		CodecHooks: opt.CodecHooks,
+		Encoding:   opt.Encoding,
 	}
 }
 
@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"sync"
+	"time"
+)
+
+// NodePing is the result of pinging a cluster node.
+type NodePing struct {
+	Latency time.Duration
+	Err     error
+}
+
+// nodeAddrs returns addresses of the masters and replicas serving
+// slots. Known node addresses, e.g. seed addresses, are returned when
+// slots are not loaded yet.
+func (c *ClusterClient) nodeAddrs() []string {
+	c.slotsMx.RLock()
+	defer c.slotsMx.RUnlock()
+
+	var addrs []string
+	seen := make(map[string]struct{})
+	for _, slotAddrs := range c.slots {
+		for _, addr := range slotAddrs {
+			if _, ok := seen[addr]; !ok {
+				seen[addr] = struct{}{}
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	if len(addrs) == 0 {
+		addrs = append(addrs, c.addrs...)
+	}
+	return addrs
+}
+
+// PingAll concurrently pings every master and replica serving slots
+// and returns results by node address. It is meant to be used as a
+// health check verifying that the whole cluster is reachable. Seed
+// addresses are pinged when slots are not loaded yet, e.g. because no
+// node was reachable. Slow nodes are bounded by DialTimeout and
+// ReadTimeout options.
+func (c *ClusterClient) PingAll() map[string]NodePing {
+	addrs := c.nodeAddrs()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	pings := make(map[string]NodePing, len(addrs))
+	for _, addr := range addrs {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+
+			var ping NodePing
+			client, err := c.getClient(addr)
+			if err != nil {
+				ping.Err = err
+			} else {
+				start := time.Now()
+				ping.Err = client.Ping().Err()
+				ping.Latency = time.Since(start)
+			}
+
+			mu.Lock()
+			pings[addr] = ping
+			mu.Unlock()
+		}(addr)
+	}
+	wg.Wait()
+	return pings
+}
@@ -44,8 +44,26 @@ func (pipe *ClusterPipeline) Exec() (cmds []Cmder, retErr error) {
 	cmds = pipe.cmds
 	pipe.cmds = make([]Cmder, 0, 10)
 
+	if policy := pipe.cluster.opt.KeyPolicy; policy != nil {
+		if err := policy.checkCmds(cmds); err != nil {
+			return cmds, err
+		}
+	}
+	if pipe.cluster.opt.DisableDestructiveCommands {
+		if err := checkDestructiveCmds(cmds); err != nil {
+			return cmds, err
+		}
+	}
+
 	cmdsMap := make(map[string][]Cmder)
 	for _, cmd := range cmds {
+		if err := checkCrossSlot(cmd); err != nil {
+			cmd.setErr(err)
+			if retErr == nil {
+				retErr = err
+			}
+			continue
+		}
 		slot := hashSlot(cmd.clusterKey())
 		addr := pipe.cluster.slotMasterAddr(slot)
 		cmdsMap[addr] = append(cmdsMap[addr], cmd)
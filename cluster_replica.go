@@ -0,0 +1,161 @@
+package redis
+
+import (
+	"log"
+	"math/rand"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReplicaStatus describes the replication state of a replica as
+// reported by its master.
+type ReplicaStatus struct {
+	// Address of the replica.
+	Addr string
+	// Address of the master the replica is replicating from.
+	MasterAddr string
+	// Replication state, e.g. "online" or "wait_bgsave".
+	State string
+	// Replication offset acknowledged by the replica.
+	Offset int64
+	// Number of bytes the replica is behind the master.
+	OffsetLag int64
+	// Time since the master received the last acknowledgement from
+	// the replica. The precision is one second.
+	Lag time.Duration
+	// Time when the status was recorded.
+	Time time.Time
+}
+
+type replicaStatuses struct {
+	mu       sync.RWMutex
+	statuses map[string]ReplicaStatus
+}
+
+// slotReplicaAddr returns a random replica address for the slot or
+// the master address if the slot has no replicas.
+func (c *ClusterClient) slotReplicaAddr(slot int) string {
+	addrs := c.slotAddrs(slot)
+	switch len(addrs) {
+	case 0:
+		return ""
+	case 1:
+		return addrs[0]
+	default:
+		return addrs[1+rand.Intn(len(addrs)-1)]
+	}
+}
+
+// ReplicaStatus returns the last recorded status of every replica
+// sorted by address. Statuses are recorded only when ReadOnly option
+// is enabled.
+func (c *ClusterClient) ReplicaStatus() []ReplicaStatus {
+	c.replicas.mu.RLock()
+	statuses := make([]ReplicaStatus, 0, len(c.replicas.statuses))
+	for _, status := range c.replicas.statuses {
+		statuses = append(statuses, status)
+	}
+	c.replicas.mu.RUnlock()
+
+	sort.Sort(replicaStatusesByAddr(statuses))
+	return statuses
+}
+
+// ReplicaFallbacks returns the number of reads that failed on a replica
+// and were retried on the master.
+func (c *ClusterClient) ReplicaFallbacks() int64 {
+	return atomic.LoadInt64(&c.fallbacks)
+}
+
+// replicaMonitor periodically records the replication lag of replicas.
+func (c *ClusterClient) replicaMonitor() {
+	ticker := time.NewTicker(c.opt.getReplicaStatusInterval())
+	defer ticker.Stop()
+	for _ = range ticker.C {
+		c.clientsMx.RLock()
+		closed := c.closed
+		c.clientsMx.RUnlock()
+		if closed {
+			break
+		}
+
+		c.updateReplicaStatus()
+	}
+}
+
+func (c *ClusterClient) updateReplicaStatus() {
+	statuses := make(map[string]ReplicaStatus)
+	for _, addr := range c.masterAddrs() {
+		client, err := c.getClient(addr)
+		if err != nil {
+			return
+		}
+		info, err := client.Info("replication").Result()
+		if err != nil {
+			log.Printf("redis: Info failed: %s", err)
+			continue
+		}
+		for _, status := range parseReplicaStatus(addr, parseInfo(info), time.Now()) {
+			statuses[status.Addr] = status
+		}
+	}
+
+	c.replicas.mu.Lock()
+	c.replicas.statuses = statuses
+	c.replicas.mu.Unlock()
+
+	if c.opt.OnReplicaStatus != nil {
+		for _, status := range statuses {
+			status := status
+			c.opt.OnReplicaStatus(&status)
+		}
+	}
+}
+
+// parseReplicaStatus parses "slaveN" fields of INFO replication
+// reply, e.g. "ip=127.0.0.1,port=7001,state=online,offset=42,lag=0".
+func parseReplicaStatus(master string, info map[string]string, now time.Time) []ReplicaStatus {
+	masterOffset, _ := strconv.ParseInt(info["master_repl_offset"], 10, 64)
+
+	var statuses []ReplicaStatus
+	for i := 0; ; i++ {
+		line, ok := info["slave"+strconv.Itoa(i)]
+		if !ok {
+			break
+		}
+
+		fields := make(map[string]string)
+		for _, field := range strings.Split(line, ",") {
+			if j := strings.IndexByte(field, '='); j > 0 {
+				fields[field[:j]] = field[j+1:]
+			}
+		}
+
+		offset, _ := strconv.ParseInt(fields["offset"], 10, 64)
+		lag, _ := strconv.ParseInt(fields["lag"], 10, 64)
+		status := ReplicaStatus{
+			Addr:       net.JoinHostPort(fields["ip"], fields["port"]),
+			MasterAddr: master,
+			State:      fields["state"],
+			Offset:     offset,
+			Lag:        time.Duration(lag) * time.Second,
+			Time:       now,
+		}
+		if masterOffset > offset {
+			status.OffsetLag = masterOffset - offset
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+type replicaStatusesByAddr []ReplicaStatus
+
+func (s replicaStatusesByAddr) Len() int           { return len(s) }
+func (s replicaStatusesByAddr) Less(i, j int) bool { return s[i].Addr < s[j].Addr }
+func (s replicaStatusesByAddr) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,180 @@
+package redis
+
+import (
+	"strings"
+	"sync"
+)
+
+// nodeScripts tracks scripts loaded on a cluster node.
+type nodeScripts struct {
+	mu     sync.Mutex
+	runID  string
+	loaded map[string]struct{}
+}
+
+func (s *nodeScripts) isLoaded(hash string) bool {
+	s.mu.Lock()
+	_, ok := s.loaded[hash]
+	s.mu.Unlock()
+	return ok
+}
+
+func (s *nodeScripts) setLoaded(hash string, loaded bool) {
+	s.mu.Lock()
+	if loaded {
+		s.loaded[hash] = struct{}{}
+	} else {
+		delete(s.loaded, hash)
+	}
+	s.mu.Unlock()
+}
+
+// checkRunID forgets loaded scripts when node was restarted.
+func (s *nodeScripts) checkRunID(client *Client) error {
+	info, err := client.Info("server").Result()
+	if err != nil {
+		return err
+	}
+	runID := parseInfo(info)["run_id"]
+
+	s.mu.Lock()
+	if s.runID != runID {
+		s.runID = runID
+		s.loaded = make(map[string]struct{})
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+func (c *ClusterClient) nodeScripts(addr string) *nodeScripts {
+	c.scriptsMx.Lock()
+	defer c.scriptsMx.Unlock()
+	if c.scripts == nil {
+		c.scripts = make(map[string]*nodeScripts)
+	}
+	scripts, ok := c.scripts[addr]
+	if !ok {
+		scripts = &nodeScripts{
+			loaded: make(map[string]struct{}),
+		}
+		c.scripts[addr] = scripts
+	}
+	return scripts
+}
+
+// runScript runs the script on the node serving the first key. Scripts
+// are loaded at most once per node unless the node is restarted.
+func (c *ClusterClient) runScript(s *Script, keys []string, args []string) *Cmd {
+	var key string
+	if len(keys) > 0 {
+		key = keys[0]
+	}
+	addr := c.slotMasterAddr(hashSlot(key))
+
+	client, err := c.getClient(addr)
+	if err != nil {
+		cmd := NewCmd()
+		cmd.setErr(err)
+		return cmd
+	}
+	// Slots may not be loaded yet.
+	addr = client.opt.Addr
+
+	scripts := c.nodeScripts(addr)
+	if !scripts.isLoaded(s.hash) {
+		if err := scripts.checkRunID(client); err == nil {
+			if err := s.Load(client).Err(); err == nil {
+				scripts.setLoaded(s.hash, true)
+			}
+		}
+	}
+
+	var cmd *Cmd
+	if len(keys) > 0 {
+		// Cluster client follows redirects.
+		cmd = s.EvalSha(c, keys, args)
+	} else {
+		cmd = s.EvalSha(client, keys, args)
+	}
+	if err := cmd.Err(); err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT ") {
+		scripts.setLoaded(s.hash, false)
+		if len(keys) > 0 {
+			return s.Eval(c, keys, args)
+		}
+		return s.Eval(client, keys, args)
+	}
+	return cmd
+}
+
+// ScriptLoad loads the script on all masters, so EVALSHA works on any
+// node serving the script keys, and returns SHA1 digest of the script.
+func (c *ClusterClient) ScriptLoad(script string) *StringCmd {
+	cmd := NewStringCmd("SCRIPT", "LOAD", script)
+	cmd._clusterKeyPos = 0
+
+	addrs := c.masterAddrs()
+	if len(addrs) == 0 {
+		// Slots are not loaded yet.
+		c.Process(cmd)
+		return cmd
+	}
+	for _, addr := range addrs {
+		client, err := c.getClient(addr)
+		if err != nil {
+			cmd.setErr(err)
+			return cmd
+		}
+		nodeCmd := client.ScriptLoad(script)
+		if err := nodeCmd.Err(); err != nil {
+			cmd.setErr(err)
+			return cmd
+		}
+		cmd.val = nodeCmd.val
+
+		scripts := c.nodeScripts(addr)
+		if err := scripts.checkRunID(client); err == nil {
+			scripts.setLoaded(nodeCmd.Val(), true)
+		}
+	}
+	return cmd
+}
+
+// ScriptExists reports for every SHA1 digest whether the script is
+// loaded on all masters.
+func (c *ClusterClient) ScriptExists(scripts ...string) *BoolSliceCmd {
+	args := make([]interface{}, 2+len(scripts))
+	args[0] = "SCRIPT"
+	args[1] = "EXISTS"
+	for i, script := range scripts {
+		args[2+i] = script
+	}
+	cmd := NewBoolSliceCmd(args...)
+	cmd._clusterKeyPos = 0
+
+	addrs := c.masterAddrs()
+	if len(addrs) == 0 {
+		c.Process(cmd)
+		return cmd
+	}
+	val := make([]bool, len(scripts))
+	for i := range val {
+		val[i] = true
+	}
+	for _, addr := range addrs {
+		client, err := c.getClient(addr)
+		if err != nil {
+			cmd.setErr(err)
+			return cmd
+		}
+		exists, err := client.ScriptExists(scripts...).Result()
+		if err != nil {
+			cmd.setErr(err)
+			return cmd
+		}
+		for i, ok := range exists {
+			val[i] = val[i] && ok
+		}
+	}
+	cmd.val = val
+	return cmd
+}
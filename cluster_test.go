@@ -3,7 +3,8 @@ package redis_test
 import (
 	"math/rand"
 	"net"
-
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -184,6 +185,19 @@ var _ = Describe("Cluster", func() {
 
 	})
 
+	Describe("PingAll", func() {
+		It("should ping seed addresses when slots are not loaded", func() {
+			client := redis.NewClusterClient(&redis.ClusterOptions{
+				Addrs: []string{"127.0.0.1:1"},
+			})
+			defer client.Close()
+
+			pings := client.PingAll()
+			Expect(pings).To(HaveLen(1))
+			Expect(pings["127.0.0.1:1"].Err).To(HaveOccurred())
+		})
+	})
+
 	Describe("Commands", func() {
 
 		It("should CLUSTER SLOTS", func() {
@@ -287,6 +301,360 @@ var _ = Describe("Cluster", func() {
 			Expect(cmds[27].(*redis.DurationCmd).Val()).To(BeNumerically("~", 7*time.Hour, time.Second))
 		})
 
+		It("should return CrossSlotError for keys in different slots", func() {
+			err := client.MGet("A", "B", "{A}C").Err()
+			Expect(err).To(HaveOccurred())
+
+			crossSlot, ok := err.(*redis.CrossSlotError)
+			Expect(ok).To(BeTrue())
+			Expect(crossSlot.Command).To(Equal("MGET"))
+			Expect(crossSlot.Keys).To(Equal([]string{"A", "B", "{A}C"}))
+			Expect(crossSlot.Slots).To(Equal([]int{
+				redis.HashSlot("A"), redis.HashSlot("B"), redis.HashSlot("A"),
+			}))
+			Expect(crossSlot.KeysBySlot()).To(Equal(map[int][]string{
+				redis.HashSlot("A"): {"A", "{A}C"},
+				redis.HashSlot("B"): {"B"},
+			}))
+
+			err = client.MGet("{A}B", "{A}C").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			pipe := client.Pipeline()
+			defer pipe.Close()
+
+			set := pipe.Set("A", "VALUE", 0)
+			mset := pipe.MSet("A", "1", "B", "2")
+			_, err = pipe.Exec()
+			Expect(err).To(BeAssignableToTypeOf(&redis.CrossSlotError{}))
+			Expect(set.Err()).NotTo(HaveOccurred())
+			Expect(mset.Err()).To(Equal(err))
+		})
+
+		It("should keep helper keys in one slot with HashTag option", func() {
+			sem := redis.NewSemaphore(client, "sem", 1, &redis.SemaphoreOptions{
+				Fair: true,
+			})
+			_, err := sem.TryAcquire()
+			Expect(err).To(BeAssignableToTypeOf(&redis.CrossSlotError{}))
+
+			sem = redis.NewSemaphore(client, "sem", 1, &redis.SemaphoreOptions{
+				Fair:    true,
+				HashTag: true,
+			})
+			token, err := sem.TryAcquire()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).NotTo(BeEmpty())
+			Expect(client.Exists("{sem}").Val()).To(BeTrue())
+
+			fences := redis.NewGeoFences(client, "fences", &redis.GeoFencesOptions{
+				HashTag: true,
+			})
+			Expect(fences.Add("catania", 15.087269, 37.502669, 60000)).NotTo(HaveOccurred())
+			names, err := fences.WithinFence(15, 37)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal([]string{"catania"}))
+		})
+
+		It("should exec read-only transaction on replica", func() {
+			Expect(client.Set("{A}1", "hello1", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Set("{A}2", "hello2", 0).Err()).NotTo(HaveOccurred())
+
+			multi, err := client.ReadOnlyMulti("A")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				Expect(multi.Close()).NotTo(HaveOccurred())
+			}()
+
+			Eventually(func() []interface{} {
+				var mget *redis.SliceCmd
+				_, err := multi.Exec(func() error {
+					mget = multi.MGet("{A}1", "{A}2")
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+				return mget.Val()
+			}).Should(Equal([]interface{}{"hello1", "hello2"}))
+
+			_, err = multi.Exec(func() error {
+				multi.Del("{A}1")
+				return nil
+			})
+			Expect(err).To(MatchError("redis: DEL is not allowed in read-only transaction"))
+		})
+
+		It("should get values in chunks grouped by slot", func() {
+			keys := []string{"A", "B", "C", "{A}1", "{B}1", "{C}1"}
+			for _, key := range keys {
+				Expect(client.Set(key, key, 0).Err()).NotTo(HaveOccurred())
+			}
+
+			var gotKeys []string
+			err := client.MGetChunked(keys, 10, func(keys []string, vals []interface{}) error {
+				Expect(keys).To(HaveLen(2))
+				Expect(redis.HashSlot(keys[0])).To(Equal(redis.HashSlot(keys[1])))
+				Expect(vals).To(Equal([]interface{}{keys[0], keys[1]}))
+				gotKeys = append(gotKeys, keys...)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gotKeys).To(Equal([]string{"A", "{A}1", "B", "{B}1", "C", "{C}1"}))
+		})
+
+		It("should set expiration of keys on all nodes", func() {
+			ttls := make(map[string]time.Duration)
+			for _, key := range []string{"A", "B", "C", "{A}1", "{B}1", "{C}1"} {
+				Expect(client.Set(key, key, 0).Err()).NotTo(HaveOccurred())
+				ttls[key] = time.Hour
+			}
+			ttls["missing"] = time.Hour
+
+			res, err := client.ExpireMany(ttls)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(7))
+			Expect(res).To(HaveKeyWithValue("missing", false))
+			for _, key := range []string{"A", "B", "C", "{A}1", "{B}1", "{C}1"} {
+				Expect(res).To(HaveKeyWithValue(key, true))
+				Expect(client.TTL(key).Val()).To(BeNumerically(">", 0))
+			}
+		})
+
+		It("should invalidate tags with keys in different slots", func() {
+			for _, key := range []string{"A", "B", "C", "{A}1"} {
+				Expect(client.Set(key, "hello", 0).Err()).NotTo(HaveOccurred())
+				Expect(client.Tag(key, "letters")).NotTo(HaveOccurred())
+			}
+
+			n, err := client.InvalidateTag("letters")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(4)))
+			for _, key := range []string{"A", "B", "C", "{A}1", "tag:letters"} {
+				Expect(client.Exists(key).Val()).To(BeFalse())
+			}
+		})
+
+		It("should ping all nodes", func() {
+			pings := client.PingAll()
+			Expect(pings).To(HaveLen(len(cluster.ports)))
+			for _, port := range cluster.ports {
+				ping, ok := pings[net.JoinHostPort("127.0.0.1", port)]
+				Expect(ok).To(BeTrue())
+				Expect(ping.Err).NotTo(HaveOccurred())
+				Expect(ping.Latency).To(BeNumerically(">", 0))
+			}
+		})
+
+		It("should run commands on a specific node", func() {
+			addr := net.JoinHostPort("127.0.0.1", cluster.ports[1])
+
+			node, err := client.NodeClient(addr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(node.Ping().Err()).NotTo(HaveOccurred())
+
+			var list string
+			err = client.OnNode(addr, func(node *redis.Client) error {
+				var err error
+				list, err = node.ClientList().Result()
+				return err
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ContainSubstring("laddr=" + addr))
+
+			_, err = client.NodeClient("127.0.0.1:1")
+			Expect(err).To(MatchError("redis: 127.0.0.1:1 is not a known cluster node"))
+		})
+
+		It("should load scripts on all masters", func() {
+			script := redis.NewScript(`return redis.call("GET", KEYS[1])`)
+
+			exists, err := script.Exists(client).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(Equal([]bool{false}))
+
+			Expect(script.Load(client).Err()).NotTo(HaveOccurred())
+			for _, master := range cluster.masters() {
+				Expect(script.Exists(master).Val()).To(Equal([]bool{true}))
+			}
+			exists, err = script.Exists(client).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(Equal([]bool{true}))
+
+			Expect(cluster.masters()[1].ScriptFlush().Err()).NotTo(HaveOccurred())
+			exists, err = script.Exists(client).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(Equal([]bool{false}))
+		})
+
+		It("should load scripts once per node", func() {
+			var mu sync.Mutex
+			families := make(map[string]int)
+			client = cluster.clusterClient(&redis.ClusterOptions{
+				OnProcess: func(info *redis.ProcessInfo) {
+					mu.Lock()
+					families[info.Family]++
+					mu.Unlock()
+				},
+			})
+
+			script := redis.NewScript(`return redis.call("INCR", KEYS[1])`)
+			for i := 0; i < 3; i++ {
+				for _, key := range []string{"B", "C", "D"} {
+					Expect(script.Run(client, []string{key}, nil).Err()).NotTo(HaveOccurred())
+				}
+			}
+			Expect(client.Get("B").Val()).To(Equal("3"))
+
+			mu.Lock()
+			Expect(families["script"]).To(BeNumerically("<=", 3))
+			Expect(families["evalsha"]).To(Equal(9))
+			Expect(families["eval"]).To(Equal(0))
+			mu.Unlock()
+
+			// Flushing scripts is detected on NOSCRIPT error.
+			for _, master := range cluster.masters() {
+				Expect(master.ScriptFlush().Err()).NotTo(HaveOccurred())
+			}
+			Expect(script.Run(client, []string{"B"}, nil).Val()).To(Equal(int64(4)))
+			Expect(script.Run(client, []string{"B"}, nil).Val()).To(Equal(int64(5)))
+
+			mu.Lock()
+			Expect(families["eval"]).To(Equal(1))
+			mu.Unlock()
+		})
+
+		It("should sample random keys from all nodes", func() {
+			for i := 0; i < 30; i++ {
+				key := "key" + strconv.Itoa(i)
+				Expect(client.Set(key, "hello", 0).Err()).NotTo(HaveOccurred())
+			}
+
+			keys, err := client.RandomKeys(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(10))
+
+			addrs := make(map[string]bool)
+			for _, key := range keys {
+				Expect(client.Exists(key).Val()).To(BeTrue())
+				addrs[client.SlotAddrs(redis.HashSlot(key))[0]] = true
+			}
+			Expect(len(addrs)).To(BeNumerically(">", 1))
+
+			keys, err = client.RandomKeys(100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(30))
+		})
+
+		It("should route reads to replicas and record their lag", func() {
+			var mu sync.Mutex
+			var addrs []string
+			var statuses []*redis.ReplicaStatus
+			Expect(client.Close()).NotTo(HaveOccurred())
+			client = cluster.clusterClient(&redis.ClusterOptions{
+				ReadOnly:              true,
+				ReplicaStatusInterval: 100 * time.Millisecond,
+				OnProcess: func(info *redis.ProcessInfo) {
+					mu.Lock()
+					addrs = append(addrs, info.Addr)
+					mu.Unlock()
+				},
+				OnReplicaStatus: func(status *redis.ReplicaStatus) {
+					mu.Lock()
+					statuses = append(statuses, status)
+					mu.Unlock()
+				},
+			})
+
+			Expect(client.Set("A", "VALUE", 0).Err()).NotTo(HaveOccurred())
+			Eventually(func() string {
+				return client.Get("A").Val()
+			}, "5s").Should(Equal("VALUE"))
+
+			mu.Lock()
+			Expect(addrs[0]).To(Equal("127.0.0.1:8221"))
+			Expect(addrs[len(addrs)-1]).To(Equal("127.0.0.1:8224"))
+			mu.Unlock()
+
+			Eventually(func() []redis.ReplicaStatus {
+				return client.ReplicaStatus()
+			}, "5s").Should(HaveLen(3))
+			status := client.ReplicaStatus()[0]
+			Expect(status.Addr).To(Equal("127.0.0.1:8223"))
+			Expect(status.MasterAddr).To(Equal("127.0.0.1:8220"))
+			Expect(status.State).To(Equal("online"))
+
+			mu.Lock()
+			Expect(len(statuses)).To(BeNumerically(">=", 3))
+			mu.Unlock()
+		})
+
+		It("should route reads according to consistency", func() {
+			var mu sync.Mutex
+			var addrs []string
+			lastAddr := func() string {
+				mu.Lock()
+				defer mu.Unlock()
+				return addrs[len(addrs)-1]
+			}
+
+			Expect(client.Close()).NotTo(HaveOccurred())
+			client = cluster.clusterClient(&redis.ClusterOptions{
+				ReadOnly:              true,
+				ReplicaStatusInterval: 100 * time.Millisecond,
+				OnProcess: func(info *redis.ProcessInfo) {
+					mu.Lock()
+					addrs = append(addrs, info.Addr)
+					mu.Unlock()
+				},
+			})
+
+			eventual := client.WithConsistency(redis.Eventual)
+			Expect(eventual.Set("A", "VALUE", 0).Err()).NotTo(HaveOccurred())
+			Expect(lastAddr()).To(Equal("127.0.0.1:8221"))
+
+			Eventually(func() string {
+				return eventual.Get("A").Val()
+			}, "5s").Should(Equal("VALUE"))
+			Expect(lastAddr()).To(Equal("127.0.0.1:8224"))
+
+			Expect(client.WithConsistency(redis.Strong).Get("A").Val()).To(Equal("VALUE"))
+			Expect(lastAddr()).To(Equal("127.0.0.1:8221"))
+
+			Eventually(func() []redis.ReplicaStatus {
+				return client.ReplicaStatus()
+			}, "5s").Should(HaveLen(3))
+			Expect(client.WithConsistency(redis.Bounded(time.Hour)).Get("A").Val()).To(Equal("VALUE"))
+			Expect(lastAddr()).To(Equal("127.0.0.1:8224"))
+		})
+
+		It("should fallback to master when replica fails", func() {
+			Expect(client.Close()).NotTo(HaveOccurred())
+			client = cluster.clusterClient(&redis.ClusterOptions{
+				ReadOnly:    true,
+				ReadTimeout: 200 * time.Millisecond,
+			})
+
+			Expect(client.Set("A", "VALUE", 0).Err()).NotTo(HaveOccurred())
+			Eventually(func() string {
+				return client.Get("A").Val()
+			}, "5s").Should(Equal("VALUE"))
+			Expect(client.ReplicaFallbacks()).To(Equal(int64(0)))
+
+			replica := cluster.clients["8224"]
+			done := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(done)
+				cmd := redis.NewStatusCmd("DEBUG", "SLEEP", "1")
+				replica.Process(cmd)
+				Expect(cmd.Err()).NotTo(HaveOccurred())
+			}()
+			time.Sleep(100 * time.Millisecond)
+
+			Expect(client.Get("A").Val()).To(Equal("VALUE"))
+			Expect(client.ReplicaFallbacks()).To(Equal(int64(1)))
+			<-done
+		})
+
 		It("should return error when there are no attempts left", func() {
 			client = cluster.clusterClient(&redis.ClusterOptions{
 				MaxRedirects: -1,
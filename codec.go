@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// CodecInfo describes a value marshaled to a command argument or
+// unmarshaled from a reply.
+type CodecInfo struct {
+	Type reflect.Type
+	// Size of the marshaled value in bytes.
+	Bytes    int
+	Duration time.Duration
+	Err      error
+}
+
+// CodecHooks are called around value marshaling and unmarshaling and
+// can be used to measure serialization overhead or to catch huge
+// values.
+type CodecHooks struct {
+	// Called after command argument is marshaled. Command is not sent
+	// and fails with the returned error if it is not nil.
+	OnMarshal func(*CodecInfo) error
+	// Called after reply is unmarshaled using Scan.
+	OnUnmarshal func(*CodecInfo)
+}
+
+// codec marshals command arguments and unmarshals replies using
+// options of the client that processed the command. Nil codec uses
+// defaults.
+type codec struct {
+	enc   *Encoding
+	hooks *CodecHooks
+}
+
+func newCodec(opt *Options) *codec {
+	return &codec{
+		enc:   opt.Encoding,
+		hooks: opt.CodecHooks,
+	}
+}
+
+func (c *codec) getEncoding() *Encoding {
+	if c == nil || c.enc == nil {
+		return defaultEncoding
+	}
+	return c.enc
+}
+
+func (c *codec) getHooks() *CodecHooks {
+	if c == nil {
+		return nil
+	}
+	return c.hooks
+}
+
+func (c *codec) appendArgs(b []byte, args []interface{}) ([]byte, error) {
+	b = append(b, '*')
+	b = strconv.AppendUint(b, uint64(len(args)), 10)
+	b = append(b, '\r', '\n')
+	for _, arg := range args {
+		var err error
+		b, err = c.appendArg(b, arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (c *codec) appendArg(b []byte, val interface{}) ([]byte, error) {
+	hooks := c.getHooks()
+	if hooks == nil || hooks.OnMarshal == nil {
+		return appendValue(b, val, c.getEncoding())
+	}
+
+	start := time.Now()
+	n := len(b)
+	b, err := appendValue(b, val, c.getEncoding())
+	info := &CodecInfo{
+		Type:     reflect.TypeOf(val),
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if err == nil {
+		info.Bytes = bulkLen(b[n:])
+	}
+	if err := hooks.OnMarshal(info); err != nil {
+		return nil, err
+	}
+	return b, err
+}
+
+// bulkLen returns length of the bulk string payload.
+func bulkLen(b []byte) int {
+	i := bytes.IndexByte(b, '\r')
+	if i < 1 {
+		return 0
+	}
+	n, _ := strconv.Atoi(string(b[1:i]))
+	return n
+}
+
+func (c *codec) scan(b []byte, val interface{}) error {
+	hooks := c.getHooks()
+	if hooks == nil || hooks.OnUnmarshal == nil {
+		return scanValue(b, val, c.getEncoding())
+	}
+
+	start := time.Now()
+	err := scanValue(b, val, c.getEncoding())
+	hooks.OnUnmarshal(&CodecInfo{
+		Type:     reflect.TypeOf(val),
+		Bytes:    len(b),
+		Duration: time.Since(start),
+		Err:      err,
+	})
+	return err
+}
@@ -0,0 +1,93 @@
+package redis_test
+
+import (
+	"errors"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("CodecHooks", func() {
+	var client *redis.Client
+
+	newClient := func(hooks *redis.CodecHooks) {
+		client = redis.NewClient(&redis.Options{
+			Addr:       redisAddr,
+			CodecHooks: hooks,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	}
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should call hooks", func() {
+		var marshaled, unmarshaled []redis.CodecInfo
+		newClient(&redis.CodecHooks{
+			OnMarshal: func(info *redis.CodecInfo) error {
+				marshaled = append(marshaled, *info)
+				return nil
+			},
+			OnUnmarshal: func(info *redis.CodecInfo) {
+				unmarshaled = append(unmarshaled, *info)
+			},
+		})
+		marshaled = nil
+
+		Expect(client.Set("key", int64(12345), 0).Err()).NotTo(HaveOccurred())
+		Expect(marshaled).To(HaveLen(3))
+		Expect(marshaled[1].Type).To(Equal(reflect.TypeOf("")))
+		Expect(marshaled[1].Bytes).To(Equal(3))
+		Expect(marshaled[2].Type).To(Equal(reflect.TypeOf(int64(0))))
+		Expect(marshaled[2].Bytes).To(Equal(5))
+
+		var n int64
+		Expect(client.Get("key").Scan(&n)).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(12345)))
+		Expect(unmarshaled).To(HaveLen(1))
+		Expect(unmarshaled[0].Type).To(Equal(reflect.TypeOf(&n)))
+		Expect(unmarshaled[0].Bytes).To(Equal(5))
+		Expect(unmarshaled[0].Err).NotTo(HaveOccurred())
+	})
+
+	It("should reject values", func() {
+		newClient(&redis.CodecHooks{
+			OnMarshal: func(info *redis.CodecInfo) error {
+				if info.Bytes > 10 {
+					return errors.New("value is too big")
+				}
+				return nil
+			},
+		})
+
+		err := client.Set("key", "hello world!", 0).Err()
+		Expect(err).To(MatchError("value is too big"))
+		Expect(client.Exists("key").Val()).To(BeFalse())
+	})
+
+	It("should not call hooks of other clients", func() {
+		var marshaled int
+		newClient(&redis.CodecHooks{
+			OnMarshal: func(info *redis.CodecInfo) error {
+				marshaled++
+				return nil
+			},
+		})
+
+		other := redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		defer other.Close()
+
+		marshaled = 0
+		Expect(other.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+		Expect(marshaled).To(Equal(0))
+
+		Expect(client.Get("key").Err()).NotTo(HaveOccurred())
+		Expect(marshaled).To(Equal(2))
+	})
+})
@@ -20,24 +20,44 @@ var (
 	_ Cmder = (*StringCmd)(nil)
 	_ Cmder = (*FloatCmd)(nil)
 	_ Cmder = (*StringSliceCmd)(nil)
+	_ Cmder = (*IntSliceCmd)(nil)
 	_ Cmder = (*BoolSliceCmd)(nil)
 	_ Cmder = (*StringStringMapCmd)(nil)
 	_ Cmder = (*StringIntMapCmd)(nil)
 	_ Cmder = (*ZSliceCmd)(nil)
+	_ Cmder = (*ZWithKeyCmd)(nil)
+	_ Cmder = (*ZSliceWithKeyCmd)(nil)
+	_ Cmder = (*KeyValuesCmd)(nil)
 	_ Cmder = (*ScanCmd)(nil)
 	_ Cmder = (*ClusterSlotCmd)(nil)
+	_ Cmder = (*GeoLocationCmd)(nil)
+	_ Cmder = (*GeoPosCmd)(nil)
+	_ Cmder = (*XMessageSliceCmd)(nil)
+	_ Cmder = (*XStreamSliceCmd)(nil)
+	_ Cmder = (*XPendingCmd)(nil)
+	_ Cmder = (*XPendingExtCmd)(nil)
+	_ Cmder = (*XAutoClaimCmd)(nil)
+	_ Cmder = (*XAutoClaimJustIDCmd)(nil)
+	_ Cmder = (*XInfoStreamCmd)(nil)
+	_ Cmder = (*XInfoGroupsCmd)(nil)
+	_ Cmder = (*XInfoConsumersCmd)(nil)
+	_ Cmder = (*ClientTrackingInfoCmd)(nil)
+	_ Cmder = (*LCSCmd)(nil)
+	_ Cmder = (*MemoryStatsCmd)(nil)
 )
 
 type Cmder interface {
 	args() []interface{}
 	parseReply(*bufio.Reader) error
 	setErr(error)
+	setCodec(*codec)
 	reset()
 
 	writeTimeout() *time.Duration
 	readTimeout() *time.Duration
 	clusterKey() string
 
+	Name() string
 	Err() error
 	fmt.Stringer
 }
@@ -85,6 +105,8 @@ type baseCmd struct {
 	_clusterKeyPos int
 
 	_writeTimeout, _readTimeout *time.Duration
+
+	codec *codec
 }
 
 func (cmd *baseCmd) Err() error {
@@ -98,6 +120,16 @@ func (cmd *baseCmd) args() []interface{} {
 	return cmd._args
 }
 
+// Name returns lower-cased command name, e.g. "get" or "client".
+func (cmd *baseCmd) Name() string {
+	if len(cmd._args) > 0 {
+		if s, ok := cmd._args[0].(string); ok {
+			return strings.ToLower(s)
+		}
+	}
+	return ""
+}
+
 func (cmd *baseCmd) readTimeout() *time.Duration {
 	return cmd._readTimeout
 }
@@ -121,6 +153,10 @@ func (cmd *baseCmd) setWriteTimeout(d time.Duration) {
 	cmd._writeTimeout = &d
 }
 
+func (cmd *baseCmd) setCodec(c *codec) {
+	cmd.codec = c
+}
+
 func (cmd *baseCmd) setErr(e error) {
 	cmd.err = e
 }
@@ -394,6 +430,10 @@ func NewStringCmd(args ...interface{}) *StringCmd {
 	return &StringCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
 }
 
+func newKeylessStringCmd(args ...interface{}) *StringCmd {
+	return &StringCmd{baseCmd: baseCmd{_args: args}}
+}
+
 func (cmd *StringCmd) reset() {
 	cmd.val = nil
 	cmd.err = nil
@@ -436,7 +476,7 @@ func (cmd *StringCmd) Scan(val interface{}) error {
 	if cmd.err != nil {
 		return cmd.err
 	}
-	return scan(cmd.val, val)
+	return cmd.codec.scan(cmd.val, val)
 }
 
 func (cmd *StringCmd) String() string {
@@ -476,6 +516,10 @@ func (cmd *FloatCmd) Val() float64 {
 	return cmd.val
 }
 
+func (cmd *FloatCmd) Result() (float64, error) {
+	return cmd.val, cmd.err
+}
+
 func (cmd *FloatCmd) String() string {
 	return cmdString(cmd, cmd.val)
 }
@@ -532,6 +576,45 @@ func (cmd *StringSliceCmd) parseReply(rd *bufio.Reader) error {
 
 //------------------------------------------------------------------------------
 
+type IntSliceCmd struct {
+	baseCmd
+
+	val []int64
+}
+
+func NewIntSliceCmd(args ...interface{}) *IntSliceCmd {
+	return &IntSliceCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *IntSliceCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *IntSliceCmd) Val() []int64 {
+	return cmd.val
+}
+
+func (cmd *IntSliceCmd) Result() ([]int64, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *IntSliceCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *IntSliceCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseIntSlice)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.([]int64)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
 type BoolSliceCmd struct {
 	baseCmd
 
@@ -688,6 +771,131 @@ func (cmd *ZSliceCmd) parseReply(rd *bufio.Reader) error {
 
 //------------------------------------------------------------------------------
 
+type ZWithKeyCmd struct {
+	baseCmd
+
+	val ZWithKey
+}
+
+func NewZWithKeyCmd(args ...interface{}) *ZWithKeyCmd {
+	return &ZWithKeyCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *ZWithKeyCmd) reset() {
+	cmd.val = ZWithKey{}
+	cmd.err = nil
+}
+
+func (cmd *ZWithKeyCmd) Val() ZWithKey {
+	return cmd.val
+}
+
+func (cmd *ZWithKeyCmd) Result() (ZWithKey, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *ZWithKeyCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *ZWithKeyCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseZWithKey)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.(ZWithKey)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type ZSliceWithKeyCmd struct {
+	baseCmd
+
+	key string
+	val []Z
+}
+
+func NewZSliceWithKeyCmd(args ...interface{}) *ZSliceWithKeyCmd {
+	return &ZSliceWithKeyCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *ZSliceWithKeyCmd) reset() {
+	cmd.key = ""
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *ZSliceWithKeyCmd) Val() (key string, members []Z) {
+	return cmd.key, cmd.val
+}
+
+func (cmd *ZSliceWithKeyCmd) Result() (key string, members []Z, err error) {
+	return cmd.key, cmd.val, cmd.err
+}
+
+func (cmd *ZSliceWithKeyCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *ZSliceWithKeyCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, newKeyValuesParser(parseZPairSlice))
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	reply := v.(*keyValuesReply)
+	cmd.key = reply.key
+	cmd.val = reply.val.([]Z)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type KeyValuesCmd struct {
+	baseCmd
+
+	key string
+	val []string
+}
+
+func NewKeyValuesCmd(args ...interface{}) *KeyValuesCmd {
+	return &KeyValuesCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *KeyValuesCmd) reset() {
+	cmd.key = ""
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *KeyValuesCmd) Val() (key string, vals []string) {
+	return cmd.key, cmd.val
+}
+
+func (cmd *KeyValuesCmd) Result() (key string, vals []string, err error) {
+	return cmd.key, cmd.val, cmd.err
+}
+
+func (cmd *KeyValuesCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *KeyValuesCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, newKeyValuesParser(parseStringSlice))
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	reply := v.(*keyValuesReply)
+	cmd.key = reply.key
+	cmd.val = reply.val.([]string)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
 type ScanCmd struct {
 	baseCmd
 
@@ -781,3 +989,715 @@ func (cmd *ClusterSlotCmd) parseReply(rd *bufio.Reader) error {
 	cmd.val = v.([]ClusterSlotInfo)
 	return nil
 }
+
+//------------------------------------------------------------------------------
+
+type GeoLocationCmd struct {
+	baseCmd
+
+	q         *GeoRadiusQuery
+	locations []GeoLocation
+}
+
+func NewGeoLocationCmd(q *GeoRadiusQuery, args ...interface{}) *GeoLocationCmd {
+	args = append(args, formatFloat(q.Radius))
+	if q.Unit != "" {
+		args = append(args, q.Unit)
+	} else {
+		args = append(args, "km")
+	}
+	if q.WithCoord {
+		args = append(args, "WITHCOORD")
+	}
+	if q.WithDist {
+		args = append(args, "WITHDIST")
+	}
+	if q.WithGeoHash {
+		args = append(args, "WITHHASH")
+	}
+	if q.Count > 0 {
+		args = append(args, "COUNT", formatInt(int64(q.Count)))
+	}
+	if q.Sort != "" {
+		args = append(args, q.Sort)
+	}
+	return &GeoLocationCmd{
+		baseCmd: baseCmd{_args: args, _clusterKeyPos: 1},
+		q:       q,
+	}
+}
+
+func (cmd *GeoLocationCmd) reset() {
+	cmd.locations = nil
+	cmd.err = nil
+}
+
+func (cmd *GeoLocationCmd) Val() []GeoLocation {
+	return cmd.locations
+}
+
+func (cmd *GeoLocationCmd) Result() ([]GeoLocation, error) {
+	return cmd.locations, cmd.err
+}
+
+func (cmd *GeoLocationCmd) String() string {
+	return cmdString(cmd, cmd.locations)
+}
+
+func (cmd *GeoLocationCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, newGeoLocationSliceParser(cmd.q))
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.locations = v.([]GeoLocation)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type GeoPos struct {
+	Longitude, Latitude float64
+}
+
+type GeoPosCmd struct {
+	baseCmd
+
+	positions []*GeoPos
+}
+
+func NewGeoPosCmd(args ...interface{}) *GeoPosCmd {
+	return &GeoPosCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *GeoPosCmd) reset() {
+	cmd.positions = nil
+	cmd.err = nil
+}
+
+// Val returns positions of members. Position is nil if member does
+// not exist.
+func (cmd *GeoPosCmd) Val() []*GeoPos {
+	return cmd.positions
+}
+
+func (cmd *GeoPosCmd) Result() ([]*GeoPos, error) {
+	return cmd.positions, cmd.err
+}
+
+func (cmd *GeoPosCmd) String() string {
+	return cmdString(cmd, cmd.positions)
+}
+
+func (cmd *GeoPosCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseGeoPosSlice)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.positions = v.([]*GeoPos)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type XMessage struct {
+	ID     string
+	Values map[string]interface{}
+}
+
+type XMessageSliceCmd struct {
+	baseCmd
+
+	val []XMessage
+}
+
+func NewXMessageSliceCmd(args ...interface{}) *XMessageSliceCmd {
+	return &XMessageSliceCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *XMessageSliceCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *XMessageSliceCmd) Val() []XMessage {
+	return cmd.val
+}
+
+func (cmd *XMessageSliceCmd) Result() ([]XMessage, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *XMessageSliceCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *XMessageSliceCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseXMessageSlice)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.([]XMessage)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type XStream struct {
+	Stream   string
+	Messages []XMessage
+}
+
+type XStreamSliceCmd struct {
+	baseCmd
+
+	val []XStream
+}
+
+func NewXStreamSliceCmd(args ...interface{}) *XStreamSliceCmd {
+	return &XStreamSliceCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *XStreamSliceCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *XStreamSliceCmd) Val() []XStream {
+	return cmd.val
+}
+
+func (cmd *XStreamSliceCmd) Result() ([]XStream, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *XStreamSliceCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *XStreamSliceCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseXStreamSlice)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.([]XStream)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// XPending is a summary of messages pending in consumer group.
+type XPending struct {
+	Count int64
+	// Smallest and greatest IDs of pending messages.
+	Lower, Higher string
+	// Number of pending messages per consumer.
+	Consumers map[string]int64
+}
+
+type XPendingCmd struct {
+	baseCmd
+
+	val *XPending
+}
+
+func NewXPendingCmd(args ...interface{}) *XPendingCmd {
+	return &XPendingCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *XPendingCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *XPendingCmd) Val() *XPending {
+	return cmd.val
+}
+
+func (cmd *XPendingCmd) Result() (*XPending, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *XPendingCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *XPendingCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseXPending)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.(*XPending)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// XPendingExt is a message pending in consumer group.
+type XPendingExt struct {
+	ID       string
+	Consumer string
+	// Time since the message was last delivered.
+	Idle time.Duration
+	// Number of times the message was delivered.
+	RetryCount int64
+}
+
+type XPendingExtCmd struct {
+	baseCmd
+
+	val []XPendingExt
+}
+
+func NewXPendingExtCmd(args ...interface{}) *XPendingExtCmd {
+	return &XPendingExtCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *XPendingExtCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *XPendingExtCmd) Val() []XPendingExt {
+	return cmd.val
+}
+
+func (cmd *XPendingExtCmd) Result() ([]XPendingExt, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *XPendingExtCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *XPendingExtCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseXPendingExtSlice)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.([]XPendingExt)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type XAutoClaimCmd struct {
+	baseCmd
+
+	start string
+	val   []XMessage
+}
+
+func NewXAutoClaimCmd(args ...interface{}) *XAutoClaimCmd {
+	return &XAutoClaimCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *XAutoClaimCmd) reset() {
+	cmd.start = ""
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *XAutoClaimCmd) Val() (messages []XMessage, start string) {
+	return cmd.val, cmd.start
+}
+
+func (cmd *XAutoClaimCmd) Result() (messages []XMessage, start string, err error) {
+	return cmd.val, cmd.start, cmd.err
+}
+
+func (cmd *XAutoClaimCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *XAutoClaimCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, newXAutoClaimParser(parseXMessageSlice))
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	reply := v.(*xAutoClaimReply)
+	cmd.start = reply.start
+	cmd.val = reply.val.([]XMessage)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type XAutoClaimJustIDCmd struct {
+	baseCmd
+
+	start string
+	val   []string
+}
+
+func NewXAutoClaimJustIDCmd(args ...interface{}) *XAutoClaimJustIDCmd {
+	return &XAutoClaimJustIDCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *XAutoClaimJustIDCmd) reset() {
+	cmd.start = ""
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *XAutoClaimJustIDCmd) Val() (ids []string, start string) {
+	return cmd.val, cmd.start
+}
+
+func (cmd *XAutoClaimJustIDCmd) Result() (ids []string, start string, err error) {
+	return cmd.val, cmd.start, cmd.err
+}
+
+func (cmd *XAutoClaimJustIDCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *XAutoClaimJustIDCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, newXAutoClaimParser(parseStringSlice))
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	reply := v.(*xAutoClaimReply)
+	cmd.start = reply.start
+	cmd.val = reply.val.([]string)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type XInfoStream struct {
+	Length          int64
+	RadixTreeKeys   int64
+	RadixTreeNodes  int64
+	Groups          int64
+	LastGeneratedID string
+	// First and last entries are nil when the stream is empty.
+	FirstEntry *XMessage
+	LastEntry  *XMessage
+}
+
+type XInfoStreamCmd struct {
+	baseCmd
+
+	val *XInfoStream
+}
+
+func NewXInfoStreamCmd(args ...interface{}) *XInfoStreamCmd {
+	return &XInfoStreamCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *XInfoStreamCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *XInfoStreamCmd) Val() *XInfoStream {
+	return cmd.val
+}
+
+func (cmd *XInfoStreamCmd) Result() (*XInfoStream, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *XInfoStreamCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *XInfoStreamCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseXInfoStream)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.(*XInfoStream)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type XInfoGroup struct {
+	Name            string
+	Consumers       int64
+	Pending         int64
+	LastDeliveredID string
+}
+
+type XInfoGroupsCmd struct {
+	baseCmd
+
+	val []XInfoGroup
+}
+
+func NewXInfoGroupsCmd(args ...interface{}) *XInfoGroupsCmd {
+	return &XInfoGroupsCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *XInfoGroupsCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *XInfoGroupsCmd) Val() []XInfoGroup {
+	return cmd.val
+}
+
+func (cmd *XInfoGroupsCmd) Result() ([]XInfoGroup, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *XInfoGroupsCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *XInfoGroupsCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseXInfoGroups)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.([]XInfoGroup)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type XInfoConsumer struct {
+	Name    string
+	Pending int64
+	// Time since the consumer last read messages.
+	Idle time.Duration
+}
+
+type XInfoConsumersCmd struct {
+	baseCmd
+
+	val []XInfoConsumer
+}
+
+func NewXInfoConsumersCmd(args ...interface{}) *XInfoConsumersCmd {
+	return &XInfoConsumersCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *XInfoConsumersCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *XInfoConsumersCmd) Val() []XInfoConsumer {
+	return cmd.val
+}
+
+func (cmd *XInfoConsumersCmd) Result() ([]XInfoConsumer, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *XInfoConsumersCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *XInfoConsumersCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseXInfoConsumers)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.([]XInfoConsumer)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// ClientTrackingInfo is client side caching state of a connection.
+type ClientTrackingInfo struct {
+	// Flags are e.g. "off", "on", "bcast", "optin" or "broken_redirect".
+	Flags []string
+	// Redirect is the id of the client receiving invalidation messages,
+	// 0 when they are sent to the connection itself and -1 when
+	// tracking is off.
+	Redirect int64
+	// Prefixes are key prefixes tracked in broadcasting mode.
+	Prefixes []string
+}
+
+type ClientTrackingInfoCmd struct {
+	baseCmd
+
+	val *ClientTrackingInfo
+}
+
+func NewClientTrackingInfoCmd(args ...interface{}) *ClientTrackingInfoCmd {
+	return &ClientTrackingInfoCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *ClientTrackingInfoCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *ClientTrackingInfoCmd) Val() *ClientTrackingInfo {
+	return cmd.val
+}
+
+func (cmd *ClientTrackingInfoCmd) Result() (*ClientTrackingInfo, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *ClientTrackingInfoCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *ClientTrackingInfoCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseClientTrackingInfo)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.(*ClientTrackingInfo)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// LCSMatch is the reply of LCS. MatchString is set by default, Len
+// with LCSQuery.Len or LCSQuery.Idx and Matches with LCSQuery.Idx.
+type LCSMatch struct {
+	MatchString string
+	Matches     []LCSMatchedPosition
+	Len         int64
+}
+
+// LCSMatchedPosition is a range matched in both strings.
+type LCSMatchedPosition struct {
+	Key1, Key2 LCSPosition
+	// Length of the match. It is set with LCSQuery.WithMatchLen.
+	MatchLen int64
+}
+
+// LCSPosition is an inclusive range of string offsets.
+type LCSPosition struct {
+	Start, End int64
+}
+
+type LCSCmd struct {
+	baseCmd
+
+	val *LCSMatch
+}
+
+func NewLCSCmd(args ...interface{}) *LCSCmd {
+	return &LCSCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *LCSCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *LCSCmd) Val() *LCSMatch {
+	return cmd.val
+}
+
+func (cmd *LCSCmd) Result() (*LCSMatch, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *LCSCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *LCSCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseLCSIdx)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	switch v := v.(type) {
+	case []byte:
+		cmd.val = &LCSMatch{MatchString: string(v)}
+	case int64:
+		cmd.val = &LCSMatch{Len: v}
+	case *LCSMatch:
+		cmd.val = v
+	default:
+		cmd.err = fmt.Errorf("redis: unexpected LCS reply %T", v)
+		return cmd.err
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// MemoryStats is the reply of MEMORY STATS. Sizes are in bytes.
+type MemoryStats struct {
+	PeakAllocated      int64
+	TotalAllocated     int64
+	StartupAllocated   int64
+	ReplicationBacklog int64
+	ClientsReplicas    int64
+	ClientsNormal      int64
+	AOFBuffer          int64
+	LuaCaches          int64
+	OverheadTotal      int64
+	KeysCount          int64
+	KeysBytesPerKey    int64
+	DatasetBytes       int64
+	// Percentages of net memory usage.
+	DatasetPercentage float64
+	PeakPercentage    float64
+	// Ratio of RSS to allocated memory and their difference.
+	Fragmentation      float64
+	FragmentationBytes int64
+	// Overhead of databases by database index.
+	DBs map[int64]MemoryStatsDB
+}
+
+// MemoryStatsDB is the overhead of main and expires dictionaries of a
+// database.
+type MemoryStatsDB struct {
+	OverheadHashtableMain    int64
+	OverheadHashtableExpires int64
+}
+
+type MemoryStatsCmd struct {
+	baseCmd
+
+	val *MemoryStats
+}
+
+func NewMemoryStatsCmd(args ...interface{}) *MemoryStatsCmd {
+	return &MemoryStatsCmd{baseCmd: baseCmd{_args: args, _clusterKeyPos: 1}}
+}
+
+func (cmd *MemoryStatsCmd) reset() {
+	cmd.val = nil
+	cmd.err = nil
+}
+
+func (cmd *MemoryStatsCmd) Val() *MemoryStats {
+	return cmd.val
+}
+
+func (cmd *MemoryStatsCmd) Result() (*MemoryStats, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *MemoryStatsCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *MemoryStatsCmd) parseReply(rd *bufio.Reader) error {
+	v, err := parseReply(rd, parseMemoryStats)
+	if err != nil {
+		cmd.err = err
+		return err
+	}
+	cmd.val = v.(*MemoryStats)
+	return nil
+}
@@ -57,6 +57,19 @@ var _ = Describe("Command", func() {
 		Expect(get.Val()).To(Equal("hello1\r\nhello2\r\n"))
 	})
 
+	It("should reject values that can't be marshaled", func() {
+		type point struct{ X, Y int }
+
+		err := client.Set("key", point{1, 2}, 0).Err()
+		Expect(err).To(MatchError("redis: can't marshal redis_test.point (consider implementing BinaryMarshaler)"))
+		Expect(client.Exists("key").Val()).To(BeFalse())
+
+		Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+		var p point
+		err = client.Get("key").Scan(&p)
+		Expect(err).To(MatchError("redis: can't unmarshal *redis_test.point (consider implementing BinaryUnmarshaler)"))
+	})
+
 	It("should handle big vals", func() {
 		val := string(bytes.Repeat([]byte{'*'}, 1<<16))
 		set := client.Set("key", val, 0)
@@ -83,6 +83,9 @@ func (c *commandable) Quit() *StatusCmd {
 	panic("not implemented")
 }
 
+// Select changes the database of a single pooled connection and should
+// be used only with dedicated connections, e.g. in Multi. Use
+// Client.DB to run commands against another database.
 func (c *commandable) Select(index int64) *StatusCmd {
 	cmd := newKeylessStatusCmd("SELECT", formatInt(index))
 	c.Process(cmd)
@@ -102,6 +105,19 @@ func (c *commandable) Del(keys ...string) *IntCmd {
 	return cmd
 }
 
+// Copy copies the value stored at src to dst in database db. It
+// returns 0 if dst already exists and replace is false. Requires
+// Redis 6.2.
+func (c *commandable) Copy(src, dst string, db int, replace bool) *IntCmd {
+	args := []interface{}{"COPY", src, dst, "DB", formatInt(int64(db))}
+	if replace {
+		args = append(args, "REPLACE")
+	}
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) Dump(key string) *StringCmd {
 	cmd := NewStringCmd("DUMP", key)
 	c.Process(cmd)
@@ -120,6 +136,38 @@ func (c *commandable) Expire(key string, expiration time.Duration) *BoolCmd {
 	return cmd
 }
 
+func (c *commandable) expire(key string, expiration time.Duration, cond string) *BoolCmd {
+	cmd := NewBoolCmd("EXPIRE", key, formatSec(expiration), cond)
+	c.Process(cmd)
+	return cmd
+}
+
+// ExpireNX sets expiration only when the key has no expiration.
+// Requires Redis 7.0.
+func (c *commandable) ExpireNX(key string, expiration time.Duration) *BoolCmd {
+	return c.expire(key, expiration, "NX")
+}
+
+// ExpireXX sets expiration only when the key already has expiration.
+// Requires Redis 7.0.
+func (c *commandable) ExpireXX(key string, expiration time.Duration) *BoolCmd {
+	return c.expire(key, expiration, "XX")
+}
+
+// ExpireGT sets expiration only when it is greater than the current
+// one. Keys without expiration are treated as having infinite TTL, so
+// their expiration is never set. Requires Redis 7.0.
+func (c *commandable) ExpireGT(key string, expiration time.Duration) *BoolCmd {
+	return c.expire(key, expiration, "GT")
+}
+
+// ExpireLT sets expiration only when it is less than the current one.
+// Keys without expiration are treated as having infinite TTL.
+// Requires Redis 7.0.
+func (c *commandable) ExpireLT(key string, expiration time.Duration) *BoolCmd {
+	return c.expire(key, expiration, "LT")
+}
+
 func (c *commandable) ExpireAt(key string, tm time.Time) *BoolCmd {
 	cmd := NewBoolCmd("EXPIREAT", key, formatInt(tm.Unix()))
 	c.Process(cmd)
@@ -147,6 +195,53 @@ func (c *commandable) Migrate(host, port, key string, db int64, timeout time.Dur
 	return cmd
 }
 
+// MigrateArgs are optional arguments of MIGRATE.
+type MigrateArgs struct {
+	// Keys to migrate. More than one key is sent using KEYS, which
+	// requires Redis 3.0.6.
+	Keys []string
+	// Copy keeps keys on the source instance.
+	Copy bool
+	// Replace overwrites existing keys on the target instance.
+	Replace bool
+	// Credentials of the target instance. Username requires Redis 6.0.
+	Username string
+	Password string
+}
+
+// MigrateArgs migrates keys to another instance using optional
+// arguments. NOKEY is returned when none of the keys exist.
+func (c *commandable) MigrateArgs(host, port string, db int64, timeout time.Duration, a MigrateArgs) *StatusCmd {
+	args := []interface{}{"MIGRATE", host, port, "", formatInt(db), formatMs(timeout)}
+	if len(a.Keys) == 1 {
+		args[3] = a.Keys[0]
+	}
+	if a.Copy {
+		args = append(args, "COPY")
+	}
+	if a.Replace {
+		args = append(args, "REPLACE")
+	}
+	if a.Username != "" {
+		args = append(args, "AUTH2", a.Username, a.Password)
+	} else if a.Password != "" {
+		args = append(args, "AUTH", a.Password)
+	}
+	keyPos := 3
+	if len(a.Keys) > 1 {
+		args = append(args, "KEYS")
+		keyPos = len(args)
+		for _, key := range a.Keys {
+			args = append(args, key)
+		}
+	}
+	cmd := NewStatusCmd(args...)
+	cmd._clusterKeyPos = keyPos
+	cmd.setReadTimeout(readTimeout(timeout))
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) Move(key string, db int64) *BoolCmd {
 	cmd := NewBoolCmd("MOVE", key, formatInt(db))
 	c.Process(cmd)
@@ -192,6 +287,15 @@ func (c *commandable) ObjectIdleTime(keys ...string) *DurationCmd {
 	return cmd
 }
 
+// ObjectFreq returns the logarithmic access frequency counter of the
+// key. It requires an LFU maxmemory-policy. Requires Redis 4.0.
+func (c *commandable) ObjectFreq(key string) *IntCmd {
+	cmd := NewIntCmd("OBJECT", "FREQ", key)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) Persist(key string) *BoolCmd {
 	cmd := NewBoolCmd("PERSIST", key)
 	c.Process(cmd)
@@ -204,6 +308,32 @@ func (c *commandable) PExpire(key string, expiration time.Duration) *BoolCmd {
 	return cmd
 }
 
+func (c *commandable) pExpire(key string, expiration time.Duration, cond string) *BoolCmd {
+	cmd := NewBoolCmd("PEXPIRE", key, formatMs(expiration), cond)
+	c.Process(cmd)
+	return cmd
+}
+
+// PExpireNX is like ExpireNX, but with millisecond precision.
+func (c *commandable) PExpireNX(key string, expiration time.Duration) *BoolCmd {
+	return c.pExpire(key, expiration, "NX")
+}
+
+// PExpireXX is like ExpireXX, but with millisecond precision.
+func (c *commandable) PExpireXX(key string, expiration time.Duration) *BoolCmd {
+	return c.pExpire(key, expiration, "XX")
+}
+
+// PExpireGT is like ExpireGT, but with millisecond precision.
+func (c *commandable) PExpireGT(key string, expiration time.Duration) *BoolCmd {
+	return c.pExpire(key, expiration, "GT")
+}
+
+// PExpireLT is like ExpireLT, but with millisecond precision.
+func (c *commandable) PExpireLT(key string, expiration time.Duration) *BoolCmd {
+	return c.pExpire(key, expiration, "LT")
+}
+
 func (c *commandable) PExpireAt(key string, tm time.Time) *BoolCmd {
 	cmd := NewBoolCmd(
 		"PEXPIREAT",
@@ -261,6 +391,55 @@ func (c *commandable) RestoreReplace(key string, ttl time.Duration, value string
 	return cmd
 }
 
+// RestoreArgs are optional arguments of RESTORE.
+type RestoreArgs struct {
+	// Relative expiration. Zero means no expiration.
+	TTL time.Duration
+	// Absolute expiration using ABSTTL. It has priority over TTL.
+	// Requires Redis 5.0.
+	ExpireAt time.Time
+	// Replace overwrites the existing key.
+	Replace bool
+	// Object idle time used by LRU eviction. Zero is not sent.
+	// Requires Redis 5.0.
+	IdleTime time.Duration
+	// Object access frequency used by LFU eviction. Zero is not sent.
+	// Requires Redis 5.0.
+	Freq int64
+}
+
+func (a RestoreArgs) args(key, value string) []interface{} {
+	args := []interface{}{"RESTORE", key}
+	if !a.ExpireAt.IsZero() {
+		args = append(args, formatInt(a.ExpireAt.UnixNano()/int64(time.Millisecond)))
+	} else {
+		args = append(args, formatMs(a.TTL))
+	}
+	args = append(args, value)
+	if a.Replace {
+		args = append(args, "REPLACE")
+	}
+	if !a.ExpireAt.IsZero() {
+		args = append(args, "ABSTTL")
+	}
+	if a.IdleTime > 0 {
+		args = append(args, "IDLETIME", formatSec(a.IdleTime))
+	}
+	if a.Freq > 0 {
+		args = append(args, "FREQ", formatInt(a.Freq))
+	}
+	return args
+}
+
+// RestoreArgs restores the key serialized by Dump using optional
+// arguments, e.g. to copy keys with their expiration and eviction
+// metadata.
+func (c *commandable) RestoreArgs(key, value string, a RestoreArgs) *StatusCmd {
+	cmd := NewStatusCmd(a.args(key, value)...)
+	c.Process(cmd)
+	return cmd
+}
+
 type Sort struct {
 	By            string
 	Offset, Count float64
@@ -270,8 +449,8 @@ type Sort struct {
 	Store         string
 }
 
-func (c *commandable) Sort(key string, sort Sort) *StringSliceCmd {
-	args := []interface{}{"SORT", key}
+func (sort *Sort) args(name, key string) []interface{} {
+	args := []interface{}{name, key}
 	if sort.By != "" {
 		args = append(args, "BY", sort.By)
 	}
@@ -287,6 +466,11 @@ func (c *commandable) Sort(key string, sort Sort) *StringSliceCmd {
 	if sort.IsAlpha {
 		args = append(args, "ALPHA")
 	}
+	return args
+}
+
+func (c *commandable) Sort(key string, sort Sort) *StringSliceCmd {
+	args := sort.args("SORT", key)
 	if sort.Store != "" {
 		args = append(args, "STORE", sort.Store)
 	}
@@ -295,6 +479,46 @@ func (c *commandable) Sort(key string, sort Sort) *StringSliceCmd {
 	return cmd
 }
 
+// SortStore stores the sorted elements at store and returns their
+// number. sort.Store is ignored.
+func (c *commandable) SortStore(key, store string, sort Sort) *IntCmd {
+	args := sort.args("SORT", key)
+	args = append(args, "STORE", store)
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// SortInterfaces is like Sort, but returns nil for missing elements,
+// e.g. when GET patterns refer to non-existent keys. sort.Store is
+// ignored.
+func (c *commandable) SortInterfaces(key string, sort Sort) *SliceCmd {
+	cmd := NewSliceCmd(sort.args("SORT", key)...)
+	c.Process(cmd)
+	return cmd
+}
+
+// SortRO is a read-only variant of Sort that can be routed to replicas.
+// sort.Store is ignored. Requires Redis 7.0.
+func (c *commandable) SortRO(key string, sort Sort) *StringSliceCmd {
+	cmd := NewStringSliceCmd(sort.args("SORT_RO", key)...)
+	c.Process(cmd)
+	return cmd
+}
+
+// Touch updates last access time of keys and returns the number of
+// existing keys. Requires Redis 3.2.1.
+func (c *commandable) Touch(keys ...string) *IntCmd {
+	args := make([]interface{}, 1+len(keys))
+	args[0] = "TOUCH"
+	for i, key := range keys {
+		args[1+i] = key
+	}
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) TTL(key string) *DurationCmd {
 	cmd := NewDurationCmd(time.Second, "TTL", key)
 	c.Process(cmd)
@@ -307,21 +531,20 @@ func (c *commandable) Type(key string) *StatusCmd {
 	return cmd
 }
 
-func (c *commandable) Scan(cursor int64, match string, count int64) *ScanCmd {
-	args := []interface{}{"SCAN", formatInt(cursor)}
-	if match != "" {
-		args = append(args, "MATCH", match)
-	}
-	if count > 0 {
-		args = append(args, "COUNT", formatInt(count))
+// Unlink is like Del, but reclaims memory in a background thread.
+// Requires Redis 4.0.
+func (c *commandable) Unlink(keys ...string) *IntCmd {
+	args := make([]interface{}, 1+len(keys))
+	args[0] = "UNLINK"
+	for i, key := range keys {
+		args[1+i] = key
 	}
-	cmd := NewScanCmd(args...)
+	cmd := NewIntCmd(args...)
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) SScan(key string, cursor int64, match string, count int64) *ScanCmd {
-	args := []interface{}{"SSCAN", key, formatInt(cursor)}
+func (c *commandable) scan(args []interface{}, match string, count int64) *ScanCmd {
 	if match != "" {
 		args = append(args, "MATCH", match)
 	}
@@ -329,34 +552,44 @@ func (c *commandable) SScan(key string, cursor int64, match string, count int64)
 		args = append(args, "COUNT", formatInt(count))
 	}
 	cmd := NewScanCmd(args...)
+	if match != "" {
+		if err := checkMatch(match); err != nil {
+			cmd.setErr(err)
+			return cmd
+		}
+	}
 	c.Process(cmd)
 	return cmd
 }
 
+func (c *commandable) Scan(cursor int64, match string, count int64) *ScanCmd {
+	args := []interface{}{"SCAN", formatInt(cursor)}
+	return c.scan(args, match, count)
+}
+
+// ScanType is like Scan, but returns only keys of keyType, e.g. "zset".
+// Requires Redis 6.0.
+func (c *commandable) ScanType(cursor int64, match string, count int64, keyType string) *ScanCmd {
+	args := []interface{}{"SCAN", formatInt(cursor)}
+	if keyType != "" {
+		args = append(args, "TYPE", keyType)
+	}
+	return c.scan(args, match, count)
+}
+
+func (c *commandable) SScan(key string, cursor int64, match string, count int64) *ScanCmd {
+	args := []interface{}{"SSCAN", key, formatInt(cursor)}
+	return c.scan(args, match, count)
+}
+
 func (c *commandable) HScan(key string, cursor int64, match string, count int64) *ScanCmd {
 	args := []interface{}{"HSCAN", key, formatInt(cursor)}
-	if match != "" {
-		args = append(args, "MATCH", match)
-	}
-	if count > 0 {
-		args = append(args, "COUNT", formatInt(count))
-	}
-	cmd := NewScanCmd(args...)
-	c.Process(cmd)
-	return cmd
+	return c.scan(args, match, count)
 }
 
 func (c *commandable) ZScan(key string, cursor int64, match string, count int64) *ScanCmd {
 	args := []interface{}{"ZSCAN", key, formatInt(cursor)}
-	if match != "" {
-		args = append(args, "MATCH", match)
-	}
-	if count > 0 {
-		args = append(args, "COUNT", formatInt(count))
-	}
-	cmd := NewScanCmd(args...)
-	c.Process(cmd)
-	return cmd
+	return c.scan(args, match, count)
 }
 
 //------------------------------------------------------------------------------
@@ -369,6 +602,9 @@ func (c *commandable) Append(key, value string) *IntCmd {
 
 type BitCount struct {
 	Start, End int64
+	// Can be BYTE or BIT. Requires Redis >= 7.0.
+	// Default is BYTE.
+	Unit string
 }
 
 func (c *commandable) BitCount(key string, bitCount *BitCount) *IntCmd {
@@ -379,6 +615,9 @@ func (c *commandable) BitCount(key string, bitCount *BitCount) *IntCmd {
 			formatInt(bitCount.Start),
 			formatInt(bitCount.End),
 		)
+		if bitCount.Unit != "" {
+			args = append(args, bitCount.Unit)
+		}
 	}
 	cmd := NewIntCmd(args...)
 	c.Process(cmd)
@@ -434,6 +673,91 @@ func (c *commandable) BitPos(key string, bit int64, pos ...int64) *IntCmd {
 	return cmd
 }
 
+type BitPosSpan struct {
+	// Use -1 as End to search till the end of the string.
+	Start, End int64
+	// Can be BYTE or BIT. Requires Redis >= 7.0.
+	// Default is BYTE.
+	Unit string
+}
+
+func (c *commandable) BitPosSpan(key string, bit int64, span *BitPosSpan) *IntCmd {
+	args := []interface{}{"BITPOS", key, formatInt(bit)}
+	if span != nil {
+		args = append(args, formatInt(span.Start), formatInt(span.End))
+		if span.Unit != "" {
+			args = append(args, span.Unit)
+		}
+	}
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// BitFieldArgs builds BITFIELD subcommands, e.g.
+//
+//	args := new(redis.BitFieldArgs).Overflow("SAT").IncrBy("u8", 0, 10).Get("u4", 8)
+//	client.BitField("key", args.Args()...)
+type BitFieldArgs struct {
+	args []interface{}
+}
+
+// Get reads the integer of type, e.g. "u8" or "i16", at bit offset.
+func (a *BitFieldArgs) Get(typ string, offset int64) *BitFieldArgs {
+	a.args = append(a.args, "GET", typ, formatInt(offset))
+	return a
+}
+
+// Set sets the integer at bit offset and returns the old value.
+func (a *BitFieldArgs) Set(typ string, offset, value int64) *BitFieldArgs {
+	a.args = append(a.args, "SET", typ, formatInt(offset), formatInt(value))
+	return a
+}
+
+// IncrBy increments the integer at bit offset and returns the new
+// value.
+func (a *BitFieldArgs) IncrBy(typ string, offset, increment int64) *BitFieldArgs {
+	a.args = append(a.args, "INCRBY", typ, formatInt(offset), formatInt(increment))
+	return a
+}
+
+// Overflow sets overflow behavior of following Set and IncrBy
+// subcommands. Can be WRAP, SAT or FAIL.
+func (a *BitFieldArgs) Overflow(mode string) *BitFieldArgs {
+	a.args = append(a.args, "OVERFLOW", mode)
+	return a
+}
+
+// Args returns arguments to be passed to BitField.
+func (a *BitFieldArgs) Args() []interface{} {
+	return a.args
+}
+
+// BitField treats the string as an array of integers. It returns one
+// value for each GET, SET and INCRBY subcommand; values of subcommands
+// that failed because of OVERFLOW FAIL are returned as 0.
+func (c *commandable) BitField(key string, args ...interface{}) *IntSliceCmd {
+	cmdArgs := make([]interface{}, 2+len(args))
+	cmdArgs[0] = "BITFIELD"
+	cmdArgs[1] = key
+	copy(cmdArgs[2:], args)
+	cmd := NewIntSliceCmd(cmdArgs...)
+	c.Process(cmd)
+	return cmd
+}
+
+// BitFieldRO is a read-only variant of BitField that accepts only GET
+// subcommands and can be routed to replicas. Requires Redis 6.0.
+func (c *commandable) BitFieldRO(key string, args ...interface{}) *IntSliceCmd {
+	cmdArgs := make([]interface{}, 2+len(args))
+	cmdArgs[0] = "BITFIELD_RO"
+	cmdArgs[1] = key
+	copy(cmdArgs[2:], args)
+	cmd := NewIntSliceCmd(cmdArgs...)
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) Decr(key string) *IntCmd {
 	cmd := NewIntCmd("DECR", key)
 	c.Process(cmd)
@@ -469,6 +793,13 @@ func (c *commandable) GetRange(key string, start, end int64) *StringCmd {
 	return cmd
 }
 
+// GetDel gets the value of key and deletes the key. Requires Redis 6.2.
+func (c *commandable) GetDel(key string) *StringCmd {
+	cmd := NewStringCmd("GETDEL", key)
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) GetSet(key string, value interface{}) *StringCmd {
 	cmd := NewStringCmd("GETSET", key, value)
 	c.Process(cmd)
@@ -493,6 +824,40 @@ func (c *commandable) IncrByFloat(key string, value float64) *FloatCmd {
 	return cmd
 }
 
+// LCSQuery are arguments of LCS. By default LCS returns the longest
+// common subsequence of strings stored at Key1 and Key2. Len returns
+// only its length and Idx returns positions of matches, optionally
+// filtered by MinMatchLen and including their lengths with
+// WithMatchLen.
+type LCSQuery struct {
+	Key1, Key2   string
+	Len          bool
+	Idx          bool
+	MinMatchLen  int64
+	WithMatchLen bool
+}
+
+// LCS finds the longest common subsequence of two strings. Requires
+// Redis 7.0.
+func (c *commandable) LCS(q LCSQuery) *LCSCmd {
+	args := []interface{}{"LCS", q.Key1, q.Key2}
+	if q.Len {
+		args = append(args, "LEN")
+	}
+	if q.Idx {
+		args = append(args, "IDX")
+	}
+	if q.MinMatchLen != 0 {
+		args = append(args, "MINMATCHLEN", formatInt(q.MinMatchLen))
+	}
+	if q.WithMatchLen {
+		args = append(args, "WITHMATCHLEN")
+	}
+	cmd := NewLCSCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) MGet(keys ...string) *SliceCmd {
 	args := make([]interface{}, 1+len(keys))
 	args[0] = "MGET"
@@ -546,6 +911,63 @@ func (c *commandable) Set(key string, value interface{}, expiration time.Duratio
 	return cmd
 }
 
+// SetArgs are optional arguments of SET. Use SetWithArgs to also get
+// the old value.
+type SetArgs struct {
+	// Mode is "NX" to set only missing keys or "XX" to set only
+	// existing keys. Empty mode sets the key unconditionally.
+	Mode string
+	// Relative expiration. Zero means no expiration.
+	TTL time.Duration
+	// Absolute expiration. It has priority over TTL.
+	ExpireAt time.Time
+	// KeepTTL retains the expiration of the existing key. Requires
+	// Redis 6.0.
+	KeepTTL bool
+}
+
+func (a SetArgs) args(args []interface{}) []interface{} {
+	switch {
+	case !a.ExpireAt.IsZero():
+		if a.ExpireAt.Nanosecond() != 0 {
+			args = append(args, "PXAT", formatInt(a.ExpireAt.UnixNano()/int64(time.Millisecond)))
+		} else {
+			args = append(args, "EXAT", formatInt(a.ExpireAt.Unix()))
+		}
+	case a.TTL > 0:
+		if usePrecise(a.TTL) {
+			args = append(args, "PX", formatMs(a.TTL))
+		} else {
+			args = append(args, "EX", formatSec(a.TTL))
+		}
+	case a.KeepTTL:
+		args = append(args, "KEEPTTL")
+	}
+	if a.Mode != "" {
+		args = append(args, a.Mode)
+	}
+	return args
+}
+
+// SetArgs sets the key using SET with optional arguments. Nil is
+// returned when the key is not set because of the mode. EXAT and PXAT
+// require Redis 6.2.
+func (c *commandable) SetArgs(key string, value interface{}, a SetArgs) *StatusCmd {
+	cmd := NewStatusCmd(a.args([]interface{}{"SET", key, value})...)
+	c.Process(cmd)
+	return cmd
+}
+
+// SetWithArgs is like SetArgs, but uses GET modifier to return the old
+// value of the key. Nil is returned when the key did not exist.
+// Requires Redis 6.2.
+func (c *commandable) SetWithArgs(key string, value interface{}, a SetArgs) *StringCmd {
+	args := a.args([]interface{}{"SET", key, value})
+	cmd := NewStringCmd(append(args, "GET")...)
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) SetBit(key string, offset int64, value int) *IntCmd {
 	cmd := NewIntCmd(
 		"SETBIT",
@@ -690,6 +1112,23 @@ func (c *commandable) HMSet(key, field, value string, pairs ...string) *StatusCm
 	return cmd
 }
 
+// HRandField returns up to count distinct random fields or, when count
+// is negative, -count fields that may repeat. Requires Redis 6.2.
+func (c *commandable) HRandField(key string, count int64) *StringSliceCmd {
+	cmd := NewStringSliceCmd("HRANDFIELD", key, formatInt(count))
+	c.Process(cmd)
+	return cmd
+}
+
+// HRandFieldWithValues is HRandField returning values of fields.
+// Repeated fields returned for negative count appear in the map once.
+// Requires Redis 6.2.
+func (c *commandable) HRandFieldWithValues(key string, count int64) *StringStringMapCmd {
+	cmd := NewStringStringMapCmd("HRANDFIELD", key, formatInt(count), "WITHVALUES")
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) HSet(key, field, value string) *BoolCmd {
 	cmd := NewBoolCmd("HSET", key, field, value)
 	c.Process(cmd)
@@ -702,6 +1141,66 @@ func (c *commandable) HSetNX(key, field, value string) *BoolCmd {
 	return cmd
 }
 
+func hFieldsArgs(args []interface{}, fields []string) []interface{} {
+	args = append(args, "FIELDS", strconv.Itoa(len(fields)))
+	for _, field := range fields {
+		args = append(args, field)
+	}
+	return args
+}
+
+// HExpire sets expiration of hash fields. For every field it returns
+// 1 if expiration was set, 2 if the field was deleted because
+// expiration is zero and -2 if the field does not exist. Requires
+// Redis 7.4.
+func (c *commandable) HExpire(key string, expiration time.Duration, fields ...string) *IntSliceCmd {
+	args := hFieldsArgs([]interface{}{"HEXPIRE", key, formatSec(expiration)}, fields)
+	cmd := NewIntSliceCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// HPExpire is like HExpire, but with millisecond precision. Requires
+// Redis 7.4.
+func (c *commandable) HPExpire(key string, expiration time.Duration, fields ...string) *IntSliceCmd {
+	args := hFieldsArgs([]interface{}{"HPEXPIRE", key, formatMs(expiration)}, fields)
+	cmd := NewIntSliceCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// HTTL returns remaining time to live of hash fields in seconds, -1 if
+// the field has no expiration and -2 if the field does not exist.
+// Requires Redis 7.4.
+func (c *commandable) HTTL(key string, fields ...string) *IntSliceCmd {
+	cmd := NewIntSliceCmd(hFieldsArgs([]interface{}{"HTTL", key}, fields)...)
+	c.Process(cmd)
+	return cmd
+}
+
+// HPTTL is like HTTL, but returns time to live in milliseconds.
+// Requires Redis 7.4.
+func (c *commandable) HPTTL(key string, fields ...string) *IntSliceCmd {
+	cmd := NewIntSliceCmd(hFieldsArgs([]interface{}{"HPTTL", key}, fields)...)
+	c.Process(cmd)
+	return cmd
+}
+
+// HPersist removes expiration of hash fields. For every field it
+// returns 1 if expiration was removed, -1 if the field has no
+// expiration and -2 if the field does not exist. Requires Redis 7.4.
+func (c *commandable) HPersist(key string, fields ...string) *IntSliceCmd {
+	cmd := NewIntSliceCmd(hFieldsArgs([]interface{}{"HPERSIST", key}, fields)...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) HStrLen(key, field string) *IntCmd {
+	cmd := NewIntCmd("HSTRLEN", key, field)
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) HVals(key string) *StringSliceCmd {
 	cmd := NewStringSliceCmd("HVALS", key)
 	c.Process(cmd)
@@ -748,6 +1247,39 @@ func (c *commandable) BRPopLPush(source, destination string, timeout time.Durati
 	return cmd
 }
 
+func mPopArgs(args []interface{}, direction string, count int64, keys []string) []interface{} {
+	args = append(args, strconv.Itoa(len(keys)))
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	args = append(args, direction)
+	if count > 0 {
+		args = append(args, "COUNT", formatInt(count))
+	}
+	return args
+}
+
+// LMPop pops up to count elements from the first non-empty list.
+// Direction is "LEFT" or "RIGHT". It returns Nil error when all lists
+// are empty. Requires Redis 7.0.
+func (c *commandable) LMPop(direction string, count int64, keys ...string) *KeyValuesCmd {
+	cmd := NewKeyValuesCmd(mPopArgs([]interface{}{"LMPOP"}, direction, count, keys)...)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+// BLMPop is a blocking variant of LMPop. It returns Nil error on
+// timeout. Requires Redis 7.0.
+func (c *commandable) BLMPop(timeout time.Duration, direction string, count int64, keys ...string) *KeyValuesCmd {
+	args := mPopArgs([]interface{}{"BLMPOP", formatSec(timeout)}, direction, count, keys)
+	cmd := NewKeyValuesCmd(args...)
+	cmd._clusterKeyPos = 3
+	cmd.setReadTimeout(readTimeout(timeout))
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) LIndex(key string, index int64) *StringCmd {
 	cmd := NewStringCmd("LINDEX", key, formatInt(index))
 	c.Process(cmd)
@@ -766,10 +1298,45 @@ func (c *commandable) LLen(key string) *IntCmd {
 	return cmd
 }
 
-func (c *commandable) LPop(key string) *StringCmd {
-	cmd := NewStringCmd("LPOP", key)
-	c.Process(cmd)
-	return cmd
+// LPosArgs are optional arguments of LPOS. Rank is the 1-based match
+// to return, negative to search from the tail, and MaxLen limits the
+// number of compared elements. Zero values are not sent.
+type LPosArgs struct {
+	Rank, MaxLen int64
+}
+
+func (a LPosArgs) args(args []interface{}) []interface{} {
+	if a.Rank != 0 {
+		args = append(args, "RANK", formatInt(a.Rank))
+	}
+	if a.MaxLen != 0 {
+		args = append(args, "MAXLEN", formatInt(a.MaxLen))
+	}
+	return args
+}
+
+// LPos returns the index of the matching element. It returns Nil
+// error when there is no match. Requires Redis 6.0.6.
+func (c *commandable) LPos(key string, value string, a LPosArgs) *IntCmd {
+	cmd := NewIntCmd(a.args([]interface{}{"LPOS", key, value})...)
+	c.Process(cmd)
+	return cmd
+}
+
+// LPosCount returns indexes of up to count matching elements or all
+// matching elements when count is zero. Requires Redis 6.0.6.
+func (c *commandable) LPosCount(key string, value string, count int64, a LPosArgs) *IntSliceCmd {
+	args := a.args([]interface{}{"LPOS", key, value})
+	args = append(args, "COUNT", formatInt(count))
+	cmd := NewIntSliceCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) LPop(key string) *StringCmd {
+	cmd := NewStringCmd("LPOP", key)
+	c.Process(cmd)
+	return cmd
 }
 
 func (c *commandable) LPush(key string, values ...string) *IntCmd {
@@ -926,6 +1493,20 @@ func (c *commandable) SIsMember(key, member string) *BoolCmd {
 	return cmd
 }
 
+// SMIsMember reports for every member whether it is a member of the
+// set. Requires Redis 6.2.
+func (c *commandable) SMIsMember(key string, members ...interface{}) *BoolSliceCmd {
+	args := make([]interface{}, 2+len(members))
+	args[0] = "SMISMEMBER"
+	args[1] = key
+	for i, member := range members {
+		args[2+i] = member
+	}
+	cmd := NewBoolSliceCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) SMembers(key string) *StringSliceCmd {
 	cmd := NewStringSliceCmd("SMEMBERS", key)
 	c.Process(cmd)
@@ -944,12 +1525,28 @@ func (c *commandable) SPop(key string) *StringCmd {
 	return cmd
 }
 
+// SPopN removes and returns up to count random members. Requires
+// Redis 3.2.
+func (c *commandable) SPopN(key string, count int64) *StringSliceCmd {
+	cmd := NewStringSliceCmd("SPOP", key, formatInt(count))
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) SRandMember(key string) *StringCmd {
 	cmd := NewStringCmd("SRANDMEMBER", key)
 	c.Process(cmd)
 	return cmd
 }
 
+// SRandMemberN returns up to count distinct random members or, when
+// count is negative, -count members that may repeat.
+func (c *commandable) SRandMemberN(key string, count int64) *StringSliceCmd {
+	cmd := NewStringSliceCmd("SRANDMEMBER", key, formatInt(count))
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) SRem(key string, members ...string) *IntCmd {
 	args := make([]interface{}, 2+len(members))
 	args[0] = "SREM"
@@ -993,6 +1590,12 @@ type Z struct {
 	Member interface{}
 }
 
+// Sorted set member popped from the key by BZPopMin or BZPopMax.
+type ZWithKey struct {
+	Z
+	Key string
+}
+
 // Sorted set store operation.
 type ZStore struct {
 	Weights []int64
@@ -1000,19 +1603,98 @@ type ZStore struct {
 	Aggregate string
 }
 
-func (c *commandable) ZAdd(key string, members ...Z) *IntCmd {
-	args := make([]interface{}, 2+2*len(members))
-	args[0] = "ZADD"
-	args[1] = key
+func (c *commandable) zAdd(a []interface{}, n int, members ...Z) *IntCmd {
 	for i, m := range members {
-		args[2+2*i] = formatFloat(m.Score)
-		args[2+2*i+1] = m.Member
+		a[n+2*i] = formatFloat(m.Score)
+		a[n+2*i+1] = m.Member
 	}
-	cmd := NewIntCmd(args...)
+	cmd := NewIntCmd(a...)
+	c.Process(cmd)
+	return cmd
+}
+
+// Redis `ZADD key score member [score member ...]` command.
+func (c *commandable) ZAdd(key string, members ...Z) *IntCmd {
+	const n = 2
+	a := make([]interface{}, n+2*len(members))
+	a[0], a[1] = "ZADD", key
+	return c.zAdd(a, n, members...)
+}
+
+// Redis `ZADD key NX score member [score member ...]` command.
+func (c *commandable) ZAddNX(key string, members ...Z) *IntCmd {
+	const n = 3
+	a := make([]interface{}, n+2*len(members))
+	a[0], a[1], a[2] = "ZADD", key, "NX"
+	return c.zAdd(a, n, members...)
+}
+
+// Redis `ZADD key XX score member [score member ...]` command.
+func (c *commandable) ZAddXX(key string, members ...Z) *IntCmd {
+	const n = 3
+	a := make([]interface{}, n+2*len(members))
+	a[0], a[1], a[2] = "ZADD", key, "XX"
+	return c.zAdd(a, n, members...)
+}
+
+// Redis `ZADD key CH score member [score member ...]` command.
+func (c *commandable) ZAddCh(key string, members ...Z) *IntCmd {
+	const n = 3
+	a := make([]interface{}, n+2*len(members))
+	a[0], a[1], a[2] = "ZADD", key, "CH"
+	return c.zAdd(a, n, members...)
+}
+
+// Redis `ZADD key NX CH score member [score member ...]` command.
+func (c *commandable) ZAddNXCh(key string, members ...Z) *IntCmd {
+	const n = 4
+	a := make([]interface{}, n+2*len(members))
+	a[0], a[1], a[2], a[3] = "ZADD", key, "NX", "CH"
+	return c.zAdd(a, n, members...)
+}
+
+// Redis `ZADD key XX CH score member [score member ...]` command.
+func (c *commandable) ZAddXXCh(key string, members ...Z) *IntCmd {
+	const n = 4
+	a := make([]interface{}, n+2*len(members))
+	a[0], a[1], a[2], a[3] = "ZADD", key, "XX", "CH"
+	return c.zAdd(a, n, members...)
+}
+
+func (c *commandable) zIncr(a []interface{}, n int, member Z) *FloatCmd {
+	a[n] = formatFloat(member.Score)
+	a[n+1] = member.Member
+	cmd := NewFloatCmd(a...)
 	c.Process(cmd)
 	return cmd
 }
 
+// Redis `ZADD key INCR score member` command.
+func (c *commandable) ZIncr(key string, member Z) *FloatCmd {
+	const n = 3
+	a := make([]interface{}, n+2)
+	a[0], a[1], a[2] = "ZADD", key, "INCR"
+	return c.zIncr(a, n, member)
+}
+
+// Redis `ZADD key NX INCR score member` command. It returns Nil error
+// when the member already exists.
+func (c *commandable) ZIncrNX(key string, member Z) *FloatCmd {
+	const n = 4
+	a := make([]interface{}, n+2)
+	a[0], a[1], a[2], a[3] = "ZADD", key, "NX", "INCR"
+	return c.zIncr(a, n, member)
+}
+
+// Redis `ZADD key XX INCR score member` command. It returns Nil error
+// when the member does not exist.
+func (c *commandable) ZIncrXX(key string, member Z) *FloatCmd {
+	const n = 4
+	a := make([]interface{}, n+2)
+	a[0], a[1], a[2], a[3] = "ZADD", key, "XX", "INCR"
+	return c.zIncr(a, n, member)
+}
+
 func (c *commandable) ZCard(key string) *IntCmd {
 	cmd := NewIntCmd("ZCARD", key)
 	c.Process(cmd)
@@ -1057,6 +1739,68 @@ func (c *commandable) ZInterStore(
 	return cmd
 }
 
+// ZPopMin removes and returns up to count members with the lowest
+// scores. Requires Redis 5.0.
+func (c *commandable) ZPopMin(key string, count int64) *ZSliceCmd {
+	cmd := NewZSliceCmd("ZPOPMIN", key, formatInt(count))
+	c.Process(cmd)
+	return cmd
+}
+
+// ZPopMax removes and returns up to count members with the highest
+// scores. Requires Redis 5.0.
+func (c *commandable) ZPopMax(key string, count int64) *ZSliceCmd {
+	cmd := NewZSliceCmd("ZPOPMAX", key, formatInt(count))
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) bzPop(name string, timeout time.Duration, keys ...string) *ZWithKeyCmd {
+	args := make([]interface{}, 2+len(keys))
+	args[0] = name
+	for i, key := range keys {
+		args[1+i] = key
+	}
+	args[len(args)-1] = formatSec(timeout)
+	cmd := NewZWithKeyCmd(args...)
+	cmd.setReadTimeout(readTimeout(timeout))
+	c.Process(cmd)
+	return cmd
+}
+
+// BZPopMin is a blocking variant of ZPopMin that pops one member from
+// the first non-empty key. It returns Nil error on timeout.
+func (c *commandable) BZPopMin(timeout time.Duration, keys ...string) *ZWithKeyCmd {
+	return c.bzPop("BZPOPMIN", timeout, keys...)
+}
+
+// BZPopMax is a blocking variant of ZPopMax that pops one member from
+// the first non-empty key. It returns Nil error on timeout.
+func (c *commandable) BZPopMax(timeout time.Duration, keys ...string) *ZWithKeyCmd {
+	return c.bzPop("BZPOPMAX", timeout, keys...)
+}
+
+// ZMPop pops up to count members with the lowest or highest scores
+// from the first non-empty sorted set. Order is "MIN" or "MAX". It
+// returns Nil error when all sorted sets are empty. Requires Redis 7.0.
+func (c *commandable) ZMPop(order string, count int64, keys ...string) *ZSliceWithKeyCmd {
+	cmd := NewZSliceWithKeyCmd(mPopArgs([]interface{}{"ZMPOP"}, order, count, keys)...)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+// BZMPop is a blocking variant of ZMPop. It returns Nil error on
+// timeout. Requires Redis 7.0.
+func (c *commandable) BZMPop(timeout time.Duration, order string, count int64, keys ...string) *ZSliceWithKeyCmd {
+	args := mPopArgs([]interface{}{"BZMPOP", formatSec(timeout)}, order, count, keys)
+	cmd := NewZSliceWithKeyCmd(args...)
+	cmd._clusterKeyPos = 3
+	cmd.setReadTimeout(readTimeout(timeout))
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) zRange(key string, start, stop int64, withScores bool) *StringSliceCmd {
 	args := []interface{}{
 		"ZRANGE",
@@ -1131,6 +1875,116 @@ func (c *commandable) ZRangeByScoreWithScores(key string, opt ZRangeByScore) *ZS
 	return cmd
 }
 
+// Sorted set lexicographical range. Min and Max are e.g. "-", "+",
+// "[a" (inclusive) or "(a" (exclusive).
+type ZRangeByLex struct {
+	Min, Max      string
+	Offset, Count int64
+}
+
+func (c *commandable) zRangeByLex(cmdName, key, start, stop string, opt ZRangeByLex) *StringSliceCmd {
+	args := []interface{}{cmdName, key, start, stop}
+	if opt.Offset != 0 || opt.Count != 0 {
+		args = append(
+			args,
+			"LIMIT",
+			formatInt(opt.Offset),
+			formatInt(opt.Count),
+		)
+	}
+	cmd := NewStringSliceCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) ZRangeByLex(key string, opt ZRangeByLex) *StringSliceCmd {
+	return c.zRangeByLex("ZRANGEBYLEX", key, opt.Min, opt.Max, opt)
+}
+
+func (c *commandable) ZRevRangeByLex(key string, opt ZRangeByLex) *StringSliceCmd {
+	return c.zRangeByLex("ZREVRANGEBYLEX", key, opt.Max, opt.Min, opt)
+}
+
+// ZRangeArgs are arguments of ZRANGE command introduced in Redis 6.2.
+// Start and Stop are ranks by default, scores with ByScore, e.g. "1",
+// "(1" or "+inf", and members with ByLex, e.g. "[a" or "-". With Rev
+// Start is the higher end of the range. Offset and Count are used only
+// with ByScore or ByLex.
+type ZRangeArgs struct {
+	Key         string
+	Start, Stop string
+
+	ByScore bool
+	ByLex   bool
+	Rev     bool
+
+	Offset, Count int64
+}
+
+func (z *ZRangeArgs) args(args []interface{}) []interface{} {
+	args = append(args, z.Key, z.Start, z.Stop)
+	if z.ByScore {
+		args = append(args, "BYSCORE")
+	} else if z.ByLex {
+		args = append(args, "BYLEX")
+	}
+	if z.Rev {
+		args = append(args, "REV")
+	}
+	if z.Offset != 0 || z.Count != 0 {
+		args = append(args, "LIMIT", formatInt(z.Offset), formatInt(z.Count))
+	}
+	return args
+}
+
+// ZRangeArgs is ZRange supporting all ZRANGE options. Requires Redis
+// 6.2.
+func (c *commandable) ZRangeArgs(z ZRangeArgs) *StringSliceCmd {
+	cmd := NewStringSliceCmd(z.args([]interface{}{"ZRANGE"})...)
+	c.Process(cmd)
+	return cmd
+}
+
+// ZRangeArgsWithScores is ZRangeWithScores supporting all ZRANGE
+// options. Requires Redis 6.2.
+func (c *commandable) ZRangeArgsWithScores(z ZRangeArgs) *ZSliceCmd {
+	args := append(z.args([]interface{}{"ZRANGE"}), "WITHSCORES")
+	cmd := NewZSliceCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// ZRangeStore stores the range of the sorted set in dst and returns the
+// number of stored members. Requires Redis 6.2.
+func (c *commandable) ZRangeStore(dst string, z ZRangeArgs) *IntCmd {
+	cmd := NewIntCmd(z.args([]interface{}{"ZRANGESTORE", dst})...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) ZLexCount(key, min, max string) *IntCmd {
+	cmd := NewIntCmd("ZLEXCOUNT", key, min, max)
+	c.Process(cmd)
+	return cmd
+}
+
+// ZRandMember returns up to count distinct random members or, when
+// count is negative, -count members that may repeat. Requires Redis
+// 6.2.
+func (c *commandable) ZRandMember(key string, count int64) *StringSliceCmd {
+	cmd := NewStringSliceCmd("ZRANDMEMBER", key, formatInt(count))
+	c.Process(cmd)
+	return cmd
+}
+
+// ZRandMemberWithScores is ZRandMember returning scores of members.
+// Requires Redis 6.2.
+func (c *commandable) ZRandMemberWithScores(key string, count int64) *ZSliceCmd {
+	cmd := NewZSliceCmd("ZRANDMEMBER", key, formatInt(count), "WITHSCORES")
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) ZRank(key, member string) *IntCmd {
 	cmd := NewIntCmd("ZRANK", key, member)
 	c.Process(cmd)
@@ -1160,6 +2014,12 @@ func (c *commandable) ZRemRangeByRank(key string, start, stop int64) *IntCmd {
 	return cmd
 }
 
+func (c *commandable) ZRemRangeByLex(key, min, max string) *IntCmd {
+	cmd := NewIntCmd("ZREMRANGEBYLEX", key, min, max)
+	c.Process(cmd)
+	return cmd
+}
+
 func (c *commandable) ZRemRangeByScore(key, min, max string) *IntCmd {
 	cmd := NewIntCmd("ZREMRANGEBYSCORE", key, min, max)
 	c.Process(cmd)
@@ -1242,117 +2102,367 @@ func (c *commandable) ZUnionStore(dest string, store ZStore, keys ...string) *In
 	return cmd
 }
 
-//------------------------------------------------------------------------------
+// zSetAlgebraArgs returns arguments of ZDIFF, ZUNION and ZINTER.
+func zSetAlgebraArgs(name string, store *ZStore, withScores bool, keys []string) []interface{} {
+	args := make([]interface{}, 2+len(keys))
+	args[0] = name
+	args[1] = strconv.Itoa(len(keys))
+	for i, key := range keys {
+		args[2+i] = key
+	}
+	if store != nil {
+		if len(store.Weights) > 0 {
+			args = append(args, "WEIGHTS")
+			for _, weight := range store.Weights {
+				args = append(args, formatInt(weight))
+			}
+		}
+		if store.Aggregate != "" {
+			args = append(args, "AGGREGATE", store.Aggregate)
+		}
+	}
+	if withScores {
+		args = append(args, "WITHSCORES")
+	}
+	return args
+}
 
-func (c *commandable) BgRewriteAOF() *StatusCmd {
-	cmd := NewStatusCmd("BGREWRITEAOF")
-	cmd._clusterKeyPos = 0
+// ZDiff returns members of the first sorted set that are not in the
+// other sorted sets. Requires Redis 6.2.
+func (c *commandable) ZDiff(keys ...string) *StringSliceCmd {
+	cmd := NewStringSliceCmd(zSetAlgebraArgs("ZDIFF", nil, false, keys)...)
+	cmd._clusterKeyPos = 2
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) BgSave() *StatusCmd {
-	cmd := NewStatusCmd("BGSAVE")
-	cmd._clusterKeyPos = 0
+// ZDiffWithScores is ZDiff returning scores. Requires Redis 6.2.
+func (c *commandable) ZDiffWithScores(keys ...string) *ZSliceCmd {
+	cmd := NewZSliceCmd(zSetAlgebraArgs("ZDIFF", nil, true, keys)...)
+	cmd._clusterKeyPos = 2
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) ClientKill(ipPort string) *StatusCmd {
-	cmd := NewStatusCmd("CLIENT", "KILL", ipPort)
-	cmd._clusterKeyPos = 0
+// ZUnion is ZUnionStore returning members instead of storing them.
+// Requires Redis 6.2.
+func (c *commandable) ZUnion(store ZStore, keys ...string) *StringSliceCmd {
+	cmd := NewStringSliceCmd(zSetAlgebraArgs("ZUNION", &store, false, keys)...)
+	cmd._clusterKeyPos = 2
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) ClientList() *StringCmd {
-	cmd := NewStringCmd("CLIENT", "LIST")
-	cmd._clusterKeyPos = 0
+// ZUnionWithScores is ZUnion returning scores. Requires Redis 6.2.
+func (c *commandable) ZUnionWithScores(store ZStore, keys ...string) *ZSliceCmd {
+	cmd := NewZSliceCmd(zSetAlgebraArgs("ZUNION", &store, true, keys)...)
+	cmd._clusterKeyPos = 2
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) ClientPause(dur time.Duration) *BoolCmd {
-	cmd := NewBoolCmd("CLIENT", "PAUSE", formatMs(dur))
-	cmd._clusterKeyPos = 0
+// ZInter is ZInterStore returning members instead of storing them.
+// Requires Redis 6.2.
+func (c *commandable) ZInter(store ZStore, keys ...string) *StringSliceCmd {
+	cmd := NewStringSliceCmd(zSetAlgebraArgs("ZINTER", &store, false, keys)...)
+	cmd._clusterKeyPos = 2
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) ConfigGet(parameter string) *SliceCmd {
-	cmd := NewSliceCmd("CONFIG", "GET", parameter)
-	cmd._clusterKeyPos = 0
+// ZInterWithScores is ZInter returning scores. Requires Redis 6.2.
+func (c *commandable) ZInterWithScores(store ZStore, keys ...string) *ZSliceCmd {
+	cmd := NewZSliceCmd(zSetAlgebraArgs("ZINTER", &store, true, keys)...)
+	cmd._clusterKeyPos = 2
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) ConfigResetStat() *StatusCmd {
-	cmd := NewStatusCmd("CONFIG", "RESETSTAT")
-	cmd._clusterKeyPos = 0
+// ZInterCard returns the number of members in the intersection of the
+// sorted sets. Counting stops at limit unless limit is zero. Requires
+// Redis 7.0.
+func (c *commandable) ZInterCard(limit int64, keys ...string) *IntCmd {
+	args := zSetAlgebraArgs("ZINTERCARD", nil, false, keys)
+	if limit > 0 {
+		args = append(args, "LIMIT", formatInt(limit))
+	}
+	cmd := NewIntCmd(args...)
+	cmd._clusterKeyPos = 2
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) ConfigSet(parameter, value string) *StatusCmd {
-	cmd := NewStatusCmd("CONFIG", "SET", parameter, value)
-	cmd._clusterKeyPos = 0
+//------------------------------------------------------------------------------
+
+func (c *commandable) PFAdd(key string, fields ...string) *IntCmd {
+	args := make([]interface{}, 2+len(fields))
+	args[0] = "PFADD"
+	args[1] = key
+	for i, field := range fields {
+		args[2+i] = field
+	}
+	cmd := NewIntCmd(args...)
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) DbSize() *IntCmd {
-	cmd := NewIntCmd("DBSIZE")
-	cmd._clusterKeyPos = 0
+func (c *commandable) PFCount(keys ...string) *IntCmd {
+	args := make([]interface{}, 1+len(keys))
+	args[0] = "PFCOUNT"
+	for i, key := range keys {
+		args[1+i] = key
+	}
+	cmd := NewIntCmd(args...)
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) FlushAll() *StatusCmd {
-	cmd := newKeylessStatusCmd("FLUSHALL")
+func (c *commandable) PFMerge(dest string, keys ...string) *StatusCmd {
+	args := make([]interface{}, 2+len(keys))
+	args[0] = "PFMERGE"
+	args[1] = dest
+	for i, key := range keys {
+		args[2+i] = key
+	}
+	cmd := NewStatusCmd(args...)
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) FlushDb() *StatusCmd {
-	cmd := newKeylessStatusCmd("FLUSHDB")
+//------------------------------------------------------------------------------
+
+func (c *commandable) BgRewriteAOF() *StatusCmd {
+	cmd := NewStatusCmd("BGREWRITEAOF")
+	cmd._clusterKeyPos = 0
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) Info() *StringCmd {
-	cmd := NewStringCmd("INFO")
+func (c *commandable) BgSave() *StatusCmd {
+	cmd := NewStatusCmd("BGSAVE")
 	cmd._clusterKeyPos = 0
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) LastSave() *IntCmd {
-	cmd := NewIntCmd("LASTSAVE")
+func (c *commandable) ClientKill(ipPort string) *StatusCmd {
+	cmd := NewStatusCmd("CLIENT", "KILL", ipPort)
 	cmd._clusterKeyPos = 0
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) Save() *StatusCmd {
-	cmd := newKeylessStatusCmd("SAVE")
+func (c *commandable) ClientList() *StringCmd {
+	cmd := NewStringCmd("CLIENT", "LIST")
+	cmd._clusterKeyPos = 0
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *commandable) shutdown(modifier string) *StatusCmd {
-	var args []interface{}
-	if modifier == "" {
-		args = []interface{}{"SHUTDOWN"}
-	} else {
-		args = []interface{}{"SHUTDOWN", modifier}
-	}
-	cmd := newKeylessStatusCmd(args...)
+// ClientSetName names a single pooled connection and should be used
+// only with dedicated connections, e.g. in Multi. Use
+// Options.ClientName to name all connections of the client.
+func (c *commandable) ClientSetName(name string) *StatusCmd {
+	cmd := newKeylessStatusCmd("CLIENT", "SETNAME", name)
 	c.Process(cmd)
-	if err := cmd.Err(); err != nil {
-		if err == io.EOF {
-			// Server quit as expected.
-			cmd.err = nil
-		}
+	return cmd
+}
+
+// ClientGetName returns the name of the connection. Nil error is
+// returned when the connection has no name.
+func (c *commandable) ClientGetName() *StringCmd {
+	cmd := newKeylessStringCmd("CLIENT", "GETNAME")
+	c.Process(cmd)
+	return cmd
+}
+
+// ClientTrackingInfo returns client side caching state of the
+// connection. Requires Redis 6.2.
+func (c *commandable) ClientTrackingInfo() *ClientTrackingInfoCmd {
+	cmd := NewClientTrackingInfoCmd("CLIENT", "TRACKINGINFO")
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) clientPause(dur time.Duration, mode string) *BoolCmd {
+	args := []interface{}{"CLIENT", "PAUSE", formatMs(dur)}
+	if mode != "" {
+		args = append(args, mode)
+	}
+	cmd := NewBoolCmd(args...)
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) ClientPause(dur time.Duration) *BoolCmd {
+	return c.clientPause(dur, "")
+}
+
+// ClientPauseWrite pauses only clients executing write commands.
+// Requires Redis >= 6.2.
+func (c *commandable) ClientPauseWrite(dur time.Duration) *BoolCmd {
+	return c.clientPause(dur, PauseWrite)
+}
+
+func (c *commandable) ClientUnpause() *BoolCmd {
+	cmd := NewBoolCmd("CLIENT", "UNPAUSE")
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) ConfigGet(parameter string) *SliceCmd {
+	cmd := NewSliceCmd("CONFIG", "GET", parameter)
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) ConfigResetStat() *StatusCmd {
+	cmd := NewStatusCmd("CONFIG", "RESETSTAT")
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) ConfigSet(parameter, value string) *StatusCmd {
+	cmd := NewStatusCmd("CONFIG", "SET", parameter, value)
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) DbSize() *IntCmd {
+	cmd := NewIntCmd("DBSIZE")
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) FlushAll() *StatusCmd {
+	cmd := newKeylessStatusCmd("FLUSHALL")
+	c.Process(cmd)
+	return cmd
+}
+
+// FlushAllAsync is like FlushAll, but deletes keys in a background
+// thread. Requires Redis 4.0.
+func (c *commandable) FlushAllAsync() *StatusCmd {
+	cmd := newKeylessStatusCmd("FLUSHALL", "ASYNC")
+	c.Process(cmd)
+	return cmd
+}
+
+// FlushAllSync is like FlushAll, but deletes keys synchronously even
+// when lazyfree-lazy-user-flush is enabled. Requires Redis 6.2.
+func (c *commandable) FlushAllSync() *StatusCmd {
+	cmd := newKeylessStatusCmd("FLUSHALL", "SYNC")
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) FlushDb() *StatusCmd {
+	cmd := newKeylessStatusCmd("FLUSHDB")
+	c.Process(cmd)
+	return cmd
+}
+
+// FlushDbAsync is like FlushDb, but deletes keys in a background
+// thread. Requires Redis 4.0.
+func (c *commandable) FlushDbAsync() *StatusCmd {
+	cmd := newKeylessStatusCmd("FLUSHDB", "ASYNC")
+	c.Process(cmd)
+	return cmd
+}
+
+// FlushDbSync is like FlushDb, but deletes keys synchronously even
+// when lazyfree-lazy-user-flush is enabled. Requires Redis 6.2.
+func (c *commandable) FlushDbSync() *StatusCmd {
+	cmd := newKeylessStatusCmd("FLUSHDB", "SYNC")
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) Info(section ...string) *StringCmd {
+	args := []interface{}{"INFO"}
+	if len(section) > 0 {
+		args = append(args, section[0])
+	}
+	cmd := NewStringCmd(args...)
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+// MemoryDoctor returns the memory problems report of the server. Use
+// ParseMemoryDoctor to get the reported issues. Requires Redis 4.0.
+func (c *commandable) MemoryDoctor() *StringCmd {
+	cmd := NewStringCmd("MEMORY", "DOCTOR")
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+// MemoryStats returns memory usage details of the server. Requires
+// Redis 4.0.
+func (c *commandable) MemoryStats() *MemoryStatsCmd {
+	cmd := NewMemoryStatsCmd("MEMORY", "STATS")
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+// MemoryPurge asks the allocator to release memory. Requires Redis 4.0
+// and jemalloc.
+func (c *commandable) MemoryPurge() *StatusCmd {
+	cmd := newKeylessStatusCmd("MEMORY", "PURGE")
+	c.Process(cmd)
+	return cmd
+}
+
+// MemoryUsage returns the number of bytes used by the key and its
+// value. Nested values are estimated by sampling samples elements; 0
+// samples all elements. Requires Redis 4.0.
+func (c *commandable) MemoryUsage(key string, samples ...int) *IntCmd {
+	args := []interface{}{"MEMORY", "USAGE", key}
+	if len(samples) > 0 {
+		args = append(args, "SAMPLES", formatInt(int64(samples[0])))
+	}
+	cmd := NewIntCmd(args...)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) LastSave() *IntCmd {
+	cmd := NewIntCmd("LASTSAVE")
+	cmd._clusterKeyPos = 0
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) Save() *StatusCmd {
+	cmd := newKeylessStatusCmd("SAVE")
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) shutdown(modifier string) *StatusCmd {
+	var args []interface{}
+	if modifier == "" {
+		args = []interface{}{"SHUTDOWN"}
+	} else {
+		args = []interface{}{"SHUTDOWN", modifier}
+	}
+	cmd := newKeylessStatusCmd(args...)
+	c.Process(cmd)
+	if err := cmd.Err(); err != nil {
+		if err == io.EOF {
+			// Server quit as expected.
+			cmd.err = nil
+		}
 	} else {
 		// Server did not quit. String reply contains the reason.
 		cmd.err = errorf(cmd.val)
@@ -1571,3 +2681,629 @@ func (c *commandable) ClusterAddSlotsRange(min, max int) *StatusCmd {
 	}
 	return c.ClusterAddSlots(slots...)
 }
+
+func (c *commandable) ReadOnly() *StatusCmd {
+	cmd := newKeylessStatusCmd("READONLY")
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) ReadWrite() *StatusCmd {
+	cmd := newKeylessStatusCmd("READWRITE")
+	c.Process(cmd)
+	return cmd
+}
+
+//------------------------------------------------------------------------------
+
+// GeoLocation is used with GeoAdd to add geospatial location and is
+// returned by GeoRadius commands.
+type GeoLocation struct {
+	Name                      string
+	Longitude, Latitude, Dist float64
+	GeoHash                   int64
+}
+
+// GeoRadiusQuery is used with GeoRadius to query geospatial index.
+type GeoRadiusQuery struct {
+	Radius float64
+	// Can be m, km, ft, or mi. Default is km.
+	Unit        string
+	WithCoord   bool
+	WithDist    bool
+	WithGeoHash bool
+	Count       int
+	// Can be ASC or DESC. Default is no sort order.
+	Sort string
+}
+
+func (c *commandable) GeoAdd(key string, geoLocation ...*GeoLocation) *IntCmd {
+	args := make([]interface{}, 2+3*len(geoLocation))
+	args[0] = "GEOADD"
+	args[1] = key
+	for i, eachLoc := range geoLocation {
+		args[2+3*i] = formatFloat(eachLoc.Longitude)
+		args[2+3*i+1] = formatFloat(eachLoc.Latitude)
+		args[2+3*i+2] = eachLoc.Name
+	}
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) GeoRadius(key string, longitude, latitude float64, query *GeoRadiusQuery) *GeoLocationCmd {
+	cmd := NewGeoLocationCmd(query, "GEORADIUS", key, formatFloat(longitude), formatFloat(latitude))
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) GeoRadiusByMember(key, member string, query *GeoRadiusQuery) *GeoLocationCmd {
+	cmd := NewGeoLocationCmd(query, "GEORADIUSBYMEMBER", key, member)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) GeoDist(key string, member1, member2, unit string) *FloatCmd {
+	if unit == "" {
+		unit = "km"
+	}
+	cmd := NewFloatCmd("GEODIST", key, member1, member2, unit)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) GeoHash(key string, members ...string) *StringSliceCmd {
+	args := make([]interface{}, 2+len(members))
+	args[0] = "GEOHASH"
+	args[1] = key
+	for i, member := range members {
+		args[2+i] = member
+	}
+	cmd := NewStringSliceCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) GeoPos(key string, members ...string) *GeoPosCmd {
+	args := make([]interface{}, 2+len(members))
+	args[0] = "GEOPOS"
+	args[1] = key
+	for i, member := range members {
+		args[2+i] = member
+	}
+	cmd := NewGeoPosCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// GeoSearchQuery is used with GeoSearch to query geospatial index.
+type GeoSearchQuery struct {
+	// Searches around the member. When Member is empty Longitude and
+	// Latitude are used.
+	Member              string
+	Longitude, Latitude float64
+
+	// Searches within the circle. When Radius is zero BoxWidth and
+	// BoxHeight are used.
+	Radius              float64
+	BoxWidth, BoxHeight float64
+	// Can be m, km, ft, or mi. Default is km.
+	Unit string
+
+	// Can be ASC or DESC. Default is no sort order.
+	Sort  string
+	Count int
+	// CountAny returns first Count matches found instead of Count
+	// nearest matches.
+	CountAny bool
+}
+
+func (q *GeoSearchQuery) args(args []interface{}) []interface{} {
+	if q.Member != "" {
+		args = append(args, "FROMMEMBER", q.Member)
+	} else {
+		args = append(args, "FROMLONLAT", formatFloat(q.Longitude), formatFloat(q.Latitude))
+	}
+
+	if q.Radius > 0 {
+		args = append(args, "BYRADIUS", formatFloat(q.Radius))
+	} else {
+		args = append(args, "BYBOX", formatFloat(q.BoxWidth), formatFloat(q.BoxHeight))
+	}
+	if q.Unit != "" {
+		args = append(args, q.Unit)
+	} else {
+		args = append(args, "km")
+	}
+
+	if q.Sort != "" {
+		args = append(args, q.Sort)
+	}
+	if q.Count > 0 {
+		args = append(args, "COUNT", formatInt(int64(q.Count)))
+		if q.CountAny {
+			args = append(args, "ANY")
+		}
+	}
+	return args
+}
+
+// GeoSearchLocationQuery is used with GeoSearchLocation to query
+// geospatial index returning locations.
+type GeoSearchLocationQuery struct {
+	GeoSearchQuery
+
+	WithCoord   bool
+	WithDist    bool
+	WithGeoHash bool
+}
+
+// GeoSearchStoreQuery is used with GeoSearchStore to store results of
+// the query.
+type GeoSearchStoreQuery struct {
+	GeoSearchQuery
+
+	// StoreDist stores distances from the center as scores instead of
+	// geohashes.
+	StoreDist bool
+}
+
+// GeoSearch returns names of members within the area. Requires
+// Redis 6.2.
+func (c *commandable) GeoSearch(key string, q *GeoSearchQuery) *StringSliceCmd {
+	cmd := NewStringSliceCmd(q.args([]interface{}{"GEOSEARCH", key})...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) GeoSearchLocation(key string, q *GeoSearchLocationQuery) *GeoLocationCmd {
+	args := q.GeoSearchQuery.args([]interface{}{"GEOSEARCH", key})
+	if q.WithCoord {
+		args = append(args, "WITHCOORD")
+	}
+	if q.WithDist {
+		args = append(args, "WITHDIST")
+	}
+	if q.WithGeoHash {
+		args = append(args, "WITHHASH")
+	}
+	cmd := &GeoLocationCmd{
+		baseCmd: baseCmd{_args: args, _clusterKeyPos: 1},
+		q: &GeoRadiusQuery{
+			WithCoord:   q.WithCoord,
+			WithDist:    q.WithDist,
+			WithGeoHash: q.WithGeoHash,
+		},
+	}
+	c.Process(cmd)
+	return cmd
+}
+
+// GeoSearchStore stores members within the area in the store key and
+// returns their number.
+func (c *commandable) GeoSearchStore(key, store string, q *GeoSearchStoreQuery) *IntCmd {
+	args := q.GeoSearchQuery.args([]interface{}{"GEOSEARCHSTORE", store, key})
+	if q.StoreDist {
+		args = append(args, "STOREDIST")
+	}
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+//------------------------------------------------------------------------------
+
+// XAddArgs is used with XAdd to append a message to a stream.
+type XAddArgs struct {
+	Stream string
+	// Trims the stream to exactly MaxLen messages.
+	MaxLen int64
+	// Trims the stream to approximately MaxLenApprox messages, which
+	// is more efficient than MaxLen.
+	MaxLenApprox int64
+	// Maximum number of messages evicted by MaxLenApprox trimming.
+	Limit int64
+	// NoMkStream does not create the stream if it does not exist; Nil
+	// is returned in that case.
+	NoMkStream bool
+	// Message ID. Default is "*", i.e. the ID is generated by Redis.
+	ID     string
+	Values map[string]interface{}
+}
+
+func (c *commandable) XAdd(a *XAddArgs) *StringCmd {
+	args := make([]interface{}, 0, 9+len(a.Values)*2)
+	args = append(args, "XADD", a.Stream)
+	if a.NoMkStream {
+		args = append(args, "NOMKSTREAM")
+	}
+	if a.MaxLen > 0 {
+		args = append(args, "MAXLEN", formatInt(a.MaxLen))
+	} else if a.MaxLenApprox > 0 {
+		args = append(args, "MAXLEN", "~", formatInt(a.MaxLenApprox))
+		if a.Limit > 0 {
+			args = append(args, "LIMIT", formatInt(a.Limit))
+		}
+	}
+	if a.ID != "" {
+		args = append(args, a.ID)
+	} else {
+		args = append(args, "*")
+	}
+	for k, v := range a.Values {
+		args = append(args, k, v)
+	}
+	cmd := NewStringCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XDel(stream string, ids ...string) *IntCmd {
+	args := make([]interface{}, 2+len(ids))
+	args[0] = "XDEL"
+	args[1] = stream
+	for i, id := range ids {
+		args[2+i] = id
+	}
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XLen(stream string) *IntCmd {
+	cmd := NewIntCmd("XLEN", stream)
+	c.Process(cmd)
+	return cmd
+}
+
+// XTrimMaxLen trims the stream to exactly maxLen messages.
+func (c *commandable) XTrimMaxLen(stream string, maxLen int64) *IntCmd {
+	cmd := NewIntCmd("XTRIM", stream, "MAXLEN", formatInt(maxLen))
+	c.Process(cmd)
+	return cmd
+}
+
+// XTrimMaxLenApprox trims the stream to approximately maxLen messages
+// evicting at most limit messages. Zero limit means default server
+// limit.
+func (c *commandable) XTrimMaxLenApprox(stream string, maxLen, limit int64) *IntCmd {
+	args := []interface{}{"XTRIM", stream, "MAXLEN", "~", formatInt(maxLen)}
+	if limit > 0 {
+		args = append(args, "LIMIT", formatInt(limit))
+	}
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// XTrimMinID evicts messages with IDs lower than minID.
+func (c *commandable) XTrimMinID(stream, minID string) *IntCmd {
+	cmd := NewIntCmd("XTRIM", stream, "MINID", minID)
+	c.Process(cmd)
+	return cmd
+}
+
+// XTrimMinIDApprox is like XTrimMinID, but evicts only whole
+// macro nodes and at most limit messages. Zero limit means default
+// server limit.
+func (c *commandable) XTrimMinIDApprox(stream, minID string, limit int64) *IntCmd {
+	args := []interface{}{"XTRIM", stream, "MINID", "~", minID}
+	if limit > 0 {
+		args = append(args, "LIMIT", formatInt(limit))
+	}
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// XSetID sets the last ID of the stream.
+func (c *commandable) XSetID(stream, id string) *StatusCmd {
+	cmd := NewStatusCmd("XSETID", stream, id)
+	c.Process(cmd)
+	return cmd
+}
+
+// XSetIDExt is like XSetID, but also sets the number of entries ever
+// added to the stream and the maximal deleted ID. Zero values are not
+// sent. Requires Redis 7.0.
+func (c *commandable) XSetIDExt(stream, id string, entriesAdded int64, maxDeletedID string) *StatusCmd {
+	args := []interface{}{"XSETID", stream, id}
+	if entriesAdded != 0 {
+		args = append(args, "ENTRIESADDED", formatInt(entriesAdded))
+	}
+	if maxDeletedID != "" {
+		args = append(args, "MAXDELETEDID", maxDeletedID)
+	}
+	cmd := NewStatusCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XRange(stream, start, stop string) *XMessageSliceCmd {
+	cmd := NewXMessageSliceCmd("XRANGE", stream, start, stop)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XRangeN(stream, start, stop string, count int64) *XMessageSliceCmd {
+	cmd := NewXMessageSliceCmd("XRANGE", stream, start, stop, "COUNT", formatInt(count))
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XRevRange(stream, start, stop string) *XMessageSliceCmd {
+	cmd := NewXMessageSliceCmd("XREVRANGE", stream, start, stop)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XRevRangeN(stream, start, stop string, count int64) *XMessageSliceCmd {
+	cmd := NewXMessageSliceCmd("XREVRANGE", stream, start, stop, "COUNT", formatInt(count))
+	c.Process(cmd)
+	return cmd
+}
+
+// XReadArgs is used with XRead to read messages from streams.
+type XReadArgs struct {
+	// Stream names followed by IDs to read after, e.g.
+	// []string{"s1", "s2", "0", "$"}.
+	Streams []string
+	// Maximum number of messages returned per stream.
+	Count int64
+	// Time to wait for new messages. Zero means that XRead does not
+	// block; Nil is returned when blocking XRead times out.
+	Block time.Duration
+}
+
+func (c *commandable) XRead(a *XReadArgs) *XStreamSliceCmd {
+	args := make([]interface{}, 0, 6+len(a.Streams))
+	args = append(args, "XREAD")
+	if a.Count > 0 {
+		args = append(args, "COUNT", formatInt(a.Count))
+	}
+	if a.Block > 0 {
+		args = append(args, "BLOCK", formatMs(a.Block))
+	}
+	args = append(args, "STREAMS")
+	keyPos := len(args)
+	for _, s := range a.Streams {
+		args = append(args, s)
+	}
+	cmd := NewXStreamSliceCmd(args...)
+	cmd._clusterKeyPos = keyPos
+	if a.Block > 0 {
+		cmd.setReadTimeout(readTimeout(a.Block))
+	}
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XReadStreams(streams ...string) *XStreamSliceCmd {
+	return c.XRead(&XReadArgs{
+		Streams: streams,
+	})
+}
+
+func (c *commandable) XGroupCreate(stream, group, start string) *StatusCmd {
+	cmd := NewStatusCmd("XGROUP", "CREATE", stream, group, start)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+// XGroupCreateMkStream is like XGroupCreate, but creates the stream if
+// it does not exist.
+func (c *commandable) XGroupCreateMkStream(stream, group, start string) *StatusCmd {
+	cmd := NewStatusCmd("XGROUP", "CREATE", stream, group, start, "MKSTREAM")
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+// XGroupSetID sets the last delivered ID of the consumer group.
+func (c *commandable) XGroupSetID(stream, group, start string) *StatusCmd {
+	cmd := NewStatusCmd("XGROUP", "SETID", stream, group, start)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XGroupDestroy(stream, group string) *IntCmd {
+	cmd := NewIntCmd("XGROUP", "DESTROY", stream, group)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XGroupDelConsumer(stream, group, consumer string) *IntCmd {
+	cmd := NewIntCmd("XGROUP", "DELCONSUMER", stream, group, consumer)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+// XReadGroupArgs is used with XReadGroup to read messages as a member
+// of consumer group.
+type XReadGroupArgs struct {
+	Group    string
+	Consumer string
+	// Stream names followed by IDs to read after, e.g.
+	// []string{"s1", "s2", ">", ">"}. ID ">" reads messages never
+	// delivered to other consumers.
+	Streams []string
+	// Maximum number of messages returned per stream.
+	Count int64
+	// Time to wait for new messages. Zero means that XReadGroup does
+	// not block; Nil is returned when blocking XReadGroup times out.
+	Block time.Duration
+	// NoAck does not add messages to the pending list, i.e. messages
+	// are acknowledged when they are read.
+	NoAck bool
+}
+
+func (c *commandable) XReadGroup(a *XReadGroupArgs) *XStreamSliceCmd {
+	args := make([]interface{}, 0, 10+len(a.Streams))
+	args = append(args, "XREADGROUP", "GROUP", a.Group, a.Consumer)
+	if a.Count > 0 {
+		args = append(args, "COUNT", formatInt(a.Count))
+	}
+	if a.Block > 0 {
+		args = append(args, "BLOCK", formatMs(a.Block))
+	}
+	if a.NoAck {
+		args = append(args, "NOACK")
+	}
+	args = append(args, "STREAMS")
+	keyPos := len(args)
+	for _, s := range a.Streams {
+		args = append(args, s)
+	}
+	cmd := NewXStreamSliceCmd(args...)
+	cmd._clusterKeyPos = keyPos
+	if a.Block > 0 {
+		cmd.setReadTimeout(readTimeout(a.Block))
+	}
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XAck(stream, group string, ids ...string) *IntCmd {
+	args := make([]interface{}, 3+len(ids))
+	args[0] = "XACK"
+	args[1] = stream
+	args[2] = group
+	for i, id := range ids {
+		args[3+i] = id
+	}
+	cmd := NewIntCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XPending(stream, group string) *XPendingCmd {
+	cmd := NewXPendingCmd("XPENDING", stream, group)
+	c.Process(cmd)
+	return cmd
+}
+
+// XPendingExtArgs is used with XPendingExt to list pending messages.
+type XPendingExtArgs struct {
+	Stream string
+	Group  string
+	// Range of message IDs, e.g. "-" and "+".
+	Start, End string
+	Count      int64
+	// Lists only messages pending for the consumer.
+	Consumer string
+}
+
+func (c *commandable) XPendingExt(a *XPendingExtArgs) *XPendingExtCmd {
+	args := []interface{}{"XPENDING", a.Stream, a.Group, a.Start, a.End, formatInt(a.Count)}
+	if a.Consumer != "" {
+		args = append(args, a.Consumer)
+	}
+	cmd := NewXPendingExtCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// XClaimArgs is used with XClaim to change ownership of pending
+// messages.
+type XClaimArgs struct {
+	Stream   string
+	Group    string
+	Consumer string
+	// Only messages idle for at least MinIdle are claimed.
+	MinIdle  time.Duration
+	Messages []string
+}
+
+func xClaimArgs(a *XClaimArgs) []interface{} {
+	args := make([]interface{}, 0, 6+len(a.Messages))
+	args = append(args, "XCLAIM", a.Stream, a.Group, a.Consumer, formatMs(a.MinIdle))
+	for _, id := range a.Messages {
+		args = append(args, id)
+	}
+	return args
+}
+
+func (c *commandable) XClaim(a *XClaimArgs) *XMessageSliceCmd {
+	cmd := NewXMessageSliceCmd(xClaimArgs(a)...)
+	c.Process(cmd)
+	return cmd
+}
+
+// XClaimJustID is like XClaim, but returns only IDs of claimed
+// messages and does not increment their delivery counters.
+func (c *commandable) XClaimJustID(a *XClaimArgs) *StringSliceCmd {
+	args := append(xClaimArgs(a), "JUSTID")
+	cmd := NewStringSliceCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+// XAutoClaimArgs is used with XAutoClaim to claim pending messages
+// idle for at least MinIdle.
+type XAutoClaimArgs struct {
+	Stream   string
+	Group    string
+	Consumer string
+	MinIdle  time.Duration
+	// ID to start scanning pending messages from. Default is "0-0".
+	Start string
+	// Maximum number of claimed messages. Default is 100.
+	Count int64
+}
+
+func xAutoClaimArgs(a *XAutoClaimArgs) []interface{} {
+	start := a.Start
+	if start == "" {
+		start = "0-0"
+	}
+	args := []interface{}{
+		"XAUTOCLAIM", a.Stream, a.Group, a.Consumer, formatMs(a.MinIdle), start,
+	}
+	if a.Count > 0 {
+		args = append(args, "COUNT", formatInt(a.Count))
+	}
+	return args
+}
+
+// XAutoClaim claims pending messages idle for at least MinIdle. It
+// returns claimed messages and the ID to start the next scan from,
+// which is "0-0" when the whole pending list was scanned.
+func (c *commandable) XAutoClaim(a *XAutoClaimArgs) *XAutoClaimCmd {
+	cmd := NewXAutoClaimCmd(xAutoClaimArgs(a)...)
+	c.Process(cmd)
+	return cmd
+}
+
+// XAutoClaimJustID is like XAutoClaim, but returns only IDs of claimed
+// messages and does not increment their delivery counters.
+func (c *commandable) XAutoClaimJustID(a *XAutoClaimArgs) *XAutoClaimJustIDCmd {
+	args := append(xAutoClaimArgs(a), "JUSTID")
+	cmd := NewXAutoClaimJustIDCmd(args...)
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XInfoStream(stream string) *XInfoStreamCmd {
+	cmd := NewXInfoStreamCmd("XINFO", "STREAM", stream)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XInfoGroups(stream string) *XInfoGroupsCmd {
+	cmd := NewXInfoGroupsCmd("XINFO", "GROUPS", stream)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
+
+func (c *commandable) XInfoConsumers(stream, group string) *XInfoConsumersCmd {
+	cmd := NewXInfoConsumersCmd("XINFO", "CONSUMERS", stream, group)
+	cmd._clusterKeyPos = 2
+	c.Process(cmd)
+	return cmd
+}
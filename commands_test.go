@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -38,7 +39,7 @@ var _ = Describe("Commands", func() {
 
 		It("should Auth", func() {
 			auth := client.Auth("password")
-			Expect(auth.Err()).To(MatchError("ERR Client sent AUTH, but no password is set"))
+			Expect(auth.Err()).To(MatchError("ERR AUTH <password> called without any password configured for the default user. Are you sure your configuration is correct?"))
 			Expect(auth.Val()).To(Equal(""))
 		})
 
@@ -79,6 +80,34 @@ var _ = Describe("Commands", func() {
 			Expect(r.Val()).To(Equal(""))
 		})
 
+		It("should ClientSetName and ClientGetName", func() {
+			multi := client.Multi()
+			defer multi.Close()
+
+			Expect(multi.ClientGetName().Err()).To(Equal(redis.Nil))
+
+			set := multi.ClientSetName("theclientname")
+			Expect(set.Err()).NotTo(HaveOccurred())
+			Expect(set.Val()).To(Equal("OK"))
+
+			get := multi.ClientGetName()
+			Expect(get.Err()).NotTo(HaveOccurred())
+			Expect(get.Val()).To(Equal("theclientname"))
+		})
+
+		It("should name connections using Options.ClientName", func() {
+			named := redis.NewClient(&redis.Options{
+				Addr:       redisAddr,
+				ClientName: "named",
+				PoolSize:   1,
+			})
+			defer named.Close()
+
+			name, err := named.ClientGetName().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(MatchRegexp(`^named-\d+-1$`))
+		})
+
 		It("should ClientPause", func() {
 			err := client.ClientPause(time.Second).Err()
 			Expect(err).NotTo(HaveOccurred())
@@ -92,6 +121,113 @@ var _ = Describe("Commands", func() {
 			}, "1s").ShouldNot(HaveOccurred())
 		})
 
+		It("should ClientPauseWrite and ClientUnpause", func() {
+			err := client.ClientPauseWrite(time.Second).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Read commands are not paused.
+			Expect(client.Ping().Err()).NotTo(HaveOccurred())
+
+			unpause := client.ClientUnpause()
+			Expect(unpause.Err()).NotTo(HaveOccurred())
+			Expect(unpause.Val()).To(BeTrue())
+
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+		})
+
+		It("should ClientTrackingInfo", func() {
+			info, err := client.ClientTrackingInfo().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info).To(Equal(&redis.ClientTrackingInfo{
+				Flags:    []string{"off"},
+				Redirect: -1,
+				Prefixes: []string{},
+			}))
+
+			multi := client.Multi()
+			defer multi.Close()
+
+			id := redis.NewIntCmd("CLIENT", "ID")
+			multi.Process(id)
+			Expect(id.Err()).NotTo(HaveOccurred())
+
+			tracking := redis.NewStatusCmd(
+				"CLIENT", "TRACKING", "ON", "REDIRECT", id.Val(), "BCAST", "PREFIX", "user:")
+			multi.Process(tracking)
+			Expect(tracking.Err()).NotTo(HaveOccurred())
+
+			info, err = multi.ClientTrackingInfo().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Flags).To(ConsistOf("on", "bcast"))
+			Expect(info.Redirect).To(Equal(id.Val()))
+			Expect(info.Prefixes).To(Equal([]string{"user:"}))
+		})
+
+		It("should FlushDbAsync", func() {
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+			res, err := client.FlushDbAsync().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal("OK"))
+			Expect(client.DbSize().Val()).To(Equal(int64(0)))
+
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.FlushDbSync().Err()).NotTo(HaveOccurred())
+			Expect(client.DbSize().Val()).To(Equal(int64(0)))
+		})
+
+		It("should FlushAllAsync", func() {
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+			res, err := client.FlushAllAsync().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal("OK"))
+			Expect(client.DbSize().Val()).To(Equal(int64(0)))
+
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.FlushAllSync().Err()).NotTo(HaveOccurred())
+			Expect(client.DbSize().Val()).To(Equal(int64(0)))
+		})
+
+		It("should MemoryDoctor", func() {
+			report, err := client.MemoryDoctor().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report).NotTo(BeEmpty())
+		})
+
+		It("should MemoryStats", func() {
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+			stats, err := client.MemoryStats().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stats.PeakAllocated).To(BeNumerically(">", 0))
+			Expect(stats.TotalAllocated).To(BeNumerically(">", 0))
+			Expect(stats.KeysCount).To(Equal(int64(1)))
+			Expect(stats.Fragmentation).To(BeNumerically(">", 0))
+			Expect(stats.DBs).To(HaveKey(int64(0)))
+			Expect(stats.DBs[0].OverheadHashtableMain).To(BeNumerically(">", 0))
+		})
+
+		It("should MemoryPurge", func() {
+			Expect(client.MemoryPurge().Err()).NotTo(HaveOccurred())
+		})
+
+		It("should MemoryUsage", func() {
+			usage := client.MemoryUsage("foo")
+			Expect(usage.Err()).To(Equal(redis.Nil))
+
+			Expect(client.Set("foo", "bar", 0).Err()).NotTo(HaveOccurred())
+
+			usage = client.MemoryUsage("foo")
+			Expect(usage.Err()).NotTo(HaveOccurred())
+			Expect(usage.Val()).To(BeNumerically(">", 0))
+
+			Expect(client.RPush("list", "a", "b", "c").Err()).NotTo(HaveOccurred())
+			usage = client.MemoryUsage("list", 0)
+			Expect(usage.Err()).NotTo(HaveOccurred())
+			Expect(usage.Val()).To(BeNumerically(">", 0))
+		})
+
 		It("should ConfigGet", func() {
 			r := client.ConfigGet("*")
 			Expect(r.Err()).NotTo(HaveOccurred())
@@ -192,6 +328,57 @@ var _ = Describe("Commands", func() {
 			Expect(del.Val()).To(Equal(int64(2)))
 		})
 
+		It("should Unlink", func() {
+			Expect(client.Set("key1", "Hello", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Set("key2", "World", 0).Err()).NotTo(HaveOccurred())
+
+			n, err := client.Unlink("key1", "key2", "key3").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+			Expect(client.Exists("key1").Val()).To(BeFalse())
+		})
+
+		It("should Touch", func() {
+			Expect(client.Set("key1", "Hello", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Set("key2", "World", 0).Err()).NotTo(HaveOccurred())
+
+			n, err := client.Touch("key1", "key2", "key3").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+		})
+
+		It("should Copy", func() {
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+			n, err := client.Copy("key", "newkey", 0, false).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+			Expect(client.Get("newkey").Val()).To(Equal("hello"))
+
+			Expect(client.Set("key", "world", 0).Err()).NotTo(HaveOccurred())
+			n, err = client.Copy("key", "newkey", 0, false).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+			Expect(client.Get("newkey").Val()).To(Equal("hello"))
+
+			n, err = client.Copy("key", "newkey", 0, true).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+			Expect(client.Get("newkey").Val()).To(Equal("world"))
+
+			n, err = client.Copy("key", "key", 1, false).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+
+			other := redis.NewClient(&redis.Options{
+				Addr: redisAddr,
+				DB:   1,
+			})
+			defer other.Close()
+			Expect(other.Get("key").Val()).To(Equal("world"))
+			Expect(other.FlushDb().Err()).NotTo(HaveOccurred())
+		})
+
 		It("should Dump", func() {
 			set := client.Set("key", "hello", 0)
 			Expect(set.Err()).NotTo(HaveOccurred())
@@ -238,6 +425,47 @@ var _ = Describe("Commands", func() {
 			Expect(ttl.Val() < 0).To(Equal(true))
 		})
 
+		It("should ExpireNX and ExpireXX", func() {
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+			Expect(client.ExpireXX("key", 10*time.Second).Val()).To(BeFalse())
+			Expect(client.TTL("key").Val()).To(Equal(time.Duration(-1)))
+
+			Expect(client.ExpireNX("key", 10*time.Second).Val()).To(BeTrue())
+			Expect(client.ExpireNX("key", 20*time.Second).Val()).To(BeFalse())
+			Expect(client.TTL("key").Val()).To(Equal(10 * time.Second))
+
+			Expect(client.ExpireXX("key", 20*time.Second).Val()).To(BeTrue())
+			Expect(client.TTL("key").Val()).To(Equal(20 * time.Second))
+		})
+
+		It("should ExpireGT and ExpireLT", func() {
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+			Expect(client.ExpireGT("key", 10*time.Second).Val()).To(BeFalse())
+			Expect(client.ExpireLT("key", 10*time.Second).Val()).To(BeTrue())
+
+			Expect(client.ExpireGT("key", 5*time.Second).Val()).To(BeFalse())
+			Expect(client.ExpireGT("key", 20*time.Second).Val()).To(BeTrue())
+			Expect(client.TTL("key").Val()).To(Equal(20 * time.Second))
+
+			Expect(client.ExpireLT("key", 30*time.Second).Val()).To(BeFalse())
+			Expect(client.ExpireLT("key", 15*time.Second).Val()).To(BeTrue())
+			Expect(client.TTL("key").Val()).To(Equal(15 * time.Second))
+		})
+
+		It("should PExpireNX, PExpireXX, PExpireGT and PExpireLT", func() {
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+			Expect(client.PExpireXX("key", 10*time.Second).Val()).To(BeFalse())
+			Expect(client.PExpireNX("key", 10*time.Second).Val()).To(BeTrue())
+			Expect(client.PExpireGT("key", 5*time.Second).Val()).To(BeFalse())
+			Expect(client.PExpireLT("key", 5*time.Second).Val()).To(BeTrue())
+
+			ttl := client.PTTL("key").Val()
+			Expect(ttl).To(BeNumerically("~", 5*time.Second, 100*time.Millisecond))
+		})
+
 		It("should ExpireAt", func() {
 			set := client.Set("key", "Hello", 0)
 			Expect(set.Err()).NotTo(HaveOccurred())
@@ -288,6 +516,25 @@ var _ = Describe("Commands", func() {
 			Expect(migrate.Val()).To(Equal(""))
 		})
 
+		It("should MigrateArgs", func() {
+			migrate := client.MigrateArgs("localhost", redisSecondaryPort, 0, 0, redis.MigrateArgs{
+				Keys: []string{"key1", "key2"},
+			})
+			Expect(migrate.Err()).NotTo(HaveOccurred())
+			Expect(migrate.Val()).To(Equal("NOKEY"))
+
+			Expect(client.MSet("key1", "hello1", "key2", "hello2").Err()).NotTo(HaveOccurred())
+
+			migrate = client.MigrateArgs("localhost", redisSecondaryPort, 0, 0, redis.MigrateArgs{
+				Keys:     []string{"key1", "key2"},
+				Copy:     true,
+				Replace:  true,
+				Password: "secret",
+			})
+			Expect(migrate.Err()).To(MatchError("IOERR error or timeout writing to target instance"))
+			Expect(migrate.Val()).To(Equal(""))
+		})
+
 		It("should Move", func() {
 			move := client.Move("key", 1)
 			Expect(move.Err()).NotTo(HaveOccurred())
@@ -333,6 +580,21 @@ var _ = Describe("Commands", func() {
 			Expect(idleTime.Val()).To(Equal(time.Duration(0)))
 		})
 
+		It("should ObjectFreq", func() {
+			Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+			err := client.ObjectFreq("key").Err()
+			Expect(err).To(MatchError(ContainSubstring("LFU")))
+
+			policy := client.ConfigGet("maxmemory-policy").Val()
+			Expect(client.ConfigSet("maxmemory-policy", "allkeys-lfu").Err()).NotTo(HaveOccurred())
+			defer client.ConfigSet("maxmemory-policy", policy[1].(string))
+
+			freq := client.ObjectFreq("key")
+			Expect(freq.Err()).NotTo(HaveOccurred())
+			Expect(freq.Val()).To(BeNumerically(">=", 0))
+		})
+
 		It("should Persist", func() {
 			set := client.Set("key", "Hello", 0)
 			Expect(set.Err()).NotTo(HaveOccurred())
@@ -493,6 +755,38 @@ var _ = Describe("Commands", func() {
 			Expect(val).To(Equal("hello"))
 		})
 
+		It("should RestoreArgs", func() {
+			err := client.Set("key", "hello", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			dump := client.Dump("key")
+			Expect(dump.Err()).NotTo(HaveOccurred())
+
+			expireAt := time.Now().Add(time.Hour)
+			restore, err := client.RestoreArgs("key", dump.Val(), redis.RestoreArgs{
+				ExpireAt: expireAt,
+				Replace:  true,
+				IdleTime: 100 * time.Second,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restore).To(Equal("OK"))
+
+			val, err := client.Get("key").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("hello"))
+
+			ttl, err := client.TTL("key").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(BeNumerically("~", time.Hour, time.Minute))
+
+			idle, err := client.ObjectIdleTime("key").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(idle).To(BeNumerically(">=", 100*time.Second))
+
+			err = client.RestoreArgs("key", dump.Val(), redis.RestoreArgs{}).Err()
+			Expect(err).To(MatchError("BUSYKEY Target key name already exists."))
+		})
+
 		It("should Sort", func() {
 			lPush := client.LPush("list", "1")
 			Expect(lPush.Err()).NotTo(HaveOccurred())
@@ -509,6 +803,40 @@ var _ = Describe("Commands", func() {
 			Expect(sort.Val()).To(Equal([]string{"1", "2"}))
 		})
 
+		It("should SortStore", func() {
+			Expect(client.RPush("list", "1", "3", "2").Err()).NotTo(HaveOccurred())
+
+			n, err := client.SortStore("list", "list2", redis.Sort{Order: "DESC"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(3)))
+
+			vals, err := client.LRange("list2", 0, -1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"3", "2", "1"}))
+		})
+
+		It("should SortInterfaces", func() {
+			Expect(client.RPush("list", "1", "2").Err()).NotTo(HaveOccurred())
+			Expect(client.Set("object_1", "value1", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Set("weight_1", "5", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Set("weight_2", "1", 0).Err()).NotTo(HaveOccurred())
+
+			vals, err := client.SortInterfaces("list", redis.Sort{
+				By:  "weight_*",
+				Get: []string{"#", "object_*"},
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]interface{}{"2", nil, "1", "value1"}))
+		})
+
+		It("should SortRO", func() {
+			Expect(client.RPush("list", "1", "3", "2").Err()).NotTo(HaveOccurred())
+
+			vals, err := client.SortRO("list", redis.Sort{Offset: 0, Count: 2, Order: "ASC"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"1", "2"}))
+		})
+
 		It("should TTL", func() {
 			ttl := client.TTL("key")
 			Expect(ttl.Err()).NotTo(HaveOccurred())
@@ -555,6 +883,30 @@ var _ = Describe("Commands", func() {
 			Expect(len(keys) > 0).To(Equal(true))
 		})
 
+		It("should ScanType", func() {
+			for i := 0; i < 100; i++ {
+				Expect(client.Set(fmt.Sprintf("key%d", i), "hello", 0).Err()).NotTo(HaveOccurred())
+				Expect(client.ZAdd(fmt.Sprintf("zset%d", i), redis.Z{1, "one"}).Err()).NotTo(HaveOccurred())
+			}
+
+			var keys []string
+			var cursor int64
+			for {
+				var page []string
+				var err error
+				cursor, page, err = client.ScanType(cursor, "", 10, "zset").Result()
+				Expect(err).NotTo(HaveOccurred())
+				keys = append(keys, page...)
+				if cursor == 0 {
+					break
+				}
+			}
+			Expect(keys).To(HaveLen(100))
+			for _, key := range keys {
+				Expect(key).To(HavePrefix("zset"))
+			}
+		})
+
 		It("should SScan", func() {
 			for i := 0; i < 1000; i++ {
 				sadd := client.SAdd("myset", fmt.Sprintf("member%d", i))
@@ -591,6 +943,28 @@ var _ = Describe("Commands", func() {
 			Expect(len(keys) > 0).To(Equal(true))
 		})
 
+		It("should Scan with MATCH", func() {
+			for i := 0; i < 100; i++ {
+				set := client.Set(fmt.Sprintf("key%d", i), "hello", 0)
+				Expect(set.Err()).NotTo(HaveOccurred())
+			}
+
+			_, keys, err := client.Scan(0, "key1?", 1000).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(10))
+		})
+
+		It("should reject malformed MATCH patterns", func() {
+			err := client.Scan(0, "key[1", 0).Err()
+			Expect(err).To(MatchError("redis: MATCH pattern has unterminated character class"))
+
+			err = client.SScan("myset", 0, `key\`, 0).Err()
+			Expect(err).To(MatchError("redis: MATCH pattern ends with escape"))
+
+			err = client.HScan("myhash", 0, strings.Repeat("a*", 100), 0).Err()
+			Expect(err).To(MatchError("redis: MATCH pattern has too many wildcards"))
+		})
+
 	})
 
 	//------------------------------------------------------------------------------
@@ -624,13 +998,21 @@ var _ = Describe("Commands", func() {
 			Expect(bitCount.Err()).NotTo(HaveOccurred())
 			Expect(bitCount.Val()).To(Equal(int64(26)))
 
-			bitCount = client.BitCount("key", &redis.BitCount{0, 0})
+			bitCount = client.BitCount("key", &redis.BitCount{Start: 0, End: 0})
 			Expect(bitCount.Err()).NotTo(HaveOccurred())
 			Expect(bitCount.Val()).To(Equal(int64(4)))
 
-			bitCount = client.BitCount("key", &redis.BitCount{1, 1})
+			bitCount = client.BitCount("key", &redis.BitCount{Start: 1, End: 1})
+			Expect(bitCount.Err()).NotTo(HaveOccurred())
+			Expect(bitCount.Val()).To(Equal(int64(6)))
+
+			bitCount = client.BitCount("key", &redis.BitCount{Start: 1, End: 1, Unit: "BYTE"})
 			Expect(bitCount.Err()).NotTo(HaveOccurred())
 			Expect(bitCount.Val()).To(Equal(int64(6)))
+
+			bitCount = client.BitCount("key", &redis.BitCount{Start: 5, End: 30, Unit: "BIT"})
+			Expect(bitCount.Err()).NotTo(HaveOccurred())
+			Expect(bitCount.Val()).To(Equal(int64(17)))
 		})
 
 		It("should BitOpAnd", func() {
@@ -701,6 +1083,34 @@ var _ = Describe("Commands", func() {
 			Expect(get.Val()).To(Equal("\xff"))
 		})
 
+		It("should BitField", func() {
+			nn, err := client.BitField("mykey", "INCRBY", "i5", 100, 1, "GET", "u4", 0).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nn).To(Equal([]int64{1, 0}))
+
+			args := new(redis.BitFieldArgs).Set("u8", 0, 255).Overflow("SAT").IncrBy("u8", 0, 10).Get("u8", 0)
+			nn, err = client.BitField("counters", args.Args()...).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nn).To(Equal([]int64{0, 255, 255}))
+
+			args = new(redis.BitFieldArgs).Overflow("FAIL").IncrBy("u8", 0, 1).IncrBy("u8", 8, 1)
+			nn, err = client.BitField("counters", args.Args()...).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nn).To(Equal([]int64{0, 1}))
+		})
+
+		It("should BitFieldRO", func() {
+			err := client.Set("mykey", "\xff", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			nn, err := client.BitFieldRO("mykey", new(redis.BitFieldArgs).Get("u4", 0).Get("u4", 4).Args()...).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nn).To(Equal([]int64{15, 15}))
+
+			err = client.BitFieldRO("mykey", "SET", "u4", 0, 1).Err()
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("should BitPos", func() {
 			err := client.Set("mykey", "\xff\xf0\x00", 0).Err()
 			Expect(err).NotTo(HaveOccurred())
@@ -742,6 +1152,27 @@ var _ = Describe("Commands", func() {
 			Expect(pos).To(Equal(int64(-1)))
 		})
 
+		It("should BitPosSpan", func() {
+			err := client.Set("mykey", "\xff\xf0\x00", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			pos, err := client.BitPosSpan("mykey", 0, nil).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(Equal(int64(12)))
+
+			pos, err = client.BitPosSpan("mykey", 0, &redis.BitPosSpan{Start: 2, End: -1}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(Equal(int64(16)))
+
+			pos, err = client.BitPosSpan("mykey", 0, &redis.BitPosSpan{Start: 0, End: 11, Unit: "BIT"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(Equal(int64(-1)))
+
+			pos, err = client.BitPosSpan("mykey", 0, &redis.BitPosSpan{Start: 5, End: 15, Unit: "BIT"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(Equal(int64(12)))
+		})
+
 		It("should Decr", func() {
 			set := client.Set("key", "10", 0)
 			Expect(set.Err()).NotTo(HaveOccurred())
@@ -824,6 +1255,22 @@ var _ = Describe("Commands", func() {
 			Expect(getRange.Val()).To(Equal("string"))
 		})
 
+		It("should GetDel", func() {
+			set := client.Set("key", "value", 0)
+			Expect(set.Err()).NotTo(HaveOccurred())
+
+			getDel := client.GetDel("key")
+			Expect(getDel.Err()).NotTo(HaveOccurred())
+			Expect(getDel.Val()).To(Equal("value"))
+
+			getDel = client.GetDel("key")
+			Expect(getDel.Err()).To(Equal(redis.Nil))
+
+			exists := client.Exists("key")
+			Expect(exists.Err()).NotTo(HaveOccurred())
+			Expect(exists.Val()).To(BeFalse())
+		})
+
 		It("should GetSet", func() {
 			incr := client.Incr("key")
 			Expect(incr.Err()).NotTo(HaveOccurred())
@@ -886,6 +1333,45 @@ var _ = Describe("Commands", func() {
 			Expect(incrByFloat.Val()).To(Equal(float64(996945661)))
 		})
 
+		It("should LCS", func() {
+			Expect(client.MSet("key1", "ohmytext", "key2", "mynewtext").Err()).NotTo(HaveOccurred())
+
+			lcs := client.LCS(redis.LCSQuery{Key1: "key1", Key2: "key2"})
+			Expect(lcs.Err()).NotTo(HaveOccurred())
+			Expect(lcs.Val().MatchString).To(Equal("mytext"))
+
+			lcs = client.LCS(redis.LCSQuery{Key1: "key1", Key2: "key2", Len: true})
+			Expect(lcs.Err()).NotTo(HaveOccurred())
+			Expect(lcs.Val()).To(Equal(&redis.LCSMatch{Len: 6}))
+
+			lcs = client.LCS(redis.LCSQuery{Key1: "key1", Key2: "key2", Idx: true})
+			Expect(lcs.Err()).NotTo(HaveOccurred())
+			Expect(lcs.Val()).To(Equal(&redis.LCSMatch{
+				Matches: []redis.LCSMatchedPosition{
+					{Key1: redis.LCSPosition{Start: 4, End: 7}, Key2: redis.LCSPosition{Start: 5, End: 8}},
+					{Key1: redis.LCSPosition{Start: 2, End: 3}, Key2: redis.LCSPosition{Start: 0, End: 1}},
+				},
+				Len: 6,
+			}))
+
+			lcs = client.LCS(redis.LCSQuery{
+				Key1:         "key1",
+				Key2:         "key2",
+				Idx:          true,
+				MinMatchLen:  4,
+				WithMatchLen: true,
+			})
+			Expect(lcs.Err()).NotTo(HaveOccurred())
+			Expect(lcs.Val()).To(Equal(&redis.LCSMatch{
+				Matches: []redis.LCSMatchedPosition{{
+					Key1:     redis.LCSPosition{Start: 4, End: 7},
+					Key2:     redis.LCSPosition{Start: 5, End: 8},
+					MatchLen: 4,
+				}},
+				Len: 6,
+			}))
+		})
+
 		It("should MSetMGet", func() {
 			mSet := client.MSet("key1", "hello1", "key2", "hello2")
 			Expect(mSet.Err()).NotTo(HaveOccurred())
@@ -919,6 +1405,50 @@ var _ = Describe("Commands", func() {
 			}, "1s", "100ms").Should(Equal(redis.Nil))
 		})
 
+		It("should SetArgs", func() {
+			err := client.SetArgs("key", "hello", redis.SetArgs{TTL: 10 * time.Second}).Err()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.TTL("key").Val()).To(Equal(10 * time.Second))
+
+			err = client.SetArgs("key", "world", redis.SetArgs{KeepTTL: true}).Err()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Get("key").Val()).To(Equal("world"))
+			Expect(client.TTL("key").Val()).To(Equal(10 * time.Second))
+
+			expireAt := time.Now().Add(time.Hour).Truncate(time.Second)
+			err = client.SetArgs("key", "hello", redis.SetArgs{ExpireAt: expireAt}).Err()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.TTL("key").Val()).To(BeNumerically("~", time.Hour, 2*time.Second))
+
+			expireAt = time.Now().Add(time.Hour).Add(500 * time.Millisecond)
+			err = client.SetArgs("key", "hello", redis.SetArgs{ExpireAt: expireAt}).Err()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.PTTL("key").Val()).To(BeNumerically("~", time.Hour+500*time.Millisecond, time.Second))
+
+			err = client.SetArgs("key", "hello", redis.SetArgs{Mode: "NX"}).Err()
+			Expect(err).To(Equal(redis.Nil))
+
+			err = client.SetArgs("key2", "hello", redis.SetArgs{Mode: "XX"}).Err()
+			Expect(err).To(Equal(redis.Nil))
+			Expect(client.Exists("key2").Val()).To(BeFalse())
+		})
+
+		It("should SetWithArgs", func() {
+			err := client.SetWithArgs("key", "hello", redis.SetArgs{}).Err()
+			Expect(err).To(Equal(redis.Nil))
+
+			old, err := client.SetWithArgs("key", "world", redis.SetArgs{TTL: time.Minute}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(old).To(Equal("hello"))
+			Expect(client.Get("key").Val()).To(Equal("world"))
+			Expect(client.TTL("key").Val()).To(Equal(time.Minute))
+
+			old, err = client.SetWithArgs("key", "again", redis.SetArgs{KeepTTL: true}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(old).To(Equal("world"))
+			Expect(client.TTL("key").Val()).To(Equal(time.Minute))
+		})
+
 		It("should SetGet", func() {
 			set := client.Set("key", "hello", 0)
 			Expect(set.Err()).NotTo(HaveOccurred())
@@ -1104,6 +1634,70 @@ var _ = Describe("Commands", func() {
 			Expect(hIncrByFloat.Val()).To(Equal(float64(5200)))
 		})
 
+		It("should HRandField", func() {
+			Expect(client.HMSet("hash", "key1", "hello1", "key2", "hello2").Err()).NotTo(HaveOccurred())
+
+			fields, err := client.HRandField("hash", 5).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fields).To(ConsistOf("key1", "key2"))
+
+			fields, err = client.HRandField("hash", -5).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fields).To(HaveLen(5))
+
+			vals, err := client.HRandFieldWithValues("hash", 2).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal(map[string]string{"key1": "hello1", "key2": "hello2"}))
+		})
+
+		It("should HStrLen", func() {
+			Expect(client.HSet("hash", "key", "hello").Err()).NotTo(HaveOccurred())
+
+			n, err := client.HStrLen("hash", "key").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(5)))
+
+			n, err = client.HStrLen("hash", "nokey").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+		})
+
+		It("should HExpire", func() {
+			Expect(client.HMSet("hash", "key1", "hello1", "key2", "hello2").Err()).NotTo(HaveOccurred())
+
+			res, err := client.HExpire("hash", time.Minute, "key1", "nokey").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]int64{1, -2}))
+
+			res, err = client.HPExpire("hash", 1500*time.Millisecond, "key2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]int64{1}))
+
+			ttls, err := client.HTTL("hash", "key1", "key2", "nokey").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttls).To(HaveLen(3))
+			Expect(ttls[0]).To(BeNumerically("~", 60, 1))
+			Expect(ttls[1]).To(BeNumerically("~", 1, 1))
+			Expect(ttls[2]).To(Equal(int64(-2)))
+
+			pttls, err := client.HPTTL("hash", "key2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pttls[0]).To(BeNumerically("~", 1500, 100))
+
+			res, err = client.HPersist("hash", "key1", "nokey").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]int64{1, -2}))
+
+			res, err = client.HPersist("hash", "key1").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]int64{-1}))
+
+			Eventually(func() bool {
+				return client.HExists("hash", "key2").Val()
+			}, "3s").Should(BeFalse())
+			Expect(client.HGet("hash", "key1").Val()).To(Equal("hello1"))
+		})
+
 		It("should HKeys", func() {
 			hkeys := client.HKeys("hash")
 			Expect(hkeys.Err()).NotTo(HaveOccurred())
@@ -1328,18 +1922,92 @@ var _ = Describe("Commands", func() {
 			Expect(lRange.Val()).To(Equal([]string{"Hello", "There", "World"}))
 		})
 
-		It("should LLen", func() {
-			lPush := client.LPush("list", "World")
-			Expect(lPush.Err()).NotTo(HaveOccurred())
-			lPush = client.LPush("list", "Hello")
-			Expect(lPush.Err()).NotTo(HaveOccurred())
+		It("should LPos", func() {
+			Expect(client.RPush("list", "a", "b", "c", "b", "b").Err()).NotTo(HaveOccurred())
 
-			lLen := client.LLen("list")
-			Expect(lLen.Err()).NotTo(HaveOccurred())
-			Expect(lLen.Val()).To(Equal(int64(2)))
-		})
+			pos, err := client.LPos("list", "b", redis.LPosArgs{}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(Equal(int64(1)))
 
-		It("should LPop", func() {
+			pos, err = client.LPos("list", "b", redis.LPosArgs{Rank: 2}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(Equal(int64(3)))
+
+			pos, err = client.LPos("list", "b", redis.LPosArgs{Rank: -1}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(Equal(int64(4)))
+
+			_, err = client.LPos("list", "b", redis.LPosArgs{MaxLen: 1}).Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			_, err = client.LPos("list", "z", redis.LPosArgs{}).Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should LPosCount", func() {
+			Expect(client.RPush("list", "a", "b", "c", "b", "b").Err()).NotTo(HaveOccurred())
+
+			pos, err := client.LPosCount("list", "b", 2, redis.LPosArgs{}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(Equal([]int64{1, 3}))
+
+			pos, err = client.LPosCount("list", "b", 0, redis.LPosArgs{Rank: -1}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(Equal([]int64{4, 3, 1}))
+
+			pos, err = client.LPosCount("list", "z", 0, redis.LPosArgs{}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos).To(BeEmpty())
+		})
+
+		It("should LMPop", func() {
+			Expect(client.RPush("list2", "a", "b", "c").Err()).NotTo(HaveOccurred())
+
+			key, vals, err := client.LMPop("LEFT", 2, "list1", "list2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal("list2"))
+			Expect(vals).To(Equal([]string{"a", "b"}))
+
+			key, vals, err = client.LMPop("RIGHT", 0, "list1", "list2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal("list2"))
+			Expect(vals).To(Equal([]string{"c"}))
+
+			_, _, err = client.LMPop("LEFT", 1, "list1", "list2").Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should BLMPop", func() {
+			done := make(chan []string)
+			go func() {
+				defer GinkgoRecover()
+
+				key, vals, err := client.BLMPop(0, "LEFT", 10, "list1", "list2").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(key).To(Equal("list1"))
+				done <- vals
+			}()
+
+			Consistently(done).ShouldNot(Receive())
+			Expect(client.RPush("list1", "a", "b").Err()).NotTo(HaveOccurred())
+			Eventually(done).Should(Receive(Equal([]string{"a", "b"})))
+
+			_, _, err := client.BLMPop(time.Second, "LEFT", 1, "list1").Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should LLen", func() {
+			lPush := client.LPush("list", "World")
+			Expect(lPush.Err()).NotTo(HaveOccurred())
+			lPush = client.LPush("list", "Hello")
+			Expect(lPush.Err()).NotTo(HaveOccurred())
+
+			lLen := client.LLen("list")
+			Expect(lLen.Err()).NotTo(HaveOccurred())
+			Expect(lLen.Val()).To(Equal(int64(2)))
+		})
+
+		It("should LPop", func() {
 			rPush := client.RPush("list", "one")
 			Expect(rPush.Err()).NotTo(HaveOccurred())
 			rPush = client.RPush("list", "two")
@@ -1683,6 +2351,14 @@ var _ = Describe("Commands", func() {
 			Expect(sIsMember.Val()).To(Equal(false))
 		})
 
+		It("should SMIsMember", func() {
+			Expect(client.SAdd("set", "one", "two").Err()).NotTo(HaveOccurred())
+
+			vals, err := client.SMIsMember("set", "one", "three", []byte("two")).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]bool{true, false, true}))
+		})
+
 		It("should SMembers", func() {
 			sAdd := client.SAdd("set", "Hello")
 			Expect(sAdd.Err()).NotTo(HaveOccurred())
@@ -1750,6 +2426,37 @@ var _ = Describe("Commands", func() {
 			Expect(sMembers.Val()).To(HaveLen(3))
 		})
 
+		It("should SPopN", func() {
+			Expect(client.SAdd("set", "one", "two", "three", "four").Err()).NotTo(HaveOccurred())
+
+			members, err := client.SPopN("set", 3).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(HaveLen(3))
+
+			left, err := client.SMembers("set").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(append(members, left...)).To(ConsistOf("one", "two", "three", "four"))
+
+			members, err = client.SPopN("set", 10).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal(left))
+			Expect(client.Exists("set").Val()).To(BeFalse())
+		})
+
+		It("should SRandMemberN", func() {
+			Expect(client.SAdd("set", "one", "two", "three").Err()).NotTo(HaveOccurred())
+
+			members, err := client.SRandMemberN("set", 5).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(ConsistOf("one", "two", "three"))
+
+			members, err = client.SRandMemberN("set", -5).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(HaveLen(5))
+
+			Expect(client.SCard("set").Val()).To(Equal(int64(3)))
+		})
+
 		It("should SRem", func() {
 			sAdd := client.SAdd("set", "one")
 			Expect(sAdd.Err()).NotTo(HaveOccurred())
@@ -1865,6 +2572,233 @@ var _ = Describe("Commands", func() {
 			Expect(val).To(Equal([]redis.Z{{1, "one"}, {1, "uno"}, {3, "two"}}))
 		})
 
+		It("should ZAddNX", func() {
+			added, err := client.ZAddNX("zset", redis.Z{1, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(added).To(Equal(int64(1)))
+
+			added, err = client.ZAddNX("zset", redis.Z{2, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(added).To(Equal(int64(0)))
+
+			val, err := client.ZRangeWithScores("zset", 0, -1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal([]redis.Z{{1, "one"}}))
+		})
+
+		It("should ZAddXX", func() {
+			added, err := client.ZAddXX("zset", redis.Z{1, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(added).To(Equal(int64(0)))
+			Expect(client.ZCard("zset").Val()).To(Equal(int64(0)))
+
+			Expect(client.ZAdd("zset", redis.Z{1, "one"}).Err()).NotTo(HaveOccurred())
+			added, err = client.ZAddXX("zset", redis.Z{2, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(added).To(Equal(int64(0)))
+
+			val, err := client.ZRangeWithScores("zset", 0, -1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal([]redis.Z{{2, "one"}}))
+		})
+
+		It("should ZAddCh", func() {
+			changed, err := client.ZAddCh("zset", redis.Z{1, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(Equal(int64(1)))
+
+			changed, err = client.ZAddCh("zset", redis.Z{1, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(Equal(int64(0)))
+
+			changed, err = client.ZAddNXCh("zset", redis.Z{2, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(Equal(int64(0)))
+
+			changed, err = client.ZAddXXCh("zset", redis.Z{2, "one"}, redis.Z{3, "two"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(Equal(int64(1)))
+
+			val, err := client.ZRangeWithScores("zset", 0, -1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal([]redis.Z{{2, "one"}}))
+		})
+
+		It("should ZIncr", func() {
+			score, err := client.ZIncr("zset", redis.Z{1, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(score).To(Equal(float64(1)))
+
+			score, err = client.ZIncr("zset", redis.Z{1.5, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(score).To(Equal(float64(2.5)))
+		})
+
+		It("should ZIncrNX", func() {
+			score, err := client.ZIncrNX("zset", redis.Z{1, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(score).To(Equal(float64(1)))
+
+			_, err = client.ZIncrNX("zset", redis.Z{1, "one"}).Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			Expect(client.ZScore("zset", "one").Val()).To(Equal(float64(1)))
+		})
+
+		It("should ZIncrXX", func() {
+			_, err := client.ZIncrXX("zset", redis.Z{1, "one"}).Result()
+			Expect(err).To(Equal(redis.Nil))
+			Expect(client.ZCard("zset").Val()).To(Equal(int64(0)))
+
+			Expect(client.ZAdd("zset", redis.Z{1, "one"}).Err()).NotTo(HaveOccurred())
+			score, err := client.ZIncrXX("zset", redis.Z{1, "one"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(score).To(Equal(float64(2)))
+		})
+
+		It("should ZPopMin", func() {
+			Expect(client.ZAdd("zset", redis.Z{1, "one"}, redis.Z{2, "two"}, redis.Z{3, "three"}).Err()).NotTo(HaveOccurred())
+
+			members, err := client.ZPopMin("zset", 1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([]redis.Z{{1, "one"}}))
+
+			members, err = client.ZPopMin("zset", 10).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([]redis.Z{{2, "two"}, {3, "three"}}))
+
+			members, err = client.ZPopMin("zset", 1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(BeEmpty())
+		})
+
+		It("should ZPopMax", func() {
+			Expect(client.ZAdd("zset", redis.Z{1, "one"}, redis.Z{2, "two"}, redis.Z{3, "three"}).Err()).NotTo(HaveOccurred())
+
+			members, err := client.ZPopMax("zset", 2).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(Equal([]redis.Z{{3, "three"}, {2, "two"}}))
+
+			Expect(client.ZRange("zset", 0, -1).Val()).To(Equal([]string{"one"}))
+		})
+
+		It("should BZPopMin", func() {
+			Expect(client.ZAdd("zset1", redis.Z{1, "one"}, redis.Z{2, "two"}).Err()).NotTo(HaveOccurred())
+
+			member, err := client.BZPopMin(0, "zset0", "zset1").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(member).To(Equal(redis.ZWithKey{Z: redis.Z{1, "one"}, Key: "zset1"}))
+
+			member, err = client.BZPopMax(0, "zset0", "zset1").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(member).To(Equal(redis.ZWithKey{Z: redis.Z{2, "two"}, Key: "zset1"}))
+		})
+
+		It("should BZPopMin blocks", func() {
+			done := make(chan redis.ZWithKey)
+			go func() {
+				defer GinkgoRecover()
+
+				member, err := client.BZPopMin(0, "zset").Result()
+				Expect(err).NotTo(HaveOccurred())
+				done <- member
+			}()
+
+			Consistently(done).ShouldNot(Receive())
+
+			Expect(client.ZAdd("zset", redis.Z{1, "one"}).Err()).NotTo(HaveOccurred())
+
+			var member redis.ZWithKey
+			Eventually(done).Should(Receive(&member))
+			Expect(member).To(Equal(redis.ZWithKey{Z: redis.Z{1, "one"}, Key: "zset"}))
+		})
+
+		It("should BZPopMin timeout", func() {
+			member, err := client.BZPopMin(time.Second, "zset").Result()
+			Expect(err).To(Equal(redis.Nil))
+			Expect(member).To(Equal(redis.ZWithKey{}))
+		})
+
+		It("should ZRangeArgs", func() {
+			Expect(client.ZAdd("zset", redis.Z{1, "one"}, redis.Z{2, "two"}, redis.Z{3, "three"}).Err()).NotTo(HaveOccurred())
+
+			vals, err := client.ZRangeArgs(redis.ZRangeArgs{
+				Key:   "zset",
+				Start: "0",
+				Stop:  "1",
+				Rev:   true,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"three", "two"}))
+
+			vals, err = client.ZRangeArgs(redis.ZRangeArgs{
+				Key:     "zset",
+				Start:   "(1",
+				Stop:    "+inf",
+				ByScore: true,
+				Offset:  1,
+				Count:   1,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"three"}))
+
+			zz, err := client.ZRangeArgsWithScores(redis.ZRangeArgs{
+				Key:     "zset",
+				Start:   "2",
+				Stop:    "-inf",
+				ByScore: true,
+				Rev:     true,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zz).To(Equal([]redis.Z{{2, "two"}, {1, "one"}}))
+		})
+
+		It("should ZRangeStore", func() {
+			Expect(client.ZAdd("zset", redis.Z{0, "a"}, redis.Z{0, "b"}, redis.Z{0, "c"}).Err()).NotTo(HaveOccurred())
+
+			n, err := client.ZRangeStore("dst", redis.ZRangeArgs{
+				Key:   "zset",
+				Start: "[b",
+				Stop:  "+",
+				ByLex: true,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+
+			vals, err := client.ZRange("dst", 0, -1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"b", "c"}))
+		})
+
+		It("should ZMPop", func() {
+			Expect(client.ZAdd("zset2", redis.Z{1, "one"}, redis.Z{2, "two"}, redis.Z{3, "three"}).Err()).NotTo(HaveOccurred())
+
+			key, members, err := client.ZMPop("MIN", 2, "zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal("zset2"))
+			Expect(members).To(Equal([]redis.Z{{1, "one"}, {2, "two"}}))
+
+			key, members, err = client.ZMPop("MAX", 0, "zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal("zset2"))
+			Expect(members).To(Equal([]redis.Z{{3, "three"}}))
+
+			_, _, err = client.ZMPop("MIN", 1, "zset1", "zset2").Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should BZMPop", func() {
+			Expect(client.ZAdd("zset2", redis.Z{1, "one"}, redis.Z{2, "two"}).Err()).NotTo(HaveOccurred())
+
+			key, members, err := client.BZMPop(0, "MAX", 1, "zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal("zset2"))
+			Expect(members).To(Equal([]redis.Z{{2, "two"}}))
+
+			_, _, err = client.BZMPop(time.Second, "MAX", 1, "zset1").Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
 		It("should ZCard", func() {
 			zAdd := client.ZAdd("zset", redis.Z{1, "one"})
 			Expect(zAdd.Err()).NotTo(HaveOccurred())
@@ -2047,6 +2981,87 @@ var _ = Describe("Commands", func() {
 			Expect(val).To(Equal([]redis.Z{}))
 		})
 
+		It("should ZRangeByLex", func() {
+			zAdd := client.ZAdd("zset", redis.Z{0, "a"}, redis.Z{0, "b"}, redis.Z{0, "c"})
+			Expect(zAdd.Err()).NotTo(HaveOccurred())
+
+			zRangeByLex := client.ZRangeByLex("zset", redis.ZRangeByLex{
+				Min: "-",
+				Max: "+",
+			})
+			Expect(zRangeByLex.Err()).NotTo(HaveOccurred())
+			Expect(zRangeByLex.Val()).To(Equal([]string{"a", "b", "c"}))
+
+			zRangeByLex = client.ZRangeByLex("zset", redis.ZRangeByLex{
+				Min: "[a",
+				Max: "(c",
+			})
+			Expect(zRangeByLex.Err()).NotTo(HaveOccurred())
+			Expect(zRangeByLex.Val()).To(Equal([]string{"a", "b"}))
+
+			zRangeByLex = client.ZRangeByLex("zset", redis.ZRangeByLex{
+				Min:    "-",
+				Max:    "+",
+				Offset: 1,
+				Count:  1,
+			})
+			Expect(zRangeByLex.Err()).NotTo(HaveOccurred())
+			Expect(zRangeByLex.Val()).To(Equal([]string{"b"}))
+		})
+
+		It("should ZRevRangeByLex", func() {
+			zAdd := client.ZAdd("zset", redis.Z{0, "a"}, redis.Z{0, "b"}, redis.Z{0, "c"})
+			Expect(zAdd.Err()).NotTo(HaveOccurred())
+
+			zRevRangeByLex := client.ZRevRangeByLex("zset", redis.ZRangeByLex{
+				Min: "-",
+				Max: "+",
+			})
+			Expect(zRevRangeByLex.Err()).NotTo(HaveOccurred())
+			Expect(zRevRangeByLex.Val()).To(Equal([]string{"c", "b", "a"}))
+
+			zRevRangeByLex = client.ZRevRangeByLex("zset", redis.ZRangeByLex{
+				Min:   "(a",
+				Max:   "[c",
+				Count: 1,
+			})
+			Expect(zRevRangeByLex.Err()).NotTo(HaveOccurred())
+			Expect(zRevRangeByLex.Val()).To(Equal([]string{"c"}))
+		})
+
+		It("should ZLexCount", func() {
+			zAdd := client.ZAdd("zset", redis.Z{0, "a"}, redis.Z{0, "b"}, redis.Z{0, "c"})
+			Expect(zAdd.Err()).NotTo(HaveOccurred())
+
+			zLexCount := client.ZLexCount("zset", "-", "+")
+			Expect(zLexCount.Err()).NotTo(HaveOccurred())
+			Expect(zLexCount.Val()).To(Equal(int64(3)))
+
+			zLexCount = client.ZLexCount("zset", "(a", "[b")
+			Expect(zLexCount.Err()).NotTo(HaveOccurred())
+			Expect(zLexCount.Val()).To(Equal(int64(1)))
+		})
+
+		It("should ZRandMember", func() {
+			Expect(client.ZAdd("zset", redis.Z{1, "one"}, redis.Z{2, "two"}).Err()).NotTo(HaveOccurred())
+
+			members, err := client.ZRandMember("zset", 5).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(ConsistOf("one", "two"))
+
+			members, err = client.ZRandMember("zset", -5).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(HaveLen(5))
+
+			zz, err := client.ZRandMemberWithScores("zset", 2).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zz).To(ConsistOf(redis.Z{1, "one"}, redis.Z{2, "two"}))
+
+			zz, err = client.ZRandMemberWithScores("zset", -3).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zz).To(HaveLen(3))
+		})
+
 		It("should ZRank", func() {
 			zAdd := client.ZAdd("zset", redis.Z{1, "one"})
 			Expect(zAdd.Err()).NotTo(HaveOccurred())
@@ -2098,6 +3113,19 @@ var _ = Describe("Commands", func() {
 			Expect(val).To(Equal([]redis.Z{{3, "three"}}))
 		})
 
+		It("should ZRemRangeByLex", func() {
+			zAdd := client.ZAdd("zset", redis.Z{0, "a"}, redis.Z{0, "b"}, redis.Z{0, "c"})
+			Expect(zAdd.Err()).NotTo(HaveOccurred())
+
+			zRemRangeByLex := client.ZRemRangeByLex("zset", "[a", "(c")
+			Expect(zRemRangeByLex.Err()).NotTo(HaveOccurred())
+			Expect(zRemRangeByLex.Val()).To(Equal(int64(2)))
+
+			zRange := client.ZRange("zset", 0, -1)
+			Expect(zRange.Err()).NotTo(HaveOccurred())
+			Expect(zRange.Val()).To(Equal([]string{"c"}))
+		})
+
 		It("should ZRemRangeByScore", func() {
 			zAdd := client.ZAdd("zset", redis.Z{1, "one"})
 			Expect(zAdd.Err()).NotTo(HaveOccurred())
@@ -2268,6 +3296,840 @@ var _ = Describe("Commands", func() {
 			Expect(val).To(Equal([]redis.Z{{5, "one"}, {9, "three"}, {10, "two"}}))
 		})
 
+		It("should ZDiff", func() {
+			Expect(client.ZAdd("zset1", redis.Z{1, "one"}, redis.Z{2, "two"}, redis.Z{3, "three"}).Err()).NotTo(HaveOccurred())
+			Expect(client.ZAdd("zset2", redis.Z{1, "one"}, redis.Z{2, "two"}).Err()).NotTo(HaveOccurred())
+
+			vals, err := client.ZDiff("zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"three"}))
+
+			zz, err := client.ZDiffWithScores("zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zz).To(Equal([]redis.Z{{3, "three"}}))
+		})
+
+		It("should ZUnion", func() {
+			Expect(client.ZAdd("zset1", redis.Z{1, "one"}, redis.Z{2, "two"}).Err()).NotTo(HaveOccurred())
+			Expect(client.ZAdd("zset2", redis.Z{1, "one"}, redis.Z{2, "two"}, redis.Z{3, "three"}).Err()).NotTo(HaveOccurred())
+
+			vals, err := client.ZUnion(redis.ZStore{}, "zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"one", "three", "two"}))
+
+			zz, err := client.ZUnionWithScores(
+				redis.ZStore{Weights: []int64{2, 3}}, "zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zz).To(Equal([]redis.Z{{5, "one"}, {9, "three"}, {10, "two"}}))
+		})
+
+		It("should ZInter", func() {
+			Expect(client.ZAdd("zset1", redis.Z{1, "one"}, redis.Z{2, "two"}).Err()).NotTo(HaveOccurred())
+			Expect(client.ZAdd("zset2", redis.Z{1, "one"}, redis.Z{2, "two"}, redis.Z{3, "three"}).Err()).NotTo(HaveOccurred())
+
+			vals, err := client.ZInter(redis.ZStore{}, "zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"one", "two"}))
+
+			zz, err := client.ZInterWithScores(
+				redis.ZStore{Aggregate: "MAX"}, "zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zz).To(Equal([]redis.Z{{1, "one"}, {2, "two"}}))
+		})
+
+		It("should ZInterCard", func() {
+			Expect(client.ZAdd("zset1", redis.Z{1, "one"}, redis.Z{2, "two"}).Err()).NotTo(HaveOccurred())
+			Expect(client.ZAdd("zset2", redis.Z{1, "one"}, redis.Z{2, "two"}, redis.Z{3, "three"}).Err()).NotTo(HaveOccurred())
+
+			n, err := client.ZInterCard(0, "zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+
+			n, err = client.ZInterCard(1, "zset1", "zset2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+		})
+
+	})
+
+	//------------------------------------------------------------------------------
+
+	Describe("hyperloglog", func() {
+
+		It("should PFAdd", func() {
+			pfAdd := client.PFAdd("hll", "a", "b", "c")
+			Expect(pfAdd.Err()).NotTo(HaveOccurred())
+			Expect(pfAdd.Val()).To(Equal(int64(1)))
+
+			pfAdd = client.PFAdd("hll", "a", "b")
+			Expect(pfAdd.Err()).NotTo(HaveOccurred())
+			Expect(pfAdd.Val()).To(Equal(int64(0)))
+
+			pfCount := client.PFCount("hll")
+			Expect(pfCount.Err()).NotTo(HaveOccurred())
+			Expect(pfCount.Val()).To(Equal(int64(3)))
+		})
+
+		It("should PFCount multiple keys", func() {
+			Expect(client.PFAdd("hll1", "a", "b", "c").Err()).NotTo(HaveOccurred())
+			Expect(client.PFAdd("hll2", "c", "d").Err()).NotTo(HaveOccurred())
+
+			pfCount := client.PFCount("hll1", "hll2")
+			Expect(pfCount.Err()).NotTo(HaveOccurred())
+			Expect(pfCount.Val()).To(Equal(int64(4)))
+
+			pfCount = client.PFCount("nonexistent")
+			Expect(pfCount.Err()).NotTo(HaveOccurred())
+			Expect(pfCount.Val()).To(Equal(int64(0)))
+		})
+
+		It("should PFMerge", func() {
+			Expect(client.PFAdd("hll1", "a", "b", "c").Err()).NotTo(HaveOccurred())
+			Expect(client.PFAdd("hll2", "c", "d", "e").Err()).NotTo(HaveOccurred())
+
+			pfMerge := client.PFMerge("hll", "hll1", "hll2")
+			Expect(pfMerge.Err()).NotTo(HaveOccurred())
+			Expect(pfMerge.Val()).To(Equal("OK"))
+
+			pfCount := client.PFCount("hll")
+			Expect(pfCount.Err()).NotTo(HaveOccurred())
+			Expect(pfCount.Val()).To(Equal(int64(5)))
+		})
+
+		It("should return error when PFAdd is used on wrong type", func() {
+			Expect(client.Set("key", "value", 0).Err()).NotTo(HaveOccurred())
+
+			err := client.PFAdd("key", "a").Err()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("WRONGTYPE"))
+		})
+
+	})
+
+	//------------------------------------------------------------------------------
+
+	Describe("geo", func() {
+
+		BeforeEach(func() {
+			geoAdd := client.GeoAdd(
+				"Sicily",
+				&redis.GeoLocation{Longitude: 13.361389, Latitude: 38.115556, Name: "Palermo"},
+				&redis.GeoLocation{Longitude: 15.087269, Latitude: 37.502669, Name: "Catania"},
+			)
+			Expect(geoAdd.Err()).NotTo(HaveOccurred())
+			Expect(geoAdd.Val()).To(Equal(int64(2)))
+		})
+
+		It("should search geo radius", func() {
+			res, err := client.GeoRadius("Sicily", 15, 37, &redis.GeoRadiusQuery{
+				Radius: 200,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0].Name).To(Equal("Palermo"))
+			Expect(res[1].Name).To(Equal("Catania"))
+		})
+
+		It("should search geo radius with options", func() {
+			res, err := client.GeoRadius("Sicily", 15, 37, &redis.GeoRadiusQuery{
+				Radius:      200,
+				Unit:        "km",
+				WithGeoHash: true,
+				WithCoord:   true,
+				WithDist:    true,
+				Count:       2,
+				Sort:        "ASC",
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(2))
+			Expect(res[1].Name).To(Equal("Palermo"))
+			Expect(res[1].Dist).To(Equal(190.4424))
+			Expect(res[1].GeoHash).To(Equal(int64(3479099956230698)))
+			Expect(res[1].Longitude).To(BeNumerically("~", 13.361389, 1e-6))
+			Expect(res[1].Latitude).To(BeNumerically("~", 38.115556, 1e-6))
+			Expect(res[0].Name).To(Equal("Catania"))
+			Expect(res[0].Dist).To(Equal(56.4413))
+			Expect(res[0].GeoHash).To(Equal(int64(3479447370796909)))
+		})
+
+		It("should search geo radius by member", func() {
+			res, err := client.GeoRadiusByMember("Sicily", "Catania", &redis.GeoRadiusQuery{
+				Radius: 200,
+				Sort:   "ASC",
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0].Name).To(Equal("Catania"))
+			Expect(res[1].Name).To(Equal("Palermo"))
+		})
+
+		It("should get geo distance", func() {
+			dist, err := client.GeoDist("Sicily", "Palermo", "Catania", "km").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dist).To(BeNumerically("~", 166.27, 0.01))
+
+			err = client.GeoDist("Sicily", "Palermo", "Rome", "").Err()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should get geo hash", func() {
+			res, err := client.GeoHash("Sicily", "Palermo", "Catania").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]string{"sqc8b49rny0", "sqdtr74hyu0"}))
+		})
+
+		It("should get geo positions", func() {
+			res, err := client.GeoPos("Sicily", "Palermo", "Catania", "Rome").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(3))
+			Expect(res[0].Longitude).To(BeNumerically("~", 13.361389, 1e-6))
+			Expect(res[0].Latitude).To(BeNumerically("~", 38.115556, 1e-6))
+			Expect(res[1].Longitude).To(BeNumerically("~", 15.087269, 1e-6))
+			Expect(res[1].Latitude).To(BeNumerically("~", 37.502669, 1e-6))
+			Expect(res[2]).To(BeNil())
+		})
+
+		It("should GeoSearch", func() {
+			res, err := client.GeoSearch("Sicily", &redis.GeoSearchQuery{
+				Longitude: 15,
+				Latitude:  37,
+				Radius:    200,
+				Sort:      "ASC",
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]string{"Catania", "Palermo"}))
+
+			res, err = client.GeoSearch("Sicily", &redis.GeoSearchQuery{
+				Longitude: 15,
+				Latitude:  37,
+				Radius:    100,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]string{"Catania"}))
+
+			res, err = client.GeoSearch("Sicily", &redis.GeoSearchQuery{
+				Member:    "Palermo",
+				BoxWidth:  400,
+				BoxHeight: 400,
+				Sort:      "ASC",
+				Count:     1,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]string{"Palermo"}))
+		})
+
+		It("should GeoSearchLocation", func() {
+			res, err := client.GeoSearchLocation("Sicily", &redis.GeoSearchLocationQuery{
+				GeoSearchQuery: redis.GeoSearchQuery{
+					Longitude: 15,
+					Latitude:  37,
+					Radius:    200,
+					Sort:      "ASC",
+				},
+				WithCoord: true,
+				WithDist:  true,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0].Name).To(Equal("Catania"))
+			Expect(res[0].Dist).To(BeNumerically("~", 56.4413, 0.001))
+			Expect(res[0].Longitude).To(BeNumerically("~", 15.087269, 0.001))
+			Expect(res[1].Name).To(Equal("Palermo"))
+			Expect(res[1].Dist).To(BeNumerically("~", 190.4424, 0.001))
+		})
+
+		It("should GeoSearchStore", func() {
+			n, err := client.GeoSearchStore("Sicily", "result", &redis.GeoSearchStoreQuery{
+				GeoSearchQuery: redis.GeoSearchQuery{
+					Longitude: 15,
+					Latitude:  37,
+					Radius:    200,
+				},
+				StoreDist: true,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+
+			res, err := client.ZRangeWithScores("result", 0, -1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0].Member).To(Equal("Catania"))
+			Expect(res[0].Score).To(BeNumerically("~", 56.4413, 0.001))
+		})
+
+	})
+
+	//------------------------------------------------------------------------------
+
+	Describe("streams", func() {
+
+		BeforeEach(func() {
+			id, err := client.XAdd(&redis.XAddArgs{
+				Stream: "stream",
+				ID:     "1-0",
+				Values: map[string]interface{}{"uno": "un"},
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("1-0"))
+
+			id, err = client.XAdd(&redis.XAddArgs{
+				Stream: "stream",
+				ID:     "2-0",
+				Values: map[string]interface{}{"dos": "deux"},
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("2-0"))
+
+			id, err = client.XAdd(&redis.XAddArgs{
+				Stream: "stream",
+				ID:     "3-0",
+				Values: map[string]interface{}{"tres": "troix"},
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("3-0"))
+		})
+
+		It("should XAdd with generated ID", func() {
+			id, err := client.XAdd(&redis.XAddArgs{
+				Stream: "stream",
+				Values: map[string]interface{}{"quatro": "quatre"},
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).NotTo(BeEmpty())
+
+			n, err := client.XLen("stream").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(4)))
+		})
+
+		It("should XAdd with MaxLen", func() {
+			id, err := client.XAdd(&redis.XAddArgs{
+				Stream: "stream",
+				MaxLen: 1,
+				Values: map[string]interface{}{"quatro": "quatre"},
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+
+			msgs, err := client.XRange("stream", "-", "+").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: id, Values: map[string]interface{}{"quatro": "quatre"}},
+			}))
+		})
+
+		It("should XDel", func() {
+			n, err := client.XDel("stream", "1-0", "2-0", "3-0", "4-0").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(3)))
+
+			n, err = client.XLen("stream").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+		})
+
+		It("should XAdd with NoMkStream", func() {
+			err := client.XAdd(&redis.XAddArgs{
+				Stream:     "nonexistent",
+				NoMkStream: true,
+				Values:     map[string]interface{}{"uno": "un"},
+			}).Err()
+			Expect(err).To(Equal(redis.Nil))
+
+			n, err := client.Exists("nonexistent").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(BeFalse())
+
+			id, err := client.XAdd(&redis.XAddArgs{
+				Stream:     "stream",
+				NoMkStream: true,
+				ID:         "4-0",
+				Values:     map[string]interface{}{"quatro": "quatre"},
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("4-0"))
+		})
+
+		It("should XAdd with MaxLenApprox and Limit", func() {
+			err := client.XAdd(&redis.XAddArgs{
+				Stream:       "stream",
+				MaxLenApprox: 1,
+				Limit:        10,
+				Values:       map[string]interface{}{"quatro": "quatre"},
+			}).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Approximate trimming evicts only whole macro nodes.
+			n, err := client.XLen("stream").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(4)))
+		})
+
+		It("should XTrimMaxLen", func() {
+			n, err := client.XTrimMaxLen("stream", 1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+
+			msgs, err := client.XRange("stream", "-", "+").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: "3-0", Values: map[string]interface{}{"tres": "troix"}},
+			}))
+		})
+
+		It("should XTrimMaxLenApprox", func() {
+			n, err := client.XTrimMaxLenApprox("stream", 1, 0).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+
+			n, err = client.XTrimMaxLenApprox("stream", 1, 10).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+		})
+
+		It("should XTrimMinID", func() {
+			n, err := client.XTrimMinID("stream", "2-0").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+
+			n, err = client.XTrimMinIDApprox("stream", "3-0", 10).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+
+			n, err = client.XLen("stream").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+		})
+
+		It("should XSetID", func() {
+			err := client.XSetID("stream", "1-0").Err()
+			Expect(err).To(HaveOccurred())
+
+			err = client.XSetID("stream", "10-0").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.XAdd(&redis.XAddArgs{
+				Stream: "stream",
+				ID:     "5-0",
+				Values: map[string]interface{}{"cinco": "cinq"},
+			}).Result()
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should XSetIDExt", func() {
+			err := client.XDel("stream", "2-0").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.XSetIDExt("stream", "10-0", 5, "2-0").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.XSetIDExt("stream", "10-0", 5, "20-0").Err()
+			Expect(err).To(HaveOccurred())
+
+			info, err := client.XInfoStream("stream").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.LastGeneratedID).To(Equal("10-0"))
+		})
+
+		It("should XLen", func() {
+			n, err := client.XLen("stream").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(3)))
+		})
+
+		It("should XRange", func() {
+			msgs, err := client.XRange("stream", "-", "+").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: "1-0", Values: map[string]interface{}{"uno": "un"}},
+				{ID: "2-0", Values: map[string]interface{}{"dos": "deux"}},
+				{ID: "3-0", Values: map[string]interface{}{"tres": "troix"}},
+			}))
+
+			msgs, err = client.XRange("stream", "2", "+").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: "2-0", Values: map[string]interface{}{"dos": "deux"}},
+				{ID: "3-0", Values: map[string]interface{}{"tres": "troix"}},
+			}))
+
+			msgs, err = client.XRangeN("stream", "-", "+", 1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: "1-0", Values: map[string]interface{}{"uno": "un"}},
+			}))
+		})
+
+		It("should XRevRange", func() {
+			msgs, err := client.XRevRange("stream", "+", "-").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: "3-0", Values: map[string]interface{}{"tres": "troix"}},
+				{ID: "2-0", Values: map[string]interface{}{"dos": "deux"}},
+				{ID: "1-0", Values: map[string]interface{}{"uno": "un"}},
+			}))
+
+			msgs, err = client.XRevRangeN("stream", "+", "-", 1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: "3-0", Values: map[string]interface{}{"tres": "troix"}},
+			}))
+		})
+
+		It("should XRead", func() {
+			res, err := client.XReadStreams("stream", "0").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]redis.XStream{{
+				Stream: "stream",
+				Messages: []redis.XMessage{
+					{ID: "1-0", Values: map[string]interface{}{"uno": "un"}},
+					{ID: "2-0", Values: map[string]interface{}{"dos": "deux"}},
+					{ID: "3-0", Values: map[string]interface{}{"tres": "troix"}},
+				}},
+			}))
+
+			res, err = client.XRead(&redis.XReadArgs{
+				Streams: []string{"stream", "0"},
+				Count:   2,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]redis.XStream{{
+				Stream: "stream",
+				Messages: []redis.XMessage{
+					{ID: "1-0", Values: map[string]interface{}{"uno": "un"}},
+					{ID: "2-0", Values: map[string]interface{}{"dos": "deux"}},
+				}},
+			}))
+		})
+
+		It("should XRead with Block", func() {
+			_, err := client.XRead(&redis.XReadArgs{
+				Streams: []string{"stream", "$"},
+				Block:   100 * time.Millisecond,
+			}).Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			done := make(chan []redis.XStream)
+			go func() {
+				defer GinkgoRecover()
+
+				res, err := client.XRead(&redis.XReadArgs{
+					Streams: []string{"stream", "3-0"},
+					Block:   time.Second,
+				}).Result()
+				Expect(err).NotTo(HaveOccurred())
+				done <- res
+			}()
+
+			time.Sleep(100 * time.Millisecond)
+			err = client.XAdd(&redis.XAddArgs{
+				Stream: "stream",
+				ID:     "4-0",
+				Values: map[string]interface{}{"quatro": "quatre"},
+			}).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			var res []redis.XStream
+			Eventually(done).Should(Receive(&res))
+			Expect(res).To(Equal([]redis.XStream{{
+				Stream: "stream",
+				Messages: []redis.XMessage{
+					{ID: "4-0", Values: map[string]interface{}{"quatro": "quatre"}},
+				}},
+			}))
+		})
+
+	})
+
+	//------------------------------------------------------------------------------
+
+	Describe("stream consumer groups", func() {
+
+		BeforeEach(func() {
+			for _, id := range []string{"1-0", "2-0", "3-0"} {
+				err := client.XAdd(&redis.XAddArgs{
+					Stream: "stream",
+					ID:     id,
+					Values: map[string]interface{}{"n": id},
+				}).Err()
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err := client.XGroupCreate("stream", "group", "0").Err()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		readGroup := func(consumer string, count int64) []redis.XMessage {
+			res, err := client.XReadGroup(&redis.XReadGroupArgs{
+				Group:    "group",
+				Consumer: consumer,
+				Streams:  []string{"stream", ">"},
+				Count:    count,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(1))
+			return res[0].Messages
+		}
+
+		It("should XGroupCreateMkStream", func() {
+			err := client.XGroupCreate("nonexistent", "group", "$").Err()
+			Expect(err).To(HaveOccurred())
+
+			err = client.XGroupCreateMkStream("nonexistent", "group", "$").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			n, err := client.XLen("nonexistent").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+		})
+
+		It("should XGroupSetID", func() {
+			readGroup("consumer", 3)
+
+			err := client.XGroupSetID("stream", "group", "1-0").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			msgs := readGroup("consumer", 10)
+			Expect(msgs).To(HaveLen(2))
+			Expect(msgs[0].ID).To(Equal("2-0"))
+		})
+
+		It("should RepairStreamGroup", func() {
+			readGroup("consumer", 3)
+
+			repaired, err := client.RepairStreamGroup("stream", "group")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repaired).To(BeFalse())
+
+			// Simulate a stream restored from an older backup.
+			Expect(client.XGroupSetID("stream", "group", "100-0").Err()).NotTo(HaveOccurred())
+
+			repaired, err = client.RepairStreamGroup("stream", "group")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repaired).To(BeTrue())
+
+			groups, err := client.XInfoGroups("stream").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups[0].LastDeliveredID).To(Equal("3-0"))
+
+			err = client.XAdd(&redis.XAddArgs{
+				Stream: "stream",
+				ID:     "4-0",
+				Values: map[string]interface{}{"n": "4-0"},
+			}).Err()
+			Expect(err).NotTo(HaveOccurred())
+			msgs := readGroup("consumer", 10)
+			Expect(msgs).To(HaveLen(1))
+			Expect(msgs[0].ID).To(Equal("4-0"))
+
+			_, err = client.RepairStreamGroup("stream", "nonexistent")
+			Expect(err).To(MatchError(`redis: consumer group "nonexistent" does not exist`))
+		})
+
+		It("should XGroupDestroy", func() {
+			n, err := client.XGroupDestroy("stream", "group").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+
+			n, err = client.XGroupDestroy("stream", "group").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+		})
+
+		It("should XReadGroup and XAck", func() {
+			msgs := readGroup("consumer", 2)
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: "1-0", Values: map[string]interface{}{"n": "1-0"}},
+				{ID: "2-0", Values: map[string]interface{}{"n": "2-0"}},
+			}))
+
+			n, err := client.XAck("stream", "group", "1-0", "2-0", "3-0").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+
+			pending, err := client.XPending("stream", "group").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(Equal(&redis.XPending{}))
+		})
+
+		It("should XReadGroup with NoAck", func() {
+			res, err := client.XReadGroup(&redis.XReadGroupArgs{
+				Group:    "group",
+				Consumer: "consumer",
+				Streams:  []string{"stream", ">"},
+				NoAck:    true,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res[0].Messages).To(HaveLen(3))
+
+			pending, err := client.XPending("stream", "group").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending.Count).To(Equal(int64(0)))
+		})
+
+		It("should XPending and XPendingExt", func() {
+			readGroup("consumer1", 2)
+			readGroup("consumer2", 1)
+
+			pending, err := client.XPending("stream", "group").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(Equal(&redis.XPending{
+				Count:     3,
+				Lower:     "1-0",
+				Higher:    "3-0",
+				Consumers: map[string]int64{"consumer1": 2, "consumer2": 1},
+			}))
+
+			ext, err := client.XPendingExt(&redis.XPendingExtArgs{
+				Stream: "stream",
+				Group:  "group",
+				Start:  "-",
+				End:    "+",
+				Count:  10,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ext).To(HaveLen(3))
+			Expect(ext[0].ID).To(Equal("1-0"))
+			Expect(ext[0].Consumer).To(Equal("consumer1"))
+			Expect(ext[0].RetryCount).To(Equal(int64(1)))
+			Expect(ext[2].Consumer).To(Equal("consumer2"))
+
+			ext, err = client.XPendingExt(&redis.XPendingExtArgs{
+				Stream:   "stream",
+				Group:    "group",
+				Start:    "-",
+				End:      "+",
+				Count:    10,
+				Consumer: "consumer2",
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ext).To(HaveLen(1))
+			Expect(ext[0].ID).To(Equal("3-0"))
+		})
+
+		It("should XClaim", func() {
+			readGroup("consumer1", 3)
+
+			msgs, err := client.XClaim(&redis.XClaimArgs{
+				Stream:   "stream",
+				Group:    "group",
+				Consumer: "consumer2",
+				Messages: []string{"1-0", "2-0"},
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: "1-0", Values: map[string]interface{}{"n": "1-0"}},
+				{ID: "2-0", Values: map[string]interface{}{"n": "2-0"}},
+			}))
+
+			ids, err := client.XClaimJustID(&redis.XClaimArgs{
+				Stream:   "stream",
+				Group:    "group",
+				Consumer: "consumer2",
+				MinIdle:  time.Hour,
+				Messages: []string{"3-0"},
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(BeEmpty())
+
+			pending, err := client.XPending("stream", "group").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending.Consumers).To(Equal(map[string]int64{"consumer1": 1, "consumer2": 2}))
+		})
+
+		It("should XAutoClaim", func() {
+			readGroup("consumer1", 3)
+
+			msgs, start, err := client.XAutoClaim(&redis.XAutoClaimArgs{
+				Stream:   "stream",
+				Group:    "group",
+				Consumer: "consumer2",
+				Count:    2,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(start).To(Equal("3-0"))
+			Expect(msgs).To(Equal([]redis.XMessage{
+				{ID: "1-0", Values: map[string]interface{}{"n": "1-0"}},
+				{ID: "2-0", Values: map[string]interface{}{"n": "2-0"}},
+			}))
+
+			ids, start, err := client.XAutoClaimJustID(&redis.XAutoClaimArgs{
+				Stream:   "stream",
+				Group:    "group",
+				Consumer: "consumer2",
+				Start:    start,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(start).To(Equal("0-0"))
+			Expect(ids).To(Equal([]string{"3-0"}))
+
+			ids, _, err = client.XAutoClaimJustID(&redis.XAutoClaimArgs{
+				Stream:   "stream",
+				Group:    "group",
+				Consumer: "consumer1",
+				MinIdle:  time.Hour,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(BeEmpty())
+		})
+
+		It("should XInfoStream", func() {
+			info, err := client.XInfoStream("stream").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Length).To(Equal(int64(3)))
+			Expect(info.Groups).To(Equal(int64(1)))
+			Expect(info.LastGeneratedID).To(Equal("3-0"))
+			Expect(info.FirstEntry).To(Equal(&redis.XMessage{
+				ID:     "1-0",
+				Values: map[string]interface{}{"n": "1-0"},
+			}))
+			Expect(info.LastEntry).To(Equal(&redis.XMessage{
+				ID:     "3-0",
+				Values: map[string]interface{}{"n": "3-0"},
+			}))
+
+			Expect(client.XGroupCreateMkStream("empty", "group", "$").Err()).NotTo(HaveOccurred())
+			info, err = client.XInfoStream("empty").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Length).To(Equal(int64(0)))
+			Expect(info.FirstEntry).To(BeNil())
+			Expect(info.LastEntry).To(BeNil())
+		})
+
+		It("should XInfoGroups", func() {
+			readGroup("consumer", 2)
+
+			groups, err := client.XInfoGroups("stream").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(Equal([]redis.XInfoGroup{{
+				Name:            "group",
+				Consumers:       1,
+				Pending:         2,
+				LastDeliveredID: "2-0",
+			}}))
+		})
+
+		It("should XInfoConsumers", func() {
+			readGroup("consumer1", 2)
+			readGroup("consumer2", 1)
+
+			consumers, err := client.XInfoConsumers("stream", "group").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(consumers).To(HaveLen(2))
+			Expect(consumers[0].Name).To(Equal("consumer1"))
+			Expect(consumers[0].Pending).To(Equal(int64(2)))
+			Expect(consumers[0].Idle).To(BeNumerically("<", time.Minute))
+			Expect(consumers[1].Name).To(Equal("consumer2"))
+			Expect(consumers[1].Pending).To(Equal(int64(1)))
+		})
+
+		It("should XGroupDelConsumer", func() {
+			readGroup("consumer", 2)
+
+			n, err := client.XGroupDelConsumer("stream", "group", "consumer").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+		})
+
 	})
 
 	//------------------------------------------------------------------------------
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"net"
+	"sync/atomic"
 	"time"
 
 	"gopkg.in/bufio.v1"
@@ -9,6 +10,10 @@ import (
 
 var (
 	zeroTime = time.Time{}
+
+	// connNameSeq numbers dialers, so names of connections set with
+	// Options.ClientName are unique within the process.
+	connNameSeq uint64
 )
 
 type conn struct {
@@ -19,10 +24,24 @@ type conn struct {
 	usedAt       time.Time
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+
+	readCanceled int32 // Set by peekCancel to interrupt reads.
+
+	// Name set with CLIENT SETNAME when Options.ClientName is used.
+	name string
+	// Selected database. Clients returned by Client.DB share the pool
+	// and select their database when it differs.
+	db int64
+
+	codec *codec
 }
 
-func newConnDialer(opt *Options) func() (*conn, error) {
+// newConnDialer returns a function dialing new connections. When
+// namePrefix is not empty connections are named "<namePrefix>-<n>".
+func newConnDialer(opt *Options, namePrefix string) func() (*conn, error) {
 	dialer := opt.getDialer()
+	cdc := newCodec(opt)
+	var seq uint64
 	return func() (*conn, error) {
 		netcn, err := dialer()
 		if err != nil {
@@ -31,6 +50,10 @@ func newConnDialer(opt *Options) func() (*conn, error) {
 		cn := &conn{
 			netcn: netcn,
 			buf:   make([]byte, 0, 64),
+			codec: cdc,
+		}
+		if namePrefix != "" {
+			cn.name = namePrefix + "-" + formatUint(atomic.AddUint64(&seq, 1))
 		}
 		cn.rd = bufio.NewReader(cn)
 		return cn, cn.init(opt)
@@ -38,7 +61,8 @@ func newConnDialer(opt *Options) func() (*conn, error) {
 }
 
 func (cn *conn) init(opt *Options) error {
-	if opt.Password == "" && opt.DB == 0 {
+	cn.db = opt.DB
+	if opt.Password == "" && opt.DB == 0 && !opt.ReadOnly && cn.name == "" {
 		return nil
 	}
 
@@ -60,14 +84,28 @@ func (cn *conn) init(opt *Options) error {
 		}
 	}
 
+	if opt.ReadOnly {
+		if err := client.ReadOnly().Err(); err != nil {
+			return err
+		}
+	}
+
+	if cn.name != "" {
+		if err := client.ClientSetName(cn.name).Err(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (cn *conn) writeCmds(cmds ...Cmder) error {
 	buf := cn.buf[:0]
 	for _, cmd := range cmds {
+		// Replies are unmarshaled by Scan using the same codec.
+		cmd.setCodec(cn.codec)
 		var err error
-		buf, err = appendArgs(buf, cmd.args())
+		buf, err = cn.codec.appendArgs(buf, cmd.args())
 		if err != nil {
 			return err
 		}
@@ -83,9 +121,52 @@ func (cn *conn) Read(b []byte) (int, error) {
 	} else {
 		cn.netcn.SetReadDeadline(zeroTime)
 	}
+	// Checked after the deadline is set, so concurrent cancellation is
+	// never overwritten.
+	if atomic.LoadInt32(&cn.readCanceled) == 1 {
+		cn.netcn.SetReadDeadline(time.Now())
+	}
 	return cn.netcn.Read(b)
 }
 
+// peekCancel waits until a reply is available without consuming it,
+// so the connection stays usable when cancel is closed first, in which
+// case ErrReceiveCanceled is returned.
+func (cn *conn) peekCancel(cancel <-chan struct{}) error {
+	// Peek returns immediately when a reply is buffered or keeps
+	// arriving, so cancel must be checked before.
+	select {
+	case <-cancel:
+		return ErrReceiveCanceled
+	default:
+	}
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-cancel:
+			atomic.StoreInt32(&cn.readCanceled, 1)
+			cn.netcn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	_, err := cn.rd.Peek(1)
+	close(done)
+	<-exited
+
+	canceled := atomic.SwapInt32(&cn.readCanceled, 0) == 1
+	if err == nil {
+		return nil
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() && canceled {
+		return ErrReceiveCanceled
+	}
+	return err
+}
+
 func (cn *conn) Write(b []byte) (int, error) {
 	if cn.WriteTimeout != 0 {
 		cn.netcn.SetWriteDeadline(time.Now().Add(cn.WriteTimeout))
@@ -0,0 +1,178 @@
+package redis
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ConnEventType is the type of a connection lifecycle event.
+type ConnEventType int
+
+const (
+	// Connection was re-established after ConnDisconnected.
+	ConnConnected ConnEventType = iota
+	// Server became unreachable.
+	ConnDisconnected
+	// Client is trying to re-establish the connection.
+	ConnReconnectAttempt
+)
+
+func (t ConnEventType) String() string {
+	switch t {
+	case ConnConnected:
+		return "connected"
+	case ConnDisconnected:
+		return "disconnected"
+	case ConnReconnectAttempt:
+		return "reconnect attempt"
+	}
+	return "unknown"
+}
+
+// ConnEvent describes a change of the client connection state.
+type ConnEvent struct {
+	Type ConnEventType
+	Time time.Time
+	// Error that caused ConnDisconnected or failure of the previous
+	// reconnect attempt.
+	Err error
+	// Reconnect attempt number starting from 1.
+	Attempt int
+}
+
+// ConnEventSubscription delivers connection events to C. Events are
+// dropped when C is full.
+type ConnEventSubscription struct {
+	C <-chan ConnEvent
+
+	ch    chan ConnEvent
+	state *connState
+}
+
+// Close stops delivering events to the subscription.
+func (s *ConnEventSubscription) Close() {
+	s.state.mu.Lock()
+	delete(s.state.subs, s)
+	s.state.mu.Unlock()
+}
+
+// ConnEvents returns a subscription to connection lifecycle events.
+// Events are buffered up to size.
+func (c *Client) ConnEvents(size int) *ConnEventSubscription {
+	ch := make(chan ConnEvent, size)
+	sub := &ConnEventSubscription{
+		C:     ch,
+		ch:    ch,
+		state: c.state,
+	}
+	c.state.mu.Lock()
+	c.state.subs[sub] = struct{}{}
+	c.state.mu.Unlock()
+	return sub
+}
+
+// connState tracks whether the server is reachable and re-establishes
+// the connection with jittered exponential backoff when it is not.
+type connState struct {
+	pool       pool
+	clock      Clock
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mu      sync.Mutex
+	offline bool
+	// Closed when connection is re-established.
+	online chan struct{}
+	subs   map[*ConnEventSubscription]struct{}
+}
+
+func newConnState(opt *Options, pool pool) *connState {
+	return &connState{
+		pool:       pool,
+		clock:      opt.getClock(),
+		minBackoff: opt.getMinReconnectBackoff(),
+		maxBackoff: opt.getMaxReconnectBackoff(),
+		subs:       make(map[*ConnEventSubscription]struct{}),
+	}
+}
+
+// reconnected returns a channel that is closed once the connection is
+// re-established.
+func (s *connState) reconnected() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.offline {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
+	return s.online
+}
+
+// disconnected marks the server as unreachable and starts reconnecting.
+func (s *connState) disconnected(err error) {
+	if err == errClosed || err == errPoolTimeout {
+		return
+	}
+
+	s.mu.Lock()
+	if s.offline {
+		s.mu.Unlock()
+		return
+	}
+	s.offline = true
+	s.online = make(chan struct{})
+	s.emit(ConnEvent{Type: ConnDisconnected, Err: err})
+	s.mu.Unlock()
+
+	go s.reconnect(err)
+}
+
+func (s *connState) reconnect(err error) {
+	for attempt := 1; ; attempt++ {
+		s.clock.Sleep(s.backoff(attempt))
+
+		s.mu.Lock()
+		s.emit(ConnEvent{Type: ConnReconnectAttempt, Err: err, Attempt: attempt})
+		s.mu.Unlock()
+
+		var cn *conn
+		cn, err = s.pool.Get()
+		if err == errClosed {
+			return
+		}
+		if err == nil {
+			s.pool.Put(cn)
+			break
+		}
+	}
+
+	s.mu.Lock()
+	s.offline = false
+	close(s.online)
+	s.emit(ConnEvent{Type: ConnConnected})
+	s.mu.Unlock()
+}
+
+// backoff returns exponential backoff for the attempt with jitter.
+func (s *connState) backoff(attempt int) time.Duration {
+	d := s.maxBackoff
+	if attempt < 32 {
+		if b := s.minBackoff << uint(attempt-1); b > 0 && b < d {
+			d = b
+		}
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// emit sends the event to subscribers. s.mu must be held.
+func (s *connState) emit(event ConnEvent) {
+	event.Time = s.clock.Now()
+	for sub := range s.subs {
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+}
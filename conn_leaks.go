@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errNoClientName = errors.New("redis: ConnLeaks requires Options.ClientName")
+
+// ClientInfo describes a connection listed by CLIENT LIST.
+type ClientInfo struct {
+	ID   int64
+	Addr string
+	Name string
+	// Connection age and time since the last command.
+	Age, Idle time.Duration
+	DB        int64
+	Flags     string
+	// Last command executed by the connection.
+	Cmd string
+}
+
+// ParseClientList parses the output of CLIENT LIST. Unknown fields are
+// ignored.
+func ParseClientList(s string) ([]ClientInfo, error) {
+	var infos []ClientInfo
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var info ClientInfo
+		for _, field := range strings.Fields(line) {
+			i := strings.IndexByte(field, '=')
+			if i < 0 {
+				continue
+			}
+			k, v := field[:i], field[i+1:]
+
+			var err error
+			switch k {
+			case "id":
+				info.ID, err = strconv.ParseInt(v, 10, 64)
+			case "addr":
+				info.Addr = v
+			case "name":
+				info.Name = v
+			case "age":
+				info.Age, err = parseSeconds(v)
+			case "idle":
+				info.Idle, err = parseSeconds(v)
+			case "db":
+				info.DB, err = strconv.ParseInt(v, 10, 64)
+			case "flags":
+				info.Flags = v
+			case "cmd":
+				info.Cmd = v
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func parseSeconds(s string) (time.Duration, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n) * time.Second, nil
+}
+
+// ConnLeaks is a result of matching connections of the client pool
+// with connections open on the server.
+type ConnLeaks struct {
+	// Connections open on the server that were named by the pool but
+	// are not known to it anymore, e.g. connections removed from the
+	// pool without being closed.
+	Orphaned []ClientInfo
+	// Names of pool connections that are not open on the server, e.g.
+	// connections closed by the server timeout or CLIENT KILL.
+	Missing []string
+}
+
+// Empty reports whether no leaks were found.
+func (l *ConnLeaks) Empty() bool {
+	return len(l.Orphaned) == 0 && len(l.Missing) == 0
+}
+
+// ConnLeaks correlates connections of the client pool with CLIENT LIST
+// output to find orphaned connections and connections the pool still
+// holds after the server closed them. It requires Options.ClientName,
+// which gives every connection a unique name. Connections opened or
+// closed while ConnLeaks runs are not reported.
+func (c *Client) ConnLeaks() (*ConnLeaks, error) {
+	p, ok := c.connPool.(*connPool)
+	if !ok || p.namePrefix == "" {
+		return nil, errNoClientName
+	}
+
+	before := p.conns.Names()
+	list, err := c.ClientList().Result()
+	if err != nil {
+		return nil, err
+	}
+	after := p.conns.Names()
+
+	infos, err := ParseClientList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]int, len(before))
+	for _, name := range before {
+		known[name]++
+	}
+	for _, name := range after {
+		known[name]++
+	}
+
+	leaks := &ConnLeaks{}
+	open := make(map[string]bool, len(infos))
+	prefix := p.namePrefix + "-"
+	for _, info := range infos {
+		if !strings.HasPrefix(info.Name, prefix) {
+			continue
+		}
+		open[info.Name] = true
+		if known[info.Name] == 0 {
+			leaks.Orphaned = append(leaks.Orphaned, info)
+		}
+	}
+	for _, name := range after {
+		// Skip connections that were not in the pool during CLIENT LIST.
+		if known[name] < 2 {
+			continue
+		}
+		if !open[name] {
+			leaks.Missing = append(leaks.Missing, name)
+		}
+	}
+	return leaks, nil
+}
@@ -0,0 +1,121 @@
+package redis_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("ParseClientList", func() {
+	It("should parse CLIENT LIST output", func() {
+		infos, err := redis.ParseClientList(
+			"id=3 addr=127.0.0.1:50188 laddr=127.0.0.1:6379 fd=8 name=app-1-1 age=12 idle=3 flags=N db=2 cmd=client|list\n" +
+				"id=4 addr=127.0.0.1:50190 fd=9 name= age=1 idle=0 flags=N db=0 cmd=ping\n",
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(infos).To(Equal([]redis.ClientInfo{{
+			ID:    3,
+			Addr:  "127.0.0.1:50188",
+			Name:  "app-1-1",
+			Age:   12 * time.Second,
+			Idle:  3 * time.Second,
+			DB:    2,
+			Flags: "N",
+			Cmd:   "client|list",
+		}, {
+			ID:    4,
+			Addr:  "127.0.0.1:50190",
+			Age:   time.Second,
+			Flags: "N",
+			Cmd:   "ping",
+		}}))
+	})
+
+	It("should return an error for malformed fields", func() {
+		_, err := redis.ParseClientList("id=x addr=127.0.0.1:50188")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ConnLeaks", func() {
+	var client, other *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr:       redisAddr,
+			PoolSize:   2,
+			ClientName: "leaks",
+		})
+		other = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(other.Close()).NotTo(HaveOccurred())
+	})
+
+	findConn := func(suffix string) redis.ClientInfo {
+		infos, err := redis.ParseClientList(other.ClientList().Val())
+		Expect(err).NotTo(HaveOccurred())
+		for _, info := range infos {
+			if strings.HasPrefix(info.Name, "leaks-") && strings.HasSuffix(info.Name, suffix) {
+				return info
+			}
+		}
+		Fail("connection not found")
+		return redis.ClientInfo{}
+	}
+
+	It("should require client name", func() {
+		_, err := other.ConnLeaks()
+		Expect(err).To(MatchError("redis: ConnLeaks requires Options.ClientName"))
+	})
+
+	It("should report no leaks", func() {
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+
+		leaks, err := client.ConnLeaks()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaks.Empty()).To(BeTrue())
+	})
+
+	It("should report connections closed by the server", func() {
+		pool := client.Pool()
+		cn1, err := pool.Get()
+		Expect(err).NotTo(HaveOccurred())
+		cn2, err := pool.Get()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pool.Put(cn1)).NotTo(HaveOccurred())
+		Expect(pool.Put(cn2)).NotTo(HaveOccurred())
+
+		killed := findConn("-2")
+		Expect(other.ClientKill(killed.Addr).Err()).NotTo(HaveOccurred())
+
+		leaks, err := client.ConnLeaks()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaks.Orphaned).To(BeEmpty())
+		Expect(leaks.Missing).To(Equal([]string{killed.Name}))
+	})
+
+	It("should report orphaned connections", func() {
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+		name := findConn("-1").Name
+		orphan := name[:len(name)-len("1")] + "100"
+
+		cmd := redis.NewStatusCmd("CLIENT", "SETNAME", orphan)
+		other.Process(cmd)
+		Expect(cmd.Err()).NotTo(HaveOccurred())
+
+		leaks, err := client.ConnLeaks()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaks.Missing).To(BeEmpty())
+		Expect(leaks.Orphaned).To(HaveLen(1))
+		Expect(leaks.Orphaned[0].Name).To(Equal(orphan))
+	})
+})
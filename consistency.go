@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"math/rand"
+	"time"
+)
+
+type consistencyLevel int
+
+const (
+	strongConsistency consistencyLevel = iota
+	eventualConsistency
+	boundedConsistency
+)
+
+// Consistency is a read consistency level used by ClusterClient to
+// choose the node serving read-only commands.
+type Consistency struct {
+	level  consistencyLevel
+	maxLag time.Duration
+}
+
+var (
+	// Strong consistency reads from masters.
+	Strong = Consistency{level: strongConsistency}
+	// Eventual consistency reads from random replicas of the slot.
+	Eventual = Consistency{level: eventualConsistency}
+)
+
+// Bounded consistency reads from replicas lagging behind the master by
+// at most maxLag and from the master when there are no such replicas.
+// The lag is reported by the master with one second precision.
+func Bounded(maxLag time.Duration) Consistency {
+	return Consistency{level: boundedConsistency, maxLag: maxLag}
+}
+
+// ConsistencyClient sends commands using ClusterClient, but routes
+// read-only commands according to the consistency level. It is
+// returned by ClusterClient.WithConsistency.
+type ConsistencyClient struct {
+	commandable
+
+	cluster     *ClusterClient
+	consistency Consistency
+}
+
+// WithConsistency returns a client routing read-only commands with the
+// consistency, e.g. client.WithConsistency(redis.Strong).Get(key).
+// Eventual and Bounded consistency require ReadOnly option, otherwise
+// all commands are sent to masters. Replica lag used by Bounded
+// consistency is refreshed every ReplicaStatusInterval.
+func (c *ClusterClient) WithConsistency(consistency Consistency) *ConsistencyClient {
+	client := &ConsistencyClient{
+		cluster:     c,
+		consistency: consistency,
+	}
+	client.commandable.process = client.process
+	return client
+}
+
+func (c *ConsistencyClient) process(cmd Cmder) {
+	c.cluster.processConsistency(cmd, c.consistency)
+}
+
+// slotReadAddr returns the address of the node serving reads of the
+// slot with the consistency.
+func (c *ClusterClient) slotReadAddr(slot int, consistency Consistency) string {
+	if !c.opt.ReadOnly {
+		return c.slotMasterAddr(slot)
+	}
+	switch consistency.level {
+	case eventualConsistency:
+		return c.slotReplicaAddr(slot)
+	case boundedConsistency:
+		return c.slotBoundedReplicaAddr(slot, consistency.maxLag)
+	}
+	return c.slotMasterAddr(slot)
+}
+
+// slotBoundedReplicaAddr returns a random online replica address for
+// the slot lagging by at most maxLag or the master address if there
+// are no such replicas.
+func (c *ClusterClient) slotBoundedReplicaAddr(slot int, maxLag time.Duration) string {
+	addrs := c.slotAddrs(slot)
+	if len(addrs) == 0 {
+		return ""
+	}
+
+	c.replicas.mu.RLock()
+	var candidates []string
+	for _, addr := range addrs[1:] {
+		status, ok := c.replicas.statuses[addr]
+		if ok && status.State == "online" && status.Lag <= maxLag {
+			candidates = append(candidates, addr)
+		}
+	}
+	c.replicas.mu.RUnlock()
+
+	if len(candidates) == 0 {
+		return addrs[0]
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
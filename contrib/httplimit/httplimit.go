@@ -0,0 +1,167 @@
+// Package httplimit provides HTTP middleware that limits request rate
+// per identity, e.g. per API key, using token buckets stored in Redis.
+// Responses carry RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers and rejected requests also get Retry-After.
+package httplimit
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gopkg.in/redis.v3"
+)
+
+// Bucket is stored in a hash with the number of tokens and the time of
+// the last refill. The key expires when the bucket is full again.
+var allowScript = redis.NewScript(`
+redis.replicate_commands()
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local limit, period = tonumber(ARGV[1]), tonumber(ARGV[2])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1]) or limit
+local ts = tonumber(state[2]) or now
+tokens = math.min(limit, tokens + (now - ts) * limit / period)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+local reset = math.ceil((limit - tokens) * period / limit)
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.max(reset, 1))
+
+local retry = 0
+if allowed == 0 then
+  retry = math.ceil((1 - tokens) * period / limit)
+end
+return {allowed, math.floor(tokens), reset, retry}
+`)
+
+var errInvalidLimit = errors.New("httplimit: limit must be positive and period at least a millisecond")
+
+// Result is the outcome of Limiter.Allow.
+type Result struct {
+	Allowed   bool
+	Limit     int64
+	Remaining int64
+	// Time until the bucket is full again.
+	Reset time.Duration
+	// Time until the next request is allowed. Zero if request is allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter allows up to limit requests per period for each identity.
+// Bursts of up to limit requests are allowed.
+type Limiter struct {
+	client *redis.Client
+	prefix string
+	limit  int64
+	period time.Duration
+	err    error
+}
+
+// NewLimiter returns a limiter storing buckets in keys prefixed with
+// "ratelimit:". Limit must be positive and period at least a
+// millisecond, otherwise Allow returns an error.
+func NewLimiter(client *redis.Client, limit int64, period time.Duration) *Limiter {
+	l := &Limiter{
+		client: client,
+		prefix: "ratelimit:",
+		limit:  limit,
+		period: period,
+	}
+	if limit <= 0 || period < time.Millisecond {
+		l.err = errInvalidLimit
+	}
+	return l
+}
+
+// Allow takes a token from the bucket of the identity.
+func (l *Limiter) Allow(id string) (*Result, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	keys := []string{l.prefix + id}
+	args := []string{
+		strconv.FormatInt(l.limit, 10),
+		strconv.FormatInt(int64(l.period/time.Millisecond), 10),
+	}
+	v, err := allowScript.Run(l.client, keys, args).Result()
+	if err != nil {
+		return nil, err
+	}
+	reply := v.([]interface{})
+	return &Result{
+		Allowed:    reply[0].(int64) == 1,
+		Limit:      l.limit,
+		Remaining:  reply[1].(int64),
+		Reset:      time.Duration(reply[2].(int64)) * time.Millisecond,
+		RetryAfter: time.Duration(reply[3].(int64)) * time.Millisecond,
+	}, nil
+}
+
+// Options are used to configure middleware.
+type Options struct {
+	// Identity returns identity of the request. Requests with empty
+	// identity are not limited.
+	// Default is the value of X-API-Key header.
+	Identity func(*http.Request) string
+	// OnError is called when limiter fails. Request is served unless
+	// OnError writes a response and returns false.
+	// Default is to serve the request.
+	OnError func(http.ResponseWriter, *http.Request, error) bool
+}
+
+func (opt *Options) identity(req *http.Request) string {
+	if opt.Identity == nil {
+		return req.Header.Get("X-API-Key")
+	}
+	return opt.Identity(req)
+}
+
+// Handler returns a handler that serves requests using h as long as
+// identity has not exceeded the limit and replies with 429 Too Many
+// Requests otherwise.
+func (l *Limiter) Handler(h http.Handler, opt *Options) http.Handler {
+	if opt == nil {
+		opt = &Options{}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := opt.identity(req)
+		if id == "" {
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		res, err := l.Allow(id)
+		if err != nil {
+			if opt.OnError == nil || opt.OnError(w, req, err) {
+				h.ServeHTTP(w, req)
+			}
+			return
+		}
+
+		header := w.Header()
+		header.Set("RateLimit-Limit", strconv.FormatInt(res.Limit, 10))
+		header.Set("RateLimit-Remaining", strconv.FormatInt(res.Remaining, 10))
+		header.Set("RateLimit-Reset", formatSeconds(res.Reset))
+		if !res.Allowed {
+			header.Set("Retry-After", formatSeconds(res.RetryAfter))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		h.ServeHTTP(w, req)
+	})
+}
+
+// formatSeconds formats duration as a number of seconds rounded up.
+func formatSeconds(dur time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(dur.Seconds())), 10)
+}
@@ -0,0 +1,117 @@
+package httplimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gopkg.in/redis.v3"
+)
+
+func redisClient(t *testing.T) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr: ":6379",
+	})
+	if err := client.Ping().Err(); err != nil {
+		t.Skipf("redis is not available: %s", err)
+	}
+	if err := client.FlushDb().Err(); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestHandler(t *testing.T) {
+	client := redisClient(t)
+	defer client.Close()
+
+	limiter := NewLimiter(client, 2, time.Minute)
+	h := limiter.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), nil)
+
+	serve := func(key string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("X-API-Key", key)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	for i, wanted := range []string{"1", "0"} {
+		w := serve("key1")
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("request %d: got %d, wanted %d", i, w.Code, http.StatusNoContent)
+		}
+		if got := w.Header().Get("RateLimit-Remaining"); got != wanted {
+			t.Errorf("request %d: got remaining %s, wanted %s", i, got, wanted)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "2" {
+			t.Errorf("request %d: got limit %s, wanted 2", i, got)
+		}
+	}
+
+	w := serve("key1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("got %d, wanted %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("got Retry-After %s, wanted 30", got)
+	}
+	if got := w.Header().Get("RateLimit-Reset"); got != "60" {
+		t.Errorf("got RateLimit-Reset %s, wanted 60", got)
+	}
+
+	if w := serve("key2"); w.Code != http.StatusNoContent {
+		t.Errorf("got %d, wanted %d", w.Code, http.StatusNoContent)
+	}
+	if w := serve(""); w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("request without identity must not be limited")
+	}
+}
+
+func TestHandlerError(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr: ":6379",
+	})
+	client.Close()
+
+	limiter := NewLimiter(client, 1, time.Second)
+	h := limiter.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		t.Error("request must not be served")
+	}), &Options{
+		Identity: func(req *http.Request) string {
+			return req.RemoteAddr
+		},
+		OnError: func(w http.ResponseWriter, req *http.Request, err error) bool {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return false
+		},
+	})
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "127.0.0.1:1234"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got %d, wanted %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestInvalidLimit(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr: ":6379",
+	})
+	defer client.Close()
+
+	for _, l := range []*Limiter{
+		NewLimiter(client, 0, time.Second),
+		NewLimiter(client, 1, 0),
+		NewLimiter(client, 1, time.Microsecond),
+	} {
+		if _, err := l.Allow("id"); err != errInvalidLimit {
+			t.Errorf("got %v, wanted %v", err, errInvalidLimit)
+		}
+	}
+}
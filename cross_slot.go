@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// CrossSlotError is returned by ClusterClient when keys of a multi-key
+// command hash to different slots.
+type CrossSlotError struct {
+	// Upper-cased command name.
+	Command string
+	Keys    []string
+	// Slots of the keys in the same order as Keys.
+	Slots []int
+}
+
+func (e *CrossSlotError) Error() string {
+	var buf bytes.Buffer
+	for i, key := range e.Keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "%q (slot %d)", key, e.Slots[i])
+	}
+	return fmt.Sprintf("redis: %s keys hash to different slots: %s", e.Command, buf.String())
+}
+
+// KeysBySlot groups keys by slot, so the command can be split into
+// commands having keys in the same slot.
+func (e *CrossSlotError) KeysBySlot() map[int][]string {
+	m := make(map[int][]string)
+	for i, key := range e.Keys {
+		m[e.Slots[i]] = append(m[e.Slots[i]], key)
+	}
+	return m
+}
+
+// checkCrossSlot returns CrossSlotError when keys of the command hash
+// to different slots.
+func checkCrossSlot(cmd Cmder) error {
+	keys := cmdKeys(cmd)
+	if len(keys) < 2 {
+		return nil
+	}
+
+	slots := make([]int, len(keys))
+	var cross bool
+	for i, key := range keys {
+		slots[i] = hashSlot(key)
+		if slots[i] != slots[0] {
+			cross = true
+		}
+	}
+	if !cross {
+		return nil
+	}
+	return &CrossSlotError{
+		Command: strings.ToUpper(cmd.Name()),
+		Keys:    keys,
+		Slots:   slots,
+	}
+}
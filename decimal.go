@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var errInvalidDecimal = errors.New("redis: invalid decimal")
+
+// Decimal is a fixed-point decimal number equal to
+// Unscaled * 10^-Scale, e.g. Decimal{Unscaled: 1050, Scale: 2} is
+// 10.50. It can be used to store monetary values without float
+// rounding errors.
+type Decimal struct {
+	Unscaled int64
+	Scale    int
+}
+
+// ParseDecimal parses decimal string like "-10.50".
+func ParseDecimal(s string) (Decimal, error) {
+	var d Decimal
+	digits := s
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		d.Scale = len(s) - i - 1
+		digits = s[:i] + s[i+1:]
+	}
+	if d.Scale > 18 || strings.ContainsAny(digits, "+ ") {
+		return Decimal{}, errInvalidDecimal
+	}
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Decimal{}, errInvalidDecimal
+	}
+	d.Unscaled = n
+	return d, nil
+}
+
+func (d Decimal) String() string {
+	s := strconv.FormatInt(d.Unscaled, 10)
+	if d.Scale < 0 && d.Unscaled != 0 {
+		// Negative scale multiplies by 10^-Scale.
+		return s + strings.Repeat("0", -d.Scale)
+	}
+	if d.Scale <= 0 {
+		return s
+	}
+
+	var sign string
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= d.Scale {
+		s = strings.Repeat("0", d.Scale-len(s)+1) + s
+	}
+	return sign + s[:len(s)-d.Scale] + "." + s[len(s)-d.Scale:]
+}
+
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalBinary(b []byte) error {
+	var err error
+	*d, err = ParseDecimal(string(b))
+	return err
+}
+
+// Values are added as integers in units of the larger scale. Lua
+// numbers are doubles, so units are limited to 2^53-1 to stay exact.
+var incrByDecimalScript = NewScript(`
+local pattern = "^(-?)(%d+)%.?(%d*)$"
+local max = 9007199254740991
+
+local cur = redis.call("GET", KEYS[1]) or "0"
+local csign, cint, cfrac = string.match(cur, pattern)
+if not cint then
+  return redis.error_reply("ERR value is not a valid decimal")
+end
+local isign, iint, ifrac = string.match(ARGV[1], pattern)
+local scale = math.max(#cfrac, #ifrac)
+
+local function units(sign, int, frac)
+  local n = tonumber(int .. frac .. string.rep("0", scale - #frac))
+  if sign == "-" then
+    return -n
+  end
+  return n
+end
+
+local a = units(csign, cint, cfrac)
+local b = units(isign, iint, ifrac)
+local n = a + b
+if math.abs(a) > max or math.abs(b) > max or math.abs(n) > max then
+  return redis.error_reply("ERR decimal is out of range")
+end
+
+local s = string.format("%d", math.abs(n))
+if scale > 0 then
+  s = string.rep("0", scale + 1 - #s) .. s
+  s = string.sub(s, 1, #s - scale) .. "." .. string.sub(s, #s - scale + 1)
+end
+if n < 0 then
+  s = "-" .. s
+end
+redis.call("SET", KEYS[1], s, "KEEPTTL")
+return s
+`)
+
+// IncrByDecimal atomically increments the decimal number stored at key
+// by d without float rounding errors and returns the new value. The
+// value is stored as a decimal string with the scale of the stored
+// value or d, whichever is larger. A missing key is treated as 0.
+// Values with more than 15 significant digits are rejected with an
+// error.
+func (c *Client) IncrByDecimal(key string, d Decimal) (Decimal, error) {
+	v, err := incrByDecimalScript.Run(c, []string{key}, []string{d.String()}).Result()
+	if err != nil {
+		return Decimal{}, err
+	}
+	return ParseDecimal(v.(string))
+}
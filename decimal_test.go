@@ -0,0 +1,82 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("Decimal", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should parse and format decimals", func() {
+		tests := []struct {
+			s string
+			d redis.Decimal
+		}{
+			{"0", redis.Decimal{Unscaled: 0, Scale: 0}},
+			{"10.50", redis.Decimal{Unscaled: 1050, Scale: 2}},
+			{"-0.05", redis.Decimal{Unscaled: -5, Scale: 2}},
+			{"123", redis.Decimal{Unscaled: 123, Scale: 0}},
+		}
+		for _, test := range tests {
+			d, err := redis.ParseDecimal(test.s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d).To(Equal(test.d))
+			Expect(d.String()).To(Equal(test.s))
+		}
+
+		// Negative scale multiplies by a power of ten.
+		Expect(redis.Decimal{Unscaled: 5, Scale: -2}.String()).To(Equal("500"))
+		Expect(redis.Decimal{Unscaled: -5, Scale: -1}.String()).To(Equal("-50"))
+		Expect(redis.Decimal{Unscaled: 0, Scale: -2}.String()).To(Equal("0"))
+
+		for _, s := range []string{"", ".", "1.2.3", "+1", "abc", "1e5"} {
+			_, err := redis.ParseDecimal(s)
+			Expect(err).To(MatchError("redis: invalid decimal"), s)
+		}
+	})
+
+	It("should increment decimals", func() {
+		d, err := client.IncrByDecimal("balance", redis.Decimal{Unscaled: 10, Scale: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.String()).To(Equal("1.0"))
+
+		for i := 0; i < 10; i++ {
+			d, err = client.IncrByDecimal("balance", redis.Decimal{Unscaled: 1, Scale: 2})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(d).To(Equal(redis.Decimal{Unscaled: 110, Scale: 2}))
+		Expect(client.Get("balance").Val()).To(Equal("1.10"))
+
+		d, err = client.IncrByDecimal("balance", redis.Decimal{Unscaled: -115, Scale: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.String()).To(Equal("-0.05"))
+
+		var got redis.Decimal
+		Expect(client.Get("balance").Scan(&got)).NotTo(HaveOccurred())
+		Expect(got).To(Equal(d))
+	})
+
+	It("should reject invalid values", func() {
+		Expect(client.Set("balance", "hello", 0).Err()).NotTo(HaveOccurred())
+		_, err := client.IncrByDecimal("balance", redis.Decimal{Unscaled: 1})
+		Expect(err).To(MatchError("ERR value is not a valid decimal"))
+
+		Expect(client.Set("balance", "9007199254740991", 0).Err()).NotTo(HaveOccurred())
+		_, err = client.IncrByDecimal("balance", redis.Decimal{Unscaled: 1})
+		Expect(err).To(MatchError("ERR decimal is out of range"))
+	})
+})
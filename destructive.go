@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// destructiveCommands are commands that delete all keys and are
+// rejected when DisableDestructiveCommands option is set.
+var destructiveCommands = map[string]bool{
+	"flushall": true,
+	"flushdb":  true,
+}
+
+func checkDestructive(cmd Cmder) error {
+	name := cmd.Name()
+	if destructiveCommands[name] {
+		return fmt.Errorf("redis: %s is disabled by DisableDestructiveCommands option", strings.ToUpper(name))
+	}
+	return nil
+}
+
+func checkDestructiveCmds(cmds []Cmder) error {
+	for _, cmd := range cmds {
+		if err := checkDestructive(cmd); err != nil {
+			setCmdsErr(cmds, err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package redis
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// TimeFormat specifies how time.Time arguments are encoded.
+type TimeFormat int
+
+const (
+	// Encodes time using time.Time.MarshalBinary.
+	TimeBinary TimeFormat = iota
+	// Encodes time as RFC 3339 string with nanoseconds.
+	TimeRFC3339
+	// Encodes time as Unix time in seconds.
+	TimeUnix
+	// Encodes time as Unix time in milliseconds.
+	TimeUnixMs
+)
+
+// DurationFormat specifies how time.Duration arguments are encoded.
+type DurationFormat int
+
+const (
+	// Encodes duration as integer number of nanoseconds.
+	DurationNanoseconds DurationFormat = iota
+	// Encodes duration as integer number of milliseconds.
+	DurationMilliseconds
+	// Encodes duration as integer number of seconds.
+	DurationSeconds
+	// Encodes duration as string like "1h2m3s".
+	DurationString
+)
+
+// Encoding configures encoding of time and duration values used as
+// command arguments and decoded by Scan. *big.Int values are always
+// encoded as decimal strings. Other values are encoded using
+// encoding.BinaryMarshaler or encoding.TextMarshaler, in that order.
+// Encoding is set per client using Options.Encoding.
+type Encoding struct {
+	// Default is TimeBinary.
+	Time TimeFormat
+	// Default is DurationNanoseconds.
+	Duration DurationFormat
+	// Gob encodes values that don't implement marshaler interfaces
+	// using encoding/gob, e.g. plain structs. Values are decoded by
+	// Scan into pointers of the same type.
+	// Default is to return an error for such values.
+	Gob bool
+}
+
+var defaultEncoding = &Encoding{}
+
+func appendTime(b []byte, tm time.Time, enc *Encoding) ([]byte, error) {
+	switch enc.Time {
+	case TimeRFC3339:
+		return appendString(b, tm.Format(time.RFC3339Nano)), nil
+	case TimeUnix:
+		return appendString(b, formatInt(tm.Unix())), nil
+	case TimeUnixMs:
+		return appendString(b, formatInt(tm.UnixNano()/int64(time.Millisecond))), nil
+	default:
+		bb, err := tm.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		return appendBytes(b, bb), nil
+	}
+}
+
+func scanTime(b []byte, tm *time.Time, enc *Encoding) error {
+	switch enc.Time {
+	case TimeRFC3339:
+		var err error
+		*tm, err = time.Parse(time.RFC3339Nano, bytesToString(b))
+		return err
+	case TimeUnix:
+		n, err := strconv.ParseInt(bytesToString(b), 10, 64)
+		if err != nil {
+			return err
+		}
+		*tm = time.Unix(n, 0)
+		return nil
+	case TimeUnixMs:
+		n, err := strconv.ParseInt(bytesToString(b), 10, 64)
+		if err != nil {
+			return err
+		}
+		*tm = time.Unix(0, n*int64(time.Millisecond))
+		return nil
+	default:
+		return tm.UnmarshalBinary(b)
+	}
+}
+
+func appendDuration(b []byte, dur time.Duration, enc *Encoding) []byte {
+	switch enc.Duration {
+	case DurationMilliseconds:
+		return appendString(b, formatInt(int64(dur/time.Millisecond)))
+	case DurationSeconds:
+		return appendString(b, formatInt(int64(dur/time.Second)))
+	case DurationString:
+		return appendString(b, dur.String())
+	default:
+		return appendString(b, formatInt(int64(dur)))
+	}
+}
+
+func scanDuration(b []byte, dur *time.Duration, enc *Encoding) error {
+	if enc.Duration == DurationString {
+		var err error
+		*dur, err = time.ParseDuration(bytesToString(b))
+		return err
+	}
+
+	n, err := strconv.ParseInt(bytesToString(b), 10, 64)
+	if err != nil {
+		return err
+	}
+	switch enc.Duration {
+	case DurationMilliseconds:
+		*dur = time.Duration(n) * time.Millisecond
+	case DurationSeconds:
+		*dur = time.Duration(n) * time.Second
+	default:
+		*dur = time.Duration(n)
+	}
+	return nil
+}
+
+func appendBigInt(b []byte, n *big.Int) ([]byte, error) {
+	if n == nil {
+		return nil, errorf("redis: can't marshal nil *big.Int")
+	}
+	return appendString(b, n.String()), nil
+}
+
+func scanBigInt(b []byte, n *big.Int) error {
+	if _, ok := n.SetString(bytesToString(b), 10); !ok {
+		return fmt.Errorf("redis: can't parse %q as big.Int", b)
+	}
+	return nil
+}
+
+func appendGob(b []byte, val interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(val); err != nil {
+		return nil, err
+	}
+	return appendBytes(b, buf.Bytes()), nil
+}
+
+func scanGob(b []byte, val interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(b)).Decode(val)
+}
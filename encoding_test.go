@@ -0,0 +1,137 @@
+package redis_test
+
+import (
+	"math/big"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("Encoding", func() {
+	var client *redis.Client
+
+	newClient := func(enc *redis.Encoding) *redis.Client {
+		return redis.NewClient(&redis.Options{
+			Addr:     redisAddr,
+			Encoding: enc,
+		})
+	}
+
+	BeforeEach(func() {
+		client = newClient(nil)
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should encode time", func() {
+		tm := time.Date(2016, 1, 2, 3, 4, 5, 6000000, time.UTC)
+
+		tests := []struct {
+			format  redis.TimeFormat
+			encoded string
+			decoded time.Time
+		}{
+			{redis.TimeRFC3339, "2016-01-02T03:04:05.006Z", tm},
+			{redis.TimeUnix, "1451703845", tm.Truncate(time.Second)},
+			{redis.TimeUnixMs, "1451703845006", tm},
+		}
+		for _, test := range tests {
+			c := newClient(&redis.Encoding{Time: test.format})
+			Expect(c.Set("key", tm, 0).Err()).NotTo(HaveOccurred())
+			Expect(c.Get("key").Val()).To(Equal(test.encoded))
+
+			var got time.Time
+			Expect(c.Get("key").Scan(&got)).NotTo(HaveOccurred())
+			Expect(got.Equal(test.decoded)).To(BeTrue(), "%s", got)
+			Expect(c.Close()).NotTo(HaveOccurred())
+		}
+
+		Expect(client.Set("key", tm, 0).Err()).NotTo(HaveOccurred())
+		var got time.Time
+		Expect(client.Get("key").Scan(&got)).NotTo(HaveOccurred())
+		Expect(got.Equal(tm)).To(BeTrue())
+	})
+
+	It("should encode durations", func() {
+		dur := 90*time.Second + 500*time.Millisecond
+
+		tests := []struct {
+			format  redis.DurationFormat
+			encoded string
+			decoded time.Duration
+		}{
+			{redis.DurationNanoseconds, "90500000000", dur},
+			{redis.DurationMilliseconds, "90500", dur},
+			{redis.DurationSeconds, "90", 90 * time.Second},
+			{redis.DurationString, "1m30.5s", dur},
+		}
+		for _, test := range tests {
+			c := newClient(&redis.Encoding{Duration: test.format})
+			Expect(c.Set("key", dur, 0).Err()).NotTo(HaveOccurred())
+			Expect(c.Get("key").Val()).To(Equal(test.encoded))
+
+			var got time.Duration
+			Expect(c.Get("key").Scan(&got)).NotTo(HaveOccurred())
+			Expect(got).To(Equal(test.decoded))
+			Expect(c.Close()).NotTo(HaveOccurred())
+		}
+
+		Expect(client.Set("key", dur, 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Get("key").Val()).To(Equal("90500000000"))
+	})
+
+	It("should encode big integers", func() {
+		n, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+		Expect(ok).To(BeTrue())
+
+		Expect(client.Set("key", n, 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Get("key").Val()).To(Equal("123456789012345678901234567890"))
+
+		got := new(big.Int)
+		Expect(client.Get("key").Scan(got)).NotTo(HaveOccurred())
+		Expect(got.Cmp(n)).To(Equal(0))
+
+		Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+		err := client.Get("key").Scan(got)
+		Expect(err).To(MatchError(`redis: can't parse "hello" as big.Int`))
+	})
+
+	It("should encode TextMarshaler values", func() {
+		ip := net.ParseIP("192.168.1.1")
+		Expect(client.Set("key", ip, 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Get("key").Val()).To(Equal("192.168.1.1"))
+
+		var got net.IP
+		Expect(client.Get("key").Scan(&got)).NotTo(HaveOccurred())
+		Expect(got.Equal(ip)).To(BeTrue())
+	})
+
+	It("should encode values using gob", func() {
+		type point struct{ X, Y int }
+
+		err := client.Set("key", point{1, 2}, 0).Err()
+		Expect(err).To(HaveOccurred())
+
+		c := newClient(&redis.Encoding{Gob: true})
+		defer c.Close()
+		Expect(c.Set("key", point{1, 2}, 0).Err()).NotTo(HaveOccurred())
+
+		var got point
+		Expect(c.Get("key").Scan(&got)).NotTo(HaveOccurred())
+		Expect(got).To(Equal(point{1, 2}))
+
+		// Other clients don't use gob.
+		Expect(client.Get("key").Scan(&got)).To(HaveOccurred())
+
+		// Marshaler interfaces have priority over gob.
+		Expect(c.Set("key", net.ParseIP("10.0.0.1"), 0).Err()).NotTo(HaveOccurred())
+		Expect(c.Get("key").Val()).To(Equal("10.0.0.1"))
+	})
+})
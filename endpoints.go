@@ -0,0 +1,86 @@
+package redis
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// EndpointProvider provides addresses of Redis nodes from a service
+// discovery system, e.g. Kubernetes Endpoints or Consul. It is used
+// by Ring for shard addresses, by ClusterClient for seed node
+// addresses and by FailoverClient for sentinel addresses.
+type EndpointProvider interface {
+	// Addrs returns current host:port addresses.
+	Addrs() ([]string, error)
+	// Watch calls fn with new addresses every time they change until
+	// the returned stop function is called.
+	Watch(fn func(addrs []string)) (stop func())
+}
+
+// NewSRVEndpointProvider returns EndpointProvider that resolves
+// addresses from DNS SRV record. The record is re-queried every
+// interval. Default interval is 30 seconds.
+func NewSRVEndpointProvider(name string, interval time.Duration) EndpointProvider {
+	return newSRVResolver(name, interval)
+}
+
+// endpointProvider returns the provider configured by options or nil.
+func endpointProvider(p EndpointProvider, srv string, interval time.Duration) EndpointProvider {
+	if p != nil {
+		return p
+	}
+	if srv != "" {
+		return newSRVResolver(srv, interval)
+	}
+	return nil
+}
+
+// Watch polls DNS every refresh interval and calls fn when addresses
+// change. Stop may be called more than once.
+func (r *srvResolver) Watch(fn func(addrs []string)) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		prev, _ := r.Addrs()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			addrs, err := r.Addrs()
+			if err != nil {
+				log.Printf("redis: LookupSRV %q failed: %s", r.name, err)
+				continue
+			}
+			if !sameAddrs(prev, addrs) {
+				prev = addrs
+				fn(addrs)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
+
+// sameAddrs reports whether a and b contain the same addresses
+// ignoring the order.
+func sameAddrs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, addr := range a {
+		if !contains(b, addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -54,6 +54,20 @@ func isMovedError(err error) (moved bool, ask bool, addr string) {
 	return
 }
 
+// isReplicaError reports whether the command failed because the
+// replica can't serve reads, e.g. it is loading the dataset or lost
+// connection to its master.
+func isReplicaError(err error) bool {
+	if isNetworkError(err) {
+		return true
+	}
+	if _, ok := err.(redisError); !ok {
+		return false
+	}
+	s := err.Error()
+	return strings.HasPrefix(s, "LOADING ") || strings.HasPrefix(s, "MASTERDOWN ")
+}
+
 // shouldRetry reports whether failed command should be retried.
 func shouldRetry(err error) bool {
 	if err == nil {
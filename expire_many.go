@@ -0,0 +1,88 @@
+package redis
+
+import "time"
+
+// expireManyBatchSize is the number of PEXPIRE commands sent in a
+// single pipeline.
+const expireManyBatchSize = 1000
+
+type expirePipeliner interface {
+	PExpire(key string, expiration time.Duration) *BoolCmd
+	Exec() ([]Cmder, error)
+	Close() error
+}
+
+// ExpireMany sets expiration of keys using pipelined PEXPIRE commands,
+// e.g. to add TTLs to keys created without them. It returns whether
+// the expiration was set for every key, i.e. false means that the key
+// does not exist. Batches are sent even if previous batches failed;
+// keys of failed commands are missing from the result and the first
+// error is returned.
+func (c *Client) ExpireMany(ttls map[string]time.Duration) (map[string]bool, error) {
+	keys := make([]string, 0, len(ttls))
+	for key := range ttls {
+		keys = append(keys, key)
+	}
+	newPipe := func() expirePipeliner {
+		return c.Pipeline()
+	}
+	return expireMany(newPipe, keys, ttls)
+}
+
+// ExpireMany sets expiration of keys using pipelined PEXPIRE commands.
+// Keys are grouped by slot, so every batch is sent to as few nodes as
+// possible. See Client.ExpireMany.
+func (c *ClusterClient) ExpireMany(ttls map[string]time.Duration) (map[string]bool, error) {
+	var slots []int
+	slotKeys := make(map[int][]string)
+	for key := range ttls {
+		slot := hashSlot(key)
+		if _, ok := slotKeys[slot]; !ok {
+			slots = append(slots, slot)
+		}
+		slotKeys[slot] = append(slotKeys[slot], key)
+	}
+
+	keys := make([]string, 0, len(ttls))
+	for _, slot := range slots {
+		keys = append(keys, slotKeys[slot]...)
+	}
+	newPipe := func() expirePipeliner {
+		return c.Pipeline()
+	}
+	return expireMany(newPipe, keys, ttls)
+}
+
+func expireMany(
+	newPipe func() expirePipeliner,
+	keys []string,
+	ttls map[string]time.Duration,
+) (map[string]bool, error) {
+	res := make(map[string]bool, len(keys))
+	var firstErr error
+	for len(keys) > 0 {
+		n := expireManyBatchSize
+		if n > len(keys) {
+			n = len(keys)
+		}
+
+		pipe := newPipe()
+		cmds := make([]*BoolCmd, n)
+		for i, key := range keys[:n] {
+			cmds[i] = pipe.PExpire(key, ttls[key])
+		}
+		_, err := pipe.Exec()
+		pipe.Close()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		for i, key := range keys[:n] {
+			if cmds[i].Err() == nil {
+				res[key] = cmds[i].Val()
+			}
+		}
+		keys = keys[n:]
+	}
+	return res, firstErr
+}
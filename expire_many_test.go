@@ -0,0 +1,53 @@
+package redis_test
+
+import (
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("ExpireMany", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should set expiration of keys", func() {
+		ttls := make(map[string]time.Duration)
+		for i := 0; i < 2500; i++ {
+			key := "key" + strconv.Itoa(i)
+			Expect(client.Set(key, "value", 0).Err()).NotTo(HaveOccurred())
+			ttls[key] = time.Hour
+		}
+		ttls["missing"] = time.Hour
+
+		res, err := client.ExpireMany(ttls)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(HaveLen(2501))
+		Expect(res).To(HaveKeyWithValue("key0", true))
+		Expect(res).To(HaveKeyWithValue("key2499", true))
+		Expect(res).To(HaveKeyWithValue("missing", false))
+
+		ttl, err := client.PTTL("key1234").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ttl).To(BeNumerically("~", time.Hour, time.Minute))
+	})
+
+	It("should handle empty map", func() {
+		res, err := client.ExpireMany(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(BeEmpty())
+	})
+})
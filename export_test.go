@@ -13,3 +13,19 @@ func (cn *conn) SetNetConn(netcn net.Conn) {
 func HashSlot(key string) int {
 	return hashSlot(key)
 }
+
+func ParseMonitorEvent(line string) (*MonitorEvent, error) {
+	return parseMonitorEvent(line)
+}
+
+func MatchGlob(pattern, s string) bool {
+	return matchGlob(pattern, s)
+}
+
+func SetLookupSRV(fn func(service, proto, name string) (string, []*net.SRV, error)) (restore func()) {
+	old := lookupSRV
+	lookupSRV = fn
+	return func() {
+		lookupSRV = old
+	}
+}
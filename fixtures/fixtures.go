@@ -0,0 +1,269 @@
+// Package fixtures loads keyspace fixtures described in JSON into
+// Redis and snapshots the keyspace back to fixtures, which makes
+// integration tests reproducible.
+//
+// A fixture looks like:
+//
+//	{
+//	  "keys": [
+//	    {"key": "greeting", "type": "string", "string": "hello", "ttl": "1h0m0s"},
+//	    {"key": "user:1", "type": "hash", "hash": {"name": "John"}},
+//	    {"key": "queue", "type": "list", "list": ["a", "b"]},
+//	    {"key": "tags", "type": "set", "set": ["go", "redis"]},
+//	    {"key": "scores", "type": "zset", "zset": [{"member": "John", "score": 42}]},
+//	    {"key": "events", "type": "stream", "stream": [{"id": "1-0", "fields": {"type": "login"}}]}
+//	  ]
+//	}
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"gopkg.in/redis.v3"
+)
+
+// Fixture describes keys stored in a database.
+type Fixture struct {
+	Keys []Key `json:"keys"`
+}
+
+// Key describes a single key. Only the field matching Type is used.
+type Key struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	// Time to live of the key. Zero means that the key does not
+	// expire.
+	TTL Duration `json:"ttl,omitempty"`
+
+	String string            `json:"string,omitempty"`
+	Hash   map[string]string `json:"hash,omitempty"`
+	List   []string          `json:"list,omitempty"`
+	Set    []string          `json:"set,omitempty"`
+	ZSet   []ZMember         `json:"zset,omitempty"`
+	Stream []StreamEntry     `json:"stream,omitempty"`
+}
+
+// ZMember is a sorted set member.
+type ZMember struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// StreamEntry is a stream entry. Empty ID is generated by the server.
+type StreamEntry struct {
+	ID     string            `json:"id,omitempty"`
+	Fields map[string]string `json:"fields"`
+}
+
+// Duration is time.Duration encoded in JSON as a string like "1h0m0s".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	dur, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(dur)
+	return nil
+}
+
+// Read decodes JSON fixture.
+func Read(r io.Reader) (*Fixture, error) {
+	var f Fixture
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Write encodes the fixture as indented JSON.
+func (f *Fixture) Write(w io.Writer) error {
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	_, err = w.Write(b)
+	return err
+}
+
+// Load replaces keys described by the fixture.
+func Load(client *redis.Client, f *Fixture) error {
+	for i := range f.Keys {
+		if err := loadKey(client, &f.Keys[i]); err != nil {
+			return fmt.Errorf("fixtures: loading %q failed: %s", f.Keys[i].Key, err)
+		}
+	}
+	return nil
+}
+
+// LoadFrom reads JSON fixture and loads it.
+func LoadFrom(client *redis.Client, r io.Reader) error {
+	f, err := Read(r)
+	if err != nil {
+		return err
+	}
+	return Load(client, f)
+}
+
+func loadKey(client *redis.Client, k *Key) error {
+	multi := client.Multi()
+	defer multi.Close()
+
+	_, err := multi.Exec(func() error {
+		multi.Del(k.Key)
+		switch k.Type {
+		case "string":
+			multi.Set(k.Key, k.String, 0)
+		case "hash":
+			for field, value := range k.Hash {
+				multi.HSet(k.Key, field, value)
+			}
+		case "list":
+			if len(k.List) > 0 {
+				multi.RPush(k.Key, k.List...)
+			}
+		case "set":
+			if len(k.Set) > 0 {
+				multi.SAdd(k.Key, k.Set...)
+			}
+		case "zset":
+			for _, z := range k.ZSet {
+				multi.ZAdd(k.Key, redis.Z{Score: z.Score, Member: z.Member})
+			}
+		case "stream":
+			for _, entry := range k.Stream {
+				values := make(map[string]interface{}, len(entry.Fields))
+				for field, value := range entry.Fields {
+					values[field] = value
+				}
+				multi.XAdd(&redis.XAddArgs{
+					Stream: k.Key,
+					ID:     entry.ID,
+					Values: values,
+				})
+			}
+		default:
+			return fmt.Errorf("unsupported type %q", k.Type)
+		}
+		if k.TTL > 0 {
+			multi.PExpire(k.Key, time.Duration(k.TTL))
+		}
+		return nil
+	})
+	return err
+}
+
+// Snapshot returns a fixture describing keys matching the pattern.
+// Keys are sorted by name and set members are sorted to make
+// snapshots comparable.
+func Snapshot(client *redis.Client, match string) (*Fixture, error) {
+	var names []string
+	var cursor int64
+	for {
+		var keys []string
+		var err error
+		cursor, keys, err = client.Scan(cursor, match, 100).Result()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, keys...)
+		if cursor == 0 {
+			break
+		}
+	}
+	sort.Strings(names)
+
+	f := &Fixture{}
+	for i, name := range names {
+		// SCAN may return the same key more than once.
+		if i > 0 && names[i-1] == name {
+			continue
+		}
+		k, err := snapshotKey(client, name)
+		if err != nil {
+			return nil, fmt.Errorf("fixtures: snapshot of %q failed: %s", name, err)
+		}
+		if k != nil {
+			f.Keys = append(f.Keys, *k)
+		}
+	}
+	return f, nil
+}
+
+func snapshotKey(client *redis.Client, name string) (*Key, error) {
+	typ, err := client.Type(name).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	k := &Key{Key: name, Type: typ}
+	switch typ {
+	case "none":
+		// Key expired or was deleted.
+		return nil, nil
+	case "string":
+		k.String, err = client.Get(name).Result()
+	case "hash":
+		k.Hash, err = client.HGetAllMap(name).Result()
+	case "list":
+		k.List, err = client.LRange(name, 0, -1).Result()
+	case "set":
+		k.Set, err = client.SMembers(name).Result()
+		sort.Strings(k.Set)
+	case "zset":
+		var zz []redis.Z
+		zz, err = client.ZRangeWithScores(name, 0, -1).Result()
+		for _, z := range zz {
+			k.ZSet = append(k.ZSet, ZMember{
+				Member: fmt.Sprint(z.Member),
+				Score:  z.Score,
+			})
+		}
+	case "stream":
+		k.Stream, err = xrange(client, name)
+	default:
+		return nil, fmt.Errorf("unsupported type %q", typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := client.PTTL(name).Result()
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		k.TTL = Duration(ttl)
+	}
+	return k, nil
+}
+
+func xrange(client *redis.Client, name string) ([]StreamEntry, error) {
+	msgs, err := client.XRange(name, "-", "+").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	stream := make([]StreamEntry, 0, len(msgs))
+	for _, msg := range msgs {
+		fields := make(map[string]string, len(msg.Values))
+		for field, value := range msg.Values {
+			fields[field] = fmt.Sprint(value)
+		}
+		stream = append(stream, StreamEntry{ID: msg.ID, Fields: fields})
+	}
+	return stream, nil
+}
@@ -0,0 +1,155 @@
+package fixtures
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/redis.v3"
+)
+
+const testFixture = `{
+  "keys": [
+    {"key": "fx:string", "type": "string", "string": "hello", "ttl": "1h0m0s"},
+    {"key": "fx:hash", "type": "hash", "hash": {"name": "John", "age": "42"}},
+    {"key": "fx:list", "type": "list", "list": ["a", "b", "a"]},
+    {"key": "fx:set", "type": "set", "set": ["go", "redis"]},
+    {"key": "fx:zset", "type": "zset", "zset": [{"member": "one", "score": 1}, {"member": "two", "score": 2.5}]},
+    {"key": "fx:stream", "type": "stream", "stream": [{"id": "1-0", "fields": {"type": "login"}}, {"id": "2-0", "fields": {"type": "logout"}}]}
+  ]
+}`
+
+func redisClient(t *testing.T) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr: ":6379",
+	})
+	if err := client.Ping().Err(); err != nil {
+		t.Skipf("redis is not available: %s", err)
+	}
+	if err := client.FlushDb().Err(); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestReadWrite(t *testing.T) {
+	f, err := Read(strings.NewReader(testFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Keys) != 6 {
+		t.Fatalf("got %d keys, wanted 6", len(f.Keys))
+	}
+	if got := time.Duration(f.Keys[0].TTL); got != time.Hour {
+		t.Fatalf("got TTL %s, wanted 1h", got)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	f2, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(f, f2) {
+		t.Fatalf("got %#v, wanted %#v", f2, f)
+	}
+}
+
+func TestReadInvalidTTL(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"keys": [{"key": "k", "type": "string", "ttl": "soon"}]}`))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	client := redisClient(t)
+	defer client.Close()
+
+	if err := LoadFrom(client, strings.NewReader(testFixture)); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Set("other", "x", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Snapshot(client, "fx:*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Keys) != 6 {
+		t.Fatalf("got %d keys, wanted 6", len(f.Keys))
+	}
+
+	keys := make(map[string]Key)
+	for _, k := range f.Keys {
+		keys[k.Key] = k
+	}
+
+	k := keys["fx:string"]
+	if k.Type != "string" || k.String != "hello" {
+		t.Fatalf("got %#v", k)
+	}
+	if ttl := time.Duration(k.TTL); ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Fatalf("got TTL %s, wanted ~1h", ttl)
+	}
+	if k := keys["fx:hash"]; !reflect.DeepEqual(k.Hash, map[string]string{"name": "John", "age": "42"}) {
+		t.Fatalf("got %#v", k)
+	}
+	if k := keys["fx:list"]; !reflect.DeepEqual(k.List, []string{"a", "b", "a"}) {
+		t.Fatalf("got %#v", k)
+	}
+	if k := keys["fx:set"]; !reflect.DeepEqual(k.Set, []string{"go", "redis"}) {
+		t.Fatalf("got %#v", k)
+	}
+	if k := keys["fx:zset"]; !reflect.DeepEqual(k.ZSet, []ZMember{{"one", 1}, {"two", 2.5}}) {
+		t.Fatalf("got %#v", k)
+	}
+	wanted := []StreamEntry{
+		{ID: "1-0", Fields: map[string]string{"type": "login"}},
+		{ID: "2-0", Fields: map[string]string{"type": "logout"}},
+	}
+	if k := keys["fx:stream"]; !reflect.DeepEqual(k.Stream, wanted) {
+		t.Fatalf("got %#v", k)
+	}
+	if k := keys["fx:hash"]; k.TTL != 0 {
+		t.Fatalf("got TTL %s, wanted 0", time.Duration(k.TTL))
+	}
+}
+
+func TestLoadReplacesKeys(t *testing.T) {
+	client := redisClient(t)
+	defer client.Close()
+
+	if err := client.RPush("fx:list", "old").Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Fixture{Keys: []Key{{Key: "fx:list", Type: "list", List: []string{"new"}}}}
+	if err := Load(client, f); err != nil {
+		t.Fatal(err)
+	}
+
+	vals, err := client.LRange("fx:list", 0, -1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(vals, []string{"new"}) {
+		t.Fatalf("got %v, wanted [new]", vals)
+	}
+}
+
+func TestLoadUnsupportedType(t *testing.T) {
+	client := redisClient(t)
+	defer client.Close()
+
+	f := &Fixture{Keys: []Key{{Key: "k", Type: "blob"}}}
+	err := Load(client, f)
+	if err == nil || !strings.Contains(err.Error(), `unsupported type "blob"`) {
+		t.Fatalf("got %v, wanted unsupported type error", err)
+	}
+}
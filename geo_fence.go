@@ -0,0 +1,99 @@
+package redis
+
+// Fence centers are stored in a geo set and radii in meters in a
+// sorted set, so candidates are found with a single GEOSEARCH using the
+// largest radius and then filtered by radius of each fence.
+var geoFenceAddScript = NewScript(`
+redis.call("GEOADD", KEYS[1], ARGV[1], ARGV[2], ARGV[4])
+redis.call("ZADD", KEYS[2], ARGV[3], ARGV[4])
+return 0
+`)
+
+var geoFenceRemoveScript = NewScript(`
+redis.call("ZREM", KEYS[1], ARGV[1])
+return redis.call("ZREM", KEYS[2], ARGV[1])
+`)
+
+var geoFenceWithinScript = NewScript(`
+local max = redis.call("ZREVRANGE", KEYS[2], 0, 0, "WITHSCORES")
+if #max == 0 then
+  return {}
+end
+
+local found = redis.call(
+  "GEOSEARCH", KEYS[1], "FROMLONLAT", ARGV[1], ARGV[2],
+  "BYRADIUS", max[2], "m", "WITHDIST", "ASC")
+local names = {}
+for _, v in ipairs(found) do
+  local radius = redis.call("ZSCORE", KEYS[2], v[1])
+  if radius and tonumber(v[2]) <= tonumber(radius) then
+    table.insert(names, v[1])
+  end
+end
+return names
+`)
+
+// GeoFencesOptions are used to configure geo fences.
+type GeoFencesOptions struct {
+	// HashTag wraps the key in a hash tag unless it already has one,
+	// so fence centers and radii are stored in the same cluster slot.
+	HashTag bool
+}
+
+// GeoFences stores named circular fences and finds fences containing a
+// point. Fence centers are stored in key and radii in "<key>:radius",
+// so in cluster the key must have a hash tag or HashTag option must be
+// set. Requires Redis 6.2.
+type GeoFences struct {
+	client scripter
+	keys   []string
+}
+
+// NewGeoFences returns fences stored in key.
+func NewGeoFences(client scripter, key string, opt *GeoFencesOptions) *GeoFences {
+	if opt == nil {
+		opt = &GeoFencesOptions{}
+	}
+	return &GeoFences{
+		client: client,
+		keys:   helperKeys(key, opt.HashTag, "radius"),
+	}
+}
+
+// Add adds the fence or replaces the fence with the same name. Radius
+// is in meters.
+func (f *GeoFences) Add(name string, longitude, latitude, radius float64) error {
+	args := []string{
+		formatFloat(longitude),
+		formatFloat(latitude),
+		formatFloat(radius),
+		name,
+	}
+	return geoFenceAddScript.Run(f.client, f.keys, args).Err()
+}
+
+// Remove removes the fence. It returns false if the fence does not
+// exist.
+func (f *GeoFences) Remove(name string) (bool, error) {
+	n, err := geoFenceRemoveScript.Run(f.client, f.keys, []string{name}).Result()
+	if err != nil {
+		return false, err
+	}
+	return n.(int64) == 1, nil
+}
+
+// WithinFence returns names of fences containing the point ordered
+// from the nearest fence center.
+func (f *GeoFences) WithinFence(longitude, latitude float64) ([]string, error) {
+	args := []string{formatFloat(longitude), formatFloat(latitude)}
+	v, err := geoFenceWithinScript.Run(f.client, f.keys, args).Result()
+	if err != nil {
+		return nil, err
+	}
+	vals := v.([]interface{})
+	names := make([]string, len(vals))
+	for i, name := range vals {
+		names[i] = name.(string)
+	}
+	return names, nil
+}
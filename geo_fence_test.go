@@ -0,0 +1,60 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("GeoFences", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should find fences containing point", func() {
+		fences := redis.NewGeoFences(client, "fences", nil)
+
+		Expect(fences.Add("palermo", 13.361389, 38.115556, 10000)).NotTo(HaveOccurred())
+		Expect(fences.Add("catania", 15.087269, 37.502669, 60000)).NotTo(HaveOccurred())
+		Expect(fences.Add("sicily", 14.0154, 37.5999, 200000)).NotTo(HaveOccurred())
+
+		names, err := fences.WithinFence(15, 37)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"catania", "sicily"}))
+
+		names, err = fences.WithinFence(13.37, 38.12)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"palermo", "sicily"}))
+
+		names, err = fences.WithinFence(0, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(BeEmpty())
+	})
+
+	It("should remove fences", func() {
+		fences := redis.NewGeoFences(client, "fences", nil)
+		Expect(fences.Add("catania", 15.087269, 37.502669, 60000)).NotTo(HaveOccurred())
+
+		ok, err := fences.Remove("catania")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		ok, err = fences.Remove("catania")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		names, err := fences.WithinFence(15, 37)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(BeEmpty())
+	})
+})
@@ -0,0 +1,17 @@
+package redis
+
+// helperKeys returns key followed by derived keys "<key>:<suffix>"
+// used internally by helpers. When hashTag is set and key has no hash
+// tag, key is wrapped in a hash tag, e.g. "{sem}" and "{sem}:queue",
+// so all keys hash to the same cluster slot.
+func helperKeys(key string, hashTag bool, suffixes ...string) []string {
+	if hashTag && hashKey(key) == key {
+		key = "{" + key + "}"
+	}
+	keys := make([]string, 1+len(suffixes))
+	keys[0] = key
+	for i, suffix := range suffixes {
+		keys[1+i] = key + ":" + suffix
+	}
+	return keys
+}
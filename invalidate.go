@@ -0,0 +1,143 @@
+package redis
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultInvalidationChannel is a channel used by InvalidateKeys and
+// SubscribeInvalidations when channel is empty.
+const DefaultInvalidationChannel = "redis:invalidate"
+
+var invalidateKeysScript = NewScript(`
+local n = redis.call("DEL", unpack(KEYS))
+redis.call("PUBLISH", ARGV[1], cjson.encode(KEYS))
+return n
+`)
+
+// InvalidateKeys deletes keys and publishes their names on the
+// channel so processes keeping local copies of the keys can evict
+// them. Both operations are executed atomically. It returns the
+// number of deleted keys.
+func (c *Client) InvalidateKeys(channel string, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	if channel == "" {
+		channel = DefaultInvalidationChannel
+	}
+	n, err := invalidateKeysScript.Run(c, keys, []string{channel}).Result()
+	if err != nil {
+		return 0, err
+	}
+	return n.(int64), nil
+}
+
+// InvalidationSubscriber receives invalidation messages published by
+// InvalidateKeys.
+type InvalidationSubscriber struct {
+	client  *Client
+	channel string
+	fn      func(keys []string)
+
+	mu     sync.Mutex
+	pubsub *PubSub
+	closed bool
+}
+
+// SubscribeInvalidations subscribes to the channel and calls fn with
+// the names of invalidated keys. Messages published while connection
+// was lost can't be recovered, so fn is called with nil keys after
+// reconnect meaning that all keys must be evicted. fn is called from a
+// single goroutine.
+func (c *Client) SubscribeInvalidations(channel string, fn func(keys []string)) (*InvalidationSubscriber, error) {
+	if channel == "" {
+		channel = DefaultInvalidationChannel
+	}
+	pubsub, err := c.Subscribe(channel)
+	if err != nil {
+		return nil, err
+	}
+	s := &InvalidationSubscriber{
+		client:  c,
+		channel: channel,
+		fn:      fn,
+		pubsub:  pubsub,
+	}
+	go s.run(pubsub)
+	return s, nil
+}
+
+func (s *InvalidationSubscriber) run(pubsub *PubSub) {
+	for {
+		msgi, err := pubsub.Receive()
+		if err != nil {
+			pubsub, err = s.resubscribe(err)
+			if err != nil {
+				return
+			}
+			s.fn(nil)
+			continue
+		}
+
+		msg, ok := msgi.(*Message)
+		if !ok {
+			continue
+		}
+		var keys []string
+		if err := json.Unmarshal([]byte(msg.Payload), &keys); err != nil {
+			log.Printf("redis: can't parse invalidation message %q: %s", msg.Payload, err)
+			continue
+		}
+		s.fn(keys)
+	}
+}
+
+// resubscribe replaces broken subscription with a new one. It returns
+// an error when subscriber is closed.
+func (s *InvalidationSubscriber) resubscribe(cause error) (*PubSub, error) {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return nil, errClosed
+		}
+		s.pubsub.Close()
+		s.mu.Unlock()
+
+		log.Printf("redis: invalidation subscription failed: %s", cause)
+		time.Sleep(time.Second)
+
+		pubsub, err := s.client.Subscribe(s.channel)
+		if err == errClosed {
+			return nil, err
+		}
+		if err != nil {
+			cause = err
+			continue
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			pubsub.Close()
+			return nil, errClosed
+		}
+		s.pubsub = pubsub
+		s.mu.Unlock()
+		return pubsub, nil
+	}
+}
+
+// Close unsubscribes from the channel.
+func (s *InvalidationSubscriber) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errClosed
+	}
+	s.closed = true
+	return s.pubsub.Close()
+}
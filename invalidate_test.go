@@ -0,0 +1,62 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("InvalidateKeys", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should delete keys and notify subscribers", func() {
+		invalidated := make(chan []string, 1)
+		sub, err := client.SubscribeInvalidations("", func(keys []string) {
+			invalidated <- keys
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer sub.Close()
+
+		Expect(client.Set("key1", "hello", 0).Err()).NotTo(HaveOccurred())
+
+		n, err := client.InvalidateKeys("", "key1", "key2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(1)))
+		Expect(client.Exists("key1").Val()).To(BeFalse())
+
+		var keys []string
+		Eventually(invalidated).Should(Receive(&keys))
+		Expect(keys).To(Equal([]string{"key1", "key2"}))
+	})
+
+	It("should use custom channel", func() {
+		pubsub, err := client.Subscribe("mychannel")
+		Expect(err).NotTo(HaveOccurred())
+		defer pubsub.Close()
+
+		_, err = client.InvalidateKeys("mychannel", "key")
+		Expect(err).NotTo(HaveOccurred())
+
+		msgi, err := pubsub.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msgi.(*redis.Subscription).Kind).To(Equal("subscribe"))
+
+		msgi, err = pubsub.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msgi.(*redis.Message).Payload).To(Equal(`["key"]`))
+	})
+})
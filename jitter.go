@@ -0,0 +1,39 @@
+package redis
+
+import (
+	"math/rand"
+	"time"
+)
+
+// TTLWithJitter returns base randomly changed by up to jitter fraction
+// of base in either direction, e.g. TTLWithJitter(time.Hour, 0.1)
+// returns a value between 54 and 66 minutes. Jittered expirations
+// spread expiry of keys written at the same time, so caches are not
+// refilled by a stampede of requests. jitter is clamped to [0, 1].
+func TTLWithJitter(base time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 || base <= 0 {
+		return base
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	delta := (rand.Float64()*2 - 1) * jitter * float64(base)
+	ttl := base + time.Duration(delta)
+	if ttl < time.Millisecond {
+		// Zero expiration means no expiration.
+		ttl = time.Millisecond
+	}
+	return ttl
+}
+
+// SetWithJitter is like Set with expiration TTLWithJitter(base,
+// jitter).
+func (c *commandable) SetWithJitter(key string, value interface{}, base time.Duration, jitter float64) *StatusCmd {
+	return c.Set(key, value, TTLWithJitter(base, jitter))
+}
+
+// ExpireWithJitter is like PExpire with expiration
+// TTLWithJitter(base, jitter).
+func (c *commandable) ExpireWithJitter(key string, base time.Duration, jitter float64) *BoolCmd {
+	return c.PExpire(key, TTLWithJitter(base, jitter))
+}
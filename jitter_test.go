@@ -0,0 +1,48 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("TTLWithJitter", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should jitter TTL within bounds", func() {
+		seen := make(map[time.Duration]bool)
+		for i := 0; i < 100; i++ {
+			ttl := redis.TTLWithJitter(time.Hour, 0.1)
+			Expect(ttl).To(BeNumerically(">=", 54*time.Minute))
+			Expect(ttl).To(BeNumerically("<=", 66*time.Minute))
+			seen[ttl] = true
+		}
+		Expect(len(seen)).To(BeNumerically(">", 1))
+
+		Expect(redis.TTLWithJitter(time.Hour, 0)).To(Equal(time.Hour))
+		Expect(redis.TTLWithJitter(time.Second, 5)).To(BeNumerically(">", 0))
+	})
+
+	It("should set keys with jittered expiration", func() {
+		Expect(client.SetWithJitter("key1", "hello", time.Hour, 0.1).Err()).NotTo(HaveOccurred())
+		Expect(client.TTL("key1").Val()).To(BeNumerically("~", time.Hour, 6*time.Minute))
+
+		Expect(client.Set("key2", "hello", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.ExpireWithJitter("key2", time.Hour, 0.1).Val()).To(BeTrue())
+		Expect(client.TTL("key2").Val()).To(BeNumerically("~", time.Hour, 6*time.Minute))
+	})
+})
@@ -0,0 +1,125 @@
+package redis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// jsonFieldMaxRetries is the number of times UpdateJSONField retries
+// when the key is modified concurrently.
+const jsonFieldMaxRetries = 10
+
+var errJSONPath = errors.New("redis: JSON path does not match document")
+
+// UpdateJSONField atomically updates a single field of a JSON document
+// stored in a plain string key. The path is a dot-separated list of
+// object members and array indexes, e.g. "user.emails.0"; an empty path
+// refers to the whole document. fn receives the current field value
+// (nil when it does not exist) decoded with json.Number for numbers and
+// returns the new value. Missing objects on the path are created and a
+// missing key is treated as an empty object.
+//
+// The key is WATCHed while the document is read and written back, so
+// the update is retried when the key is modified concurrently.
+// TxFailedErr is returned when all retries fail. The key TTL is
+// preserved.
+func (c *Client) UpdateJSONField(
+	key, path string, fn func(value interface{}) (interface{}, error),
+) error {
+	var fields []string
+	if path != "" {
+		fields = strings.Split(path, ".")
+	}
+	for i := 0; i < jsonFieldMaxRetries; i++ {
+		err := c.updateJSONField(key, fields, fn)
+		if err != TxFailedErr {
+			return err
+		}
+	}
+	return TxFailedErr
+}
+
+func (c *Client) updateJSONField(
+	key string, fields []string, fn func(interface{}) (interface{}, error),
+) error {
+	multi := c.Multi()
+	defer multi.Close()
+
+	if err := multi.Watch(key).Err(); err != nil {
+		return err
+	}
+
+	var doc interface{}
+	b, err := multi.Get(key).Bytes()
+	if err == Nil {
+		doc = map[string]interface{}{}
+	} else if err != nil {
+		return err
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return err
+		}
+	}
+
+	doc, err = updateJSONValue(doc, fields, fn)
+	if err != nil {
+		return err
+	}
+	b, err = json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	_, err = multi.Exec(func() error {
+		multi.Process(NewStatusCmd("SET", key, b, "KEEPTTL"))
+		return nil
+	})
+	return err
+}
+
+// updateJSONValue replaces the value found at fields in v with the
+// value returned by fn and returns the updated v.
+func updateJSONValue(
+	v interface{}, fields []string, fn func(interface{}) (interface{}, error),
+) (interface{}, error) {
+	if len(fields) == 0 {
+		return fn(v)
+	}
+
+	field := fields[0]
+	switch v := v.(type) {
+	case nil:
+		m := map[string]interface{}{}
+		val, err := updateJSONValue(nil, fields[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		m[field] = val
+		return m, nil
+	case map[string]interface{}:
+		val, err := updateJSONValue(v[field], fields[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		v[field] = val
+		return v, nil
+	case []interface{}:
+		i, err := strconv.Atoi(field)
+		if err != nil || i < 0 || i >= len(v) {
+			return nil, errJSONPath
+		}
+		val, err := updateJSONValue(v[i], fields[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = val
+		return v, nil
+	default:
+		return nil, errJSONPath
+	}
+}
@@ -0,0 +1,84 @@
+package redis_test
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("UpdateJSONField", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should create missing documents", func() {
+		err := client.UpdateJSONField("doc", "user.name", func(v interface{}) (interface{}, error) {
+			Expect(v).To(BeNil())
+			return "hello", nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Get("doc").Val()).To(Equal(`{"user":{"name":"hello"}}`))
+	})
+
+	It("should update nested fields and keep TTL", func() {
+		err := client.Set("doc", `{"a":[{"n":1}],"b":"x"}`, time.Hour).Err()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = client.UpdateJSONField("doc", "a.0.n", func(v interface{}) (interface{}, error) {
+			n, err := v.(json.Number).Int64()
+			return n + 1, err
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Get("doc").Val()).To(Equal(`{"a":[{"n":2}],"b":"x"}`))
+		Expect(client.TTL("doc").Val()).To(BeNumerically(">", 0))
+	})
+
+	It("should reject paths not matching the document", func() {
+		Expect(client.Set("doc", `{"a":[1],"b":"x"}`, 0).Err()).NotTo(HaveOccurred())
+
+		noop := func(v interface{}) (interface{}, error) { return v, nil }
+		err := client.UpdateJSONField("doc", "a.1", noop)
+		Expect(err).To(MatchError("redis: JSON path does not match document"))
+		err = client.UpdateJSONField("doc", "b.c", noop)
+		Expect(err).To(MatchError("redis: JSON path does not match document"))
+	})
+
+	It("should update concurrently", func() {
+		const n = 5
+
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				err := client.UpdateJSONField("doc", "count", func(v interface{}) (interface{}, error) {
+					if v == nil {
+						return 1, nil
+					}
+					n, err := v.(json.Number).Int64()
+					return n + 1, err
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+
+		Expect(client.Get("doc").Val()).To(Equal(`{"count":5}`))
+	})
+})
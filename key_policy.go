@@ -0,0 +1,201 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KeyRule allows keys matching Patterns to be used by Commands.
+type KeyRule struct {
+	// Lower-cased command names, e.g. "get", or command classes
+	// "@read" and "@write". Rule without commands applies to all
+	// commands.
+	Commands []string
+	// Glob-style patterns as supported by KEYS, e.g. "tenant1:*".
+	Patterns []string
+}
+
+func (r *KeyRule) appliesTo(name string) bool {
+	if len(r.Commands) == 0 {
+		return true
+	}
+	for _, cmd := range r.Commands {
+		switch cmd {
+		case "@read":
+			if isReadOnlyCommand(name) {
+				return true
+			}
+		case "@write":
+			if !isReadOnlyCommand(name) {
+				return true
+			}
+		default:
+			if cmd == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *KeyRule) allows(key string) bool {
+	for _, pattern := range r.Patterns {
+		if matchGlob(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyPolicy validates keys before commands are sent to the server.
+// A key is allowed if it matches a pattern of any rule that applies to
+// the command. Commands that are not covered by any rule are allowed.
+type KeyPolicy struct {
+	Rules []KeyRule
+}
+
+// Check returns an error if cmd uses a key that is not allowed.
+func (p *KeyPolicy) Check(cmd Cmder) error {
+	name := cmd.Name()
+
+	var rules []*KeyRule
+	for i := range p.Rules {
+		if p.Rules[i].appliesTo(name) {
+			rules = append(rules, &p.Rules[i])
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	for _, key := range cmdKeys(cmd) {
+		var allowed bool
+		for _, rule := range rules {
+			if rule.allows(key) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("redis: key %q is not allowed by policy for %s", key, strings.ToUpper(name))
+		}
+	}
+	return nil
+}
+
+func (p *KeyPolicy) checkCmds(cmds []Cmder) error {
+	for _, cmd := range cmds {
+		if err := p.Check(cmd); err != nil {
+			setCmdsErr(cmds, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Commands with all arguments being keys.
+var allKeysCommands = map[string]bool{
+	"del":         true,
+	"exists":      true,
+	"mget":        true,
+	"pfcount":     true,
+	"pfmerge":     true,
+	"rename":      true,
+	"renamenx":    true,
+	"rpoplpush":   true,
+	"sdiff":       true,
+	"sdiffstore":  true,
+	"sinter":      true,
+	"sinterstore": true,
+	"sunion":      true,
+	"sunionstore": true,
+	"touch":       true,
+	"unlink":      true,
+	"watch":       true,
+}
+
+// Commands without keys that have arguments at the key position.
+var noKeysCommands = map[string]bool{
+	"publish": true,
+	"unwatch": true,
+}
+
+// cmdKeys returns keys used by the command. Only the first key is
+// returned for other commands having keys after other arguments.
+func cmdKeys(cmd Cmder) []string {
+	name := cmd.Name()
+	if noKeysCommands[name] {
+		return nil
+	}
+
+	args := cmd.args()
+	switch {
+	case allKeysCommands[name]:
+		return stringArgs(args[1:], 1)
+	case name == "mset" || name == "msetnx":
+		return stringArgs(args[1:], 2)
+	case name == "blpop" || name == "brpop" || name == "brpoplpush":
+		return stringArgs(args[1:len(args)-1], 1)
+	case name == "smove" || name == "zrangestore" || name == "copy" || name == "lcs":
+		return stringArgs(args[1:3], 1)
+	case name == "bitop":
+		return stringArgs(args[2:], 1)
+	case name == "zunionstore" || name == "zinterstore":
+		keys := numKeysArgs(args, 2)
+		return append([]string{fmt.Sprint(args[1])}, keys...)
+	case name == "eval" || name == "evalsha":
+		return numKeysArgs(args, 2)
+	case name == "zdiff" || name == "zunion" || name == "zinter" || name == "zintercard",
+		name == "lmpop" || name == "zmpop":
+		return numKeysArgs(args, 1)
+	case name == "blmpop" || name == "bzmpop":
+		return numKeysArgs(args, 2)
+	case name == "migrate":
+		for i := 6; i < len(args); i++ {
+			s, _ := args[i].(string)
+			switch strings.ToUpper(s) {
+			case "AUTH":
+				i++
+			case "AUTH2":
+				i += 2
+			case "KEYS":
+				return stringArgs(args[i+1:], 1)
+			}
+		}
+	case name == "xread" || name == "xreadgroup":
+		for i, arg := range args {
+			if s, ok := arg.(string); ok && strings.ToUpper(s) == "STREAMS" {
+				streams := args[i+1:]
+				return stringArgs(streams[:len(streams)/2], 1)
+			}
+		}
+		return nil
+	}
+
+	if key := cmd.clusterKey(); key != "" {
+		return []string{key}
+	}
+	return nil
+}
+
+// numKeysArgs returns keys following the number of keys at position
+// pos.
+func numKeysArgs(args []interface{}, pos int) []string {
+	if len(args) <= pos {
+		return nil
+	}
+	n, err := strconv.Atoi(fmt.Sprint(args[pos]))
+	if err != nil || n < 0 || pos+1+n > len(args) {
+		return nil
+	}
+	return stringArgs(args[pos+1:pos+1+n], 1)
+}
+
+func stringArgs(args []interface{}, step int) []string {
+	ss := make([]string, 0, len(args)/step+1)
+	for i := 0; i < len(args); i += step {
+		ss = append(ss, fmt.Sprint(args[i]))
+	}
+	return ss
+}
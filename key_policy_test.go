@@ -0,0 +1,103 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("KeyPolicy", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+			KeyPolicy: &redis.KeyPolicy{
+				Rules: []redis.KeyRule{
+					{Patterns: []string{"tenant1:*"}},
+					{Commands: []string{"@read"}, Patterns: []string{"shared:*"}},
+				},
+			},
+		})
+	})
+
+	AfterEach(func() {
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should allow keys matching patterns", func() {
+		Expect(client.Set("tenant1:key", "hello", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Get("tenant1:key").Val()).To(Equal("hello"))
+		Expect(client.Get("shared:key").Err()).To(Equal(redis.Nil))
+		Expect(client.MGet("tenant1:key", "shared:key").Err()).NotTo(HaveOccurred())
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+	})
+
+	It("should reject keys not matching patterns", func() {
+		err := client.Set("tenant2:key", "hello", 0).Err()
+		Expect(err).To(MatchError(`redis: key "tenant2:key" is not allowed by policy for SET`))
+
+		err = client.Set("shared:key", "hello", 0).Err()
+		Expect(err).To(MatchError(`redis: key "shared:key" is not allowed by policy for SET`))
+
+		err = client.Del("tenant1:key", "tenant2:key").Err()
+		Expect(err).To(MatchError(`redis: key "tenant2:key" is not allowed by policy for DEL`))
+
+		err = client.MSet("tenant1:key", "hello", "tenant2:key", "hello").Err()
+		Expect(err).To(MatchError(`redis: key "tenant2:key" is not allowed by policy for MSET`))
+
+		err = client.MigrateArgs("localhost", "0", 0, 0, redis.MigrateArgs{
+			Keys:     []string{"tenant1:key", "tenant2:key"},
+			Password: "KEYS",
+		}).Err()
+		Expect(err).To(MatchError(`redis: key "tenant2:key" is not allowed by policy for MIGRATE`))
+	})
+
+	It("should check pipelined commands", func() {
+		cmds, err := client.Pipelined(func(pipe *redis.Pipeline) error {
+			pipe.Set("tenant1:key", "hello", 0)
+			pipe.Set("tenant2:key", "hello", 0)
+			return nil
+		})
+		Expect(err).To(MatchError(`redis: key "tenant2:key" is not allowed by policy for SET`))
+		Expect(cmds).To(HaveLen(2))
+		Expect(cmds[0].Err()).To(HaveOccurred())
+
+		Expect(client.Exists("tenant1:key").Val()).To(BeFalse())
+	})
+
+	It("should check transactional commands", func() {
+		multi := client.Multi()
+		defer multi.Close()
+
+		_, err := multi.Exec(func() error {
+			multi.Set("tenant2:key", "hello", 0)
+			return nil
+		})
+		Expect(err).To(MatchError(`redis: key "tenant2:key" is not allowed by policy for SET`))
+	})
+
+	It("should match glob patterns", func() {
+		tests := []struct {
+			pattern, s string
+			match      bool
+		}{
+			{"*", "", true},
+			{"user:*", "user:1", true},
+			{"user:*", "users:1", false},
+			{"h?llo", "hello", true},
+			{"h?llo", "hllo", false},
+			{"h[ae]llo", "hallo", true},
+			{"h[^e]llo", "hello", false},
+			{"h[a-c]llo", "hbllo", true},
+			{`h\*llo`, "h*llo", true},
+			{`h\*llo`, "hello", false},
+			{"*:*:end", "a:b:c:end", true},
+		}
+		for _, test := range tests {
+			Expect(redis.MatchGlob(test.pattern, test.s)).To(Equal(test.match), "%s %s", test.pattern, test.s)
+		}
+	})
+})
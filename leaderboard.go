@@ -0,0 +1,142 @@
+package redis
+
+// TieBreak specifies how leaderboard members with equal scores are
+// ordered.
+type TieBreak int
+
+const (
+	// Members with equal scores are ordered by name in ascending
+	// order, e.g. "alice" ranks above "bob".
+	TieBreakMemberAsc TieBreak = iota
+	// Members with equal scores are ordered by name in descending
+	// order, e.g. "bob" ranks above "alice".
+	TieBreakMemberDesc
+)
+
+// LeaderboardOptions are used to configure a leaderboard.
+type LeaderboardOptions struct {
+	// Ascending ranks members with lower scores first, e.g. for race
+	// times. By default members with higher scores rank first.
+	Ascending bool
+	// Default is TieBreakMemberAsc.
+	TieBreak TieBreak
+}
+
+// LeaderboardEntry is a ranked leaderboard member.
+type LeaderboardEntry struct {
+	Member string
+	Score  float64
+	// 0-based rank, i.e. the first member has rank 0.
+	Rank int64
+}
+
+type leaderboardClient interface {
+	ZAdd(key string, members ...Z) *IntCmd
+	ZRank(key, member string) *IntCmd
+	ZRevRank(key, member string) *IntCmd
+	ZRangeWithScores(key string, start, stop int64) *ZSliceCmd
+	ZRevRangeWithScores(key string, start, stop int64) *ZSliceCmd
+}
+
+// Leaderboard ranks members by score using a sorted set stored in key.
+//
+// Redis orders members with equal scores by name, so depending on
+// options scores are stored negated and ranges are read in reverse to
+// get the requested order. The sorted set should only be modified
+// through the leaderboard.
+type Leaderboard struct {
+	client leaderboardClient
+	key    string
+
+	negate  bool
+	reverse bool
+}
+
+// NewLeaderboard returns a leaderboard stored in key.
+func NewLeaderboard(client leaderboardClient, key string, opt *LeaderboardOptions) *Leaderboard {
+	if opt == nil {
+		opt = &LeaderboardOptions{}
+	}
+	reverse := opt.TieBreak == TieBreakMemberDesc
+	return &Leaderboard{
+		client:  client,
+		key:     key,
+		negate:  !opt.Ascending != reverse,
+		reverse: reverse,
+	}
+}
+
+func (l *Leaderboard) score(score float64) float64 {
+	if l.negate {
+		return -score
+	}
+	return score
+}
+
+// Upsert adds the member or updates its score.
+func (l *Leaderboard) Upsert(member string, score float64) error {
+	return l.client.ZAdd(l.key, Z{Score: l.score(score), Member: member}).Err()
+}
+
+// Rank returns 0-based rank of the member. Nil is returned when the
+// member is not ranked.
+func (l *Leaderboard) Rank(member string) (int64, error) {
+	if l.reverse {
+		return l.client.ZRevRank(l.key, member).Result()
+	}
+	return l.client.ZRank(l.key, member).Result()
+}
+
+// Top returns the first n members.
+func (l *Leaderboard) Top(n int64) ([]LeaderboardEntry, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	return l.rangeByRank(0, n-1)
+}
+
+// Page returns members on the 0-based page of the given size.
+func (l *Leaderboard) Page(page, size int64) ([]LeaderboardEntry, error) {
+	if page < 0 || size <= 0 {
+		return nil, nil
+	}
+	return l.rangeByRank(page*size, (page+1)*size-1)
+}
+
+// Around returns the member together with up to n members ranked above
+// and n members ranked below it. Nil is returned when the member is
+// not ranked.
+func (l *Leaderboard) Around(member string, n int64) ([]LeaderboardEntry, error) {
+	rank, err := l.Rank(member)
+	if err != nil {
+		return nil, err
+	}
+	start := rank - n
+	if start < 0 {
+		start = 0
+	}
+	return l.rangeByRank(start, rank+n)
+}
+
+func (l *Leaderboard) rangeByRank(start, stop int64) ([]LeaderboardEntry, error) {
+	var zz []Z
+	var err error
+	if l.reverse {
+		zz, err = l.client.ZRevRangeWithScores(l.key, start, stop).Result()
+	} else {
+		zz, err = l.client.ZRangeWithScores(l.key, start, stop).Result()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]LeaderboardEntry, len(zz))
+	for i, z := range zz {
+		entries[i] = LeaderboardEntry{
+			Member: z.Member.(string),
+			Score:  l.score(z.Score),
+			Rank:   start + int64(i),
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,136 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("Leaderboard", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	upsert := func(lb *redis.Leaderboard) {
+		Expect(lb.Upsert("alice", 10)).NotTo(HaveOccurred())
+		Expect(lb.Upsert("bob", 20)).NotTo(HaveOccurred())
+		Expect(lb.Upsert("carol", 20)).NotTo(HaveOccurred())
+		Expect(lb.Upsert("dave", 5)).NotTo(HaveOccurred())
+		Expect(lb.Upsert("eve", 30)).NotTo(HaveOccurred())
+	}
+
+	It("should rank higher scores first", func() {
+		lb := redis.NewLeaderboard(client, "lb", nil)
+		upsert(lb)
+
+		top, err := lb.Top(3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(top).To(Equal([]redis.LeaderboardEntry{
+			{Member: "eve", Score: 30, Rank: 0},
+			{Member: "bob", Score: 20, Rank: 1},
+			{Member: "carol", Score: 20, Rank: 2},
+		}))
+
+		rank, err := lb.Rank("dave")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rank).To(Equal(int64(4)))
+	})
+
+	It("should update scores", func() {
+		lb := redis.NewLeaderboard(client, "lb", nil)
+		upsert(lb)
+		Expect(lb.Upsert("dave", 100)).NotTo(HaveOccurred())
+
+		rank, err := lb.Rank("dave")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rank).To(Equal(int64(0)))
+	})
+
+	It("should rank lower scores first", func() {
+		lb := redis.NewLeaderboard(client, "lb", &redis.LeaderboardOptions{
+			Ascending: true,
+		})
+		upsert(lb)
+
+		top, err := lb.Top(4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(top).To(Equal([]redis.LeaderboardEntry{
+			{Member: "dave", Score: 5, Rank: 0},
+			{Member: "alice", Score: 10, Rank: 1},
+			{Member: "bob", Score: 20, Rank: 2},
+			{Member: "carol", Score: 20, Rank: 3},
+		}))
+	})
+
+	It("should break ties by member in descending order", func() {
+		for _, ascending := range []bool{false, true} {
+			Expect(client.Del("lb").Err()).NotTo(HaveOccurred())
+			lb := redis.NewLeaderboard(client, "lb", &redis.LeaderboardOptions{
+				Ascending: ascending,
+				TieBreak:  redis.TieBreakMemberDesc,
+			})
+			upsert(lb)
+
+			bob, err := lb.Rank("bob")
+			Expect(err).NotTo(HaveOccurred())
+			carol, err := lb.Rank("carol")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(carol).To(Equal(bob - 1))
+		}
+	})
+
+	It("should return pages", func() {
+		lb := redis.NewLeaderboard(client, "lb", nil)
+		upsert(lb)
+
+		page, err := lb.Page(1, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page).To(Equal([]redis.LeaderboardEntry{
+			{Member: "carol", Score: 20, Rank: 2},
+			{Member: "alice", Score: 10, Rank: 3},
+		}))
+
+		page, err = lb.Page(2, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page).To(Equal([]redis.LeaderboardEntry{
+			{Member: "dave", Score: 5, Rank: 4},
+		}))
+
+		page, err = lb.Page(3, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page).To(BeEmpty())
+	})
+
+	It("should return members around", func() {
+		lb := redis.NewLeaderboard(client, "lb", nil)
+		upsert(lb)
+
+		around, err := lb.Around("carol", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(around).To(Equal([]redis.LeaderboardEntry{
+			{Member: "bob", Score: 20, Rank: 1},
+			{Member: "carol", Score: 20, Rank: 2},
+			{Member: "alice", Score: 10, Rank: 3},
+		}))
+
+		around, err = lb.Around("eve", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(around).To(Equal([]redis.LeaderboardEntry{
+			{Member: "eve", Score: 30, Rank: 0},
+			{Member: "bob", Score: 20, Rank: 1},
+		}))
+
+		_, err = lb.Around("nobody", 1)
+		Expect(err).To(Equal(redis.Nil))
+	})
+})
@@ -0,0 +1,185 @@
+package redis
+
+import (
+	"strconv"
+	"time"
+)
+
+// Leases are stored in a sorted set scored by expiration time and
+// owners in a hash.
+var leaseAcquireScript = NewScript(`
+redis.replicate_commands()
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local exp = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if exp and tonumber(exp) > now and redis.call("HGET", KEYS[2], ARGV[1]) ~= ARGV[2] then
+  return 0
+end
+redis.call("ZADD", KEYS[1], now + tonumber(ARGV[3]), ARGV[1])
+redis.call("HSET", KEYS[2], ARGV[1], ARGV[2])
+return 1
+`)
+
+var leaseRenewScript = NewScript(`
+redis.replicate_commands()
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local exp = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if not exp or tonumber(exp) <= now or redis.call("HGET", KEYS[2], ARGV[1]) ~= ARGV[2] then
+  return 0
+end
+redis.call("ZADD", KEYS[1], now + tonumber(ARGV[3]), ARGV[1])
+return 1
+`)
+
+var leaseReleaseScript = NewScript(`
+if redis.call("HGET", KEYS[2], ARGV[1]) ~= ARGV[2] then
+  return 0
+end
+redis.call("ZREM", KEYS[1], ARGV[1])
+redis.call("HDEL", KEYS[2], ARGV[1])
+return 1
+`)
+
+var leaseStealScript = NewScript(`
+redis.replicate_commands()
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local prev = redis.call("HGET", KEYS[2], ARGV[1])
+redis.call("ZADD", KEYS[1], now + tonumber(ARGV[3]), ARGV[1])
+redis.call("HSET", KEYS[2], ARGV[1], ARGV[2])
+return prev
+`)
+
+var leaseExpiredScript = NewScript(`
+redis.replicate_commands()
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local vals = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", now, "WITHSCORES")
+local res = {}
+for i = 1, #vals, 2 do
+  local owner = redis.call("HGET", KEYS[2], vals[i]) or ""
+  table.insert(res, vals[i])
+  table.insert(res, owner)
+  table.insert(res, vals[i + 1])
+end
+return res
+`)
+
+// LeaseRegistryOptions are used to configure a lease registry.
+type LeaseRegistryOptions struct {
+	// How long a lease is held without renewal.
+	// Default is 30 seconds.
+	TTL time.Duration
+	// HashTag wraps the key in a hash tag unless it already has one,
+	// so keys of the registry are stored in the same cluster slot.
+	HashTag bool
+}
+
+func (opt *LeaseRegistryOptions) getTTL() time.Duration {
+	if opt.TTL == 0 {
+		return 30 * time.Second
+	}
+	return opt.TTL
+}
+
+// Lease is a resource held by an owner until it expires.
+type Lease struct {
+	Resource string
+	Owner    string
+	ExpireAt time.Time
+}
+
+// LeaseRegistry assigns resources, e.g. partitions, to owners, e.g.
+// workers, using leases that expire unless renewed. Expiration times
+// are stored in a sorted set at the key and owners in "<key>:owners",
+// so in cluster the key must have a hash tag or HashTag option must be
+// set. Time of the Redis server is used for expiration, so owner clocks
+// don't have to be synchronized.
+type LeaseRegistry struct {
+	client scripter
+	keys   []string
+	opt    *LeaseRegistryOptions
+}
+
+// NewLeaseRegistry returns a registry storing leases at key.
+func NewLeaseRegistry(client scripter, key string, opt *LeaseRegistryOptions) *LeaseRegistry {
+	if opt == nil {
+		opt = &LeaseRegistryOptions{}
+	}
+	return &LeaseRegistry{
+		client: client,
+		keys:   helperKeys(key, opt.HashTag, "owners"),
+		opt:    opt,
+	}
+}
+
+func (r *LeaseRegistry) run(script *Script, resource, owner string) (int64, error) {
+	args := []string{resource, owner, formatMs(r.opt.getTTL())}
+	n, err := script.Run(r.client, r.keys, args).Result()
+	if err != nil {
+		return 0, err
+	}
+	return n.(int64), nil
+}
+
+// Acquire acquires the lease of the resource for TTL. It returns false
+// if another owner holds a lease that has not expired. Acquiring a
+// lease held by the same owner renews it.
+func (r *LeaseRegistry) Acquire(resource, owner string) (bool, error) {
+	n, err := r.run(leaseAcquireScript, resource, owner)
+	return n == 1, err
+}
+
+// Renew extends the lease held by the owner for another TTL. It returns
+// false if the lease has expired or is held by another owner.
+func (r *LeaseRegistry) Renew(resource, owner string) (bool, error) {
+	n, err := r.run(leaseRenewScript, resource, owner)
+	return n == 1, err
+}
+
+// Release releases the lease held by the owner. It returns false if the
+// lease is held by another owner.
+func (r *LeaseRegistry) Release(resource, owner string) (bool, error) {
+	n, err := r.run(leaseReleaseScript, resource, owner)
+	return n == 1, err
+}
+
+// Steal acquires the lease of the resource for TTL regardless of the
+// current owner, e.g. to rebalance resources, and returns the previous
+// owner. Empty owner is returned if the resource was not leased.
+func (r *LeaseRegistry) Steal(resource, owner string) (string, error) {
+	args := []string{resource, owner, formatMs(r.opt.getTTL())}
+	prev, err := leaseStealScript.Run(r.client, r.keys, args).Result()
+	if err == Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return prev.(string), nil
+}
+
+// Expired returns leases that expired without being renewed or
+// released, e.g. because owners died. Expired leases are kept until
+// they are acquired, stolen or released.
+func (r *LeaseRegistry) Expired() ([]Lease, error) {
+	v, err := leaseExpiredScript.Run(r.client, r.keys, nil).Result()
+	if err != nil {
+		return nil, err
+	}
+	vals := v.([]interface{})
+	leases := make([]Lease, 0, len(vals)/3)
+	for i := 0; i+2 < len(vals); i += 3 {
+		ms, err := strconv.ParseInt(vals[i+2].(string), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		leases = append(leases, Lease{
+			Resource: vals[i].(string),
+			Owner:    vals[i+1].(string),
+			ExpireAt: time.Unix(0, ms*int64(time.Millisecond)),
+		})
+	}
+	return leases, nil
+}
@@ -0,0 +1,114 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("LeaseRegistry", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should acquire, renew and release leases", func() {
+		leases := redis.NewLeaseRegistry(client, "leases", nil)
+
+		ok, err := leases.Acquire("p1", "w1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		ok, err = leases.Acquire("p1", "w2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		ok, err = leases.Renew("p1", "w1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		ok, err = leases.Renew("p1", "w2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		ok, err = leases.Release("p1", "w2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		ok, err = leases.Release("p1", "w1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		ok, err = leases.Acquire("p1", "w2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should list and take over expired leases", func() {
+		leases := redis.NewLeaseRegistry(client, "leases", &redis.LeaseRegistryOptions{
+			TTL: 50 * time.Millisecond,
+		})
+
+		ok, err := leases.Acquire("p1", "w1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		expired, err := leases.Expired()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expired).To(BeEmpty())
+
+		time.Sleep(100 * time.Millisecond)
+
+		expired, err = leases.Expired()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expired).To(HaveLen(1))
+		Expect(expired[0].Resource).To(Equal("p1"))
+		Expect(expired[0].Owner).To(Equal("w1"))
+		Expect(expired[0].ExpireAt).To(BeTemporally("<", time.Now()))
+
+		ok, err = leases.Renew("p1", "w1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		ok, err = leases.Acquire("p1", "w2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		expired, err = leases.Expired()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expired).To(BeEmpty())
+	})
+
+	It("should steal leases", func() {
+		leases := redis.NewLeaseRegistry(client, "leases", &redis.LeaseRegistryOptions{
+			HashTag: true,
+		})
+
+		prev, err := leases.Steal("p1", "w1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prev).To(Equal(""))
+
+		prev, err = leases.Steal("p1", "w2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prev).To(Equal("w1"))
+
+		ok, err := leases.Renew("p1", "w1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		keys, err := client.Keys("*").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(ConsistOf("{leases}", "{leases}:owners"))
+	})
+})
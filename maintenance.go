@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Client pause modes used by MaintenancePause.
+const (
+	// PauseAll pauses all client commands.
+	PauseAll = "ALL"
+	// PauseWrite pauses only write commands. Requires Redis >= 6.2.
+	PauseWrite = "WRITE"
+)
+
+var errPauseNotDrained = errors.New("redis: in-flight commands were not drained before pause")
+
+// MaintenancePause waits until commands this client has in flight are
+// drained and then pauses clients on the server using CLIENT PAUSE with
+// the given mode (PauseAll or PauseWrite). Commands are drained before
+// the pause, because the server would pause them too and they could
+// never complete. If commands are not drained within dur, clients are
+// not paused and an error is returned.
+func (c *Client) MaintenancePause(dur time.Duration, mode string) error {
+	switch mode {
+	case "", PauseAll:
+		mode = ""
+	case PauseWrite:
+	default:
+		return fmt.Errorf("redis: unsupported pause mode: %q", mode)
+	}
+
+	clock := c.opt.getClock()
+	deadline := clock.Now().Add(dur)
+	for c.inFlight() > 0 {
+		if clock.Now().After(deadline) {
+			return errPauseNotDrained
+		}
+		clock.Sleep(10 * time.Millisecond)
+	}
+	return c.clientPause(dur, mode).Err()
+}
+
+// inFlight returns number of connections that are taken from the pool.
+func (c *Client) inFlight() int {
+	return c.connPool.Len() - c.connPool.FreeLen()
+}
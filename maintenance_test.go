@@ -0,0 +1,67 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("MaintenancePause", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr:         redisAddr,
+			ReadTimeout:  500 * time.Millisecond,
+			WriteTimeout: 500 * time.Millisecond,
+		})
+	})
+
+	AfterEach(func() {
+		Expect(client.ClientUnpause().Err()).NotTo(HaveOccurred())
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should pause writes", func() {
+		err := client.MaintenancePause(time.Second, redis.PauseWrite)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Get("key").Err()).To(Equal(redis.Nil))
+
+		err = client.Set("key", "hello", 0).Err()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should pause all commands", func() {
+		err := client.MaintenancePause(200*time.Millisecond, redis.PauseAll)
+		Expect(err).NotTo(HaveOccurred())
+
+		start := time.Now()
+		Expect(client.Get("key").Err()).To(Equal(redis.Nil))
+		Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+	})
+
+	It("should not pause when commands are not drained", func() {
+		blocked := make(chan error)
+		go func() {
+			blocked <- client.BLPop(time.Second, "list").Err()
+		}()
+		Eventually(func() int {
+			return client.Pool().Len() - client.Pool().FreeLen()
+		}).Should(Equal(1))
+
+		err := client.MaintenancePause(100*time.Millisecond, redis.PauseAll)
+		Expect(err).To(MatchError("redis: in-flight commands were not drained before pause"))
+		Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+		Expect(<-blocked).To(Equal(redis.Nil))
+	})
+
+	It("should reject unknown mode", func() {
+		err := client.MaintenancePause(time.Second, "READ")
+		Expect(err).To(MatchError(`redis: unsupported pause mode: "READ"`))
+	})
+})
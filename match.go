@@ -0,0 +1,127 @@
+package redis
+
+import "errors"
+
+// maxMatchWildcards limits the number of '*' groups in a MATCH pattern.
+// Matching patterns like "a*a*a*...b" is exponential and blocks the
+// server for a long time.
+const maxMatchWildcards = 32
+
+var (
+	errMatchClass     = errors.New("redis: MATCH pattern has unterminated character class")
+	errMatchEscape    = errors.New("redis: MATCH pattern ends with escape")
+	errMatchWildcards = errors.New("redis: MATCH pattern has too many wildcards")
+)
+
+// MatchPattern is a validated glob-style pattern as used by SCAN MATCH
+// and KEYS.
+type MatchPattern struct {
+	pattern  string
+	prefix   string
+	complete bool
+}
+
+// CompileMatch validates the pattern and returns MatchPattern that
+// can be used to match strings client-side.
+func CompileMatch(pattern string) (*MatchPattern, error) {
+	if err := checkMatch(pattern); err != nil {
+		return nil, err
+	}
+	prefix, complete := MatchLiteralPrefix(pattern)
+	return &MatchPattern{
+		pattern:  pattern,
+		prefix:   prefix,
+		complete: complete,
+	}, nil
+}
+
+// MustCompileMatch is like CompileMatch but panics if the pattern is
+// not valid.
+func MustCompileMatch(pattern string) *MatchPattern {
+	m, err := CompileMatch(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (m *MatchPattern) String() string {
+	return m.pattern
+}
+
+// Match reports whether s matches the pattern.
+func (m *MatchPattern) Match(s string) bool {
+	if m.complete {
+		return s == m.prefix
+	}
+	if len(s) < len(m.prefix) || s[:len(m.prefix)] != m.prefix {
+		return false
+	}
+	return matchGlob(m.pattern, s)
+}
+
+// LiteralPrefix returns the literal string that every match starts
+// with. complete is true if the pattern matches only the prefix itself.
+func (m *MatchPattern) LiteralPrefix() (prefix string, complete bool) {
+	return m.prefix, m.complete
+}
+
+// MatchLiteralPrefix returns the literal string that every string
+// matching the pattern starts with. complete is true if the pattern
+// has no wildcards. Patterns without a literal prefix, e.g. "*:user",
+// have to be checked against every key in the keyspace, so it can be
+// used to detect slow SCAN patterns in advance.
+func MatchLiteralPrefix(pattern string) (prefix string, complete bool) {
+	b := make([]byte, 0, len(pattern))
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*', '?', '[':
+			return string(b), false
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				c = pattern[i]
+			}
+			b = append(b, c)
+		default:
+			b = append(b, c)
+		}
+	}
+	return string(b), true
+}
+
+// checkMatch validates the pattern before it is sent to the server,
+// which silently accepts malformed patterns.
+func checkMatch(pattern string) error {
+	var wildcards int
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			if i == 0 || pattern[i-1] != '*' {
+				wildcards++
+			}
+		case '\\':
+			if i+1 == len(pattern) {
+				return errMatchEscape
+			}
+			i++
+		case '[':
+			i++
+			if i < len(pattern) && pattern[i] == '^' {
+				i++
+			}
+			for ; i < len(pattern) && pattern[i] != ']'; i++ {
+				if pattern[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(pattern) {
+				return errMatchClass
+			}
+		}
+	}
+	if wildcards > maxMatchWildcards {
+		return errMatchWildcards
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("MatchPattern", func() {
+
+	It("should detect literal prefix", func() {
+		tests := []struct {
+			pattern  string
+			prefix   string
+			complete bool
+		}{
+			{"", "", true},
+			{"*", "", false},
+			{"user:1", "user:1", true},
+			{"user:*", "user:", false},
+			{"user:?", "user:", false},
+			{"user:[12]", "user:", false},
+			{`user\*:*`, "user*:", false},
+			{"*:user", "", false},
+		}
+		for _, test := range tests {
+			prefix, complete := redis.MatchLiteralPrefix(test.pattern)
+			Expect(prefix).To(Equal(test.prefix), test.pattern)
+			Expect(complete).To(Equal(test.complete), test.pattern)
+		}
+	})
+
+	It("should match compiled patterns", func() {
+		m := redis.MustCompileMatch("user:[0-9]*")
+		Expect(m.String()).To(Equal("user:[0-9]*"))
+		Expect(m.Match("user:1")).To(BeTrue())
+		Expect(m.Match("user:a")).To(BeFalse())
+		Expect(m.Match("users:1")).To(BeFalse())
+
+		m = redis.MustCompileMatch("user:1")
+		Expect(m.Match("user:1")).To(BeTrue())
+		Expect(m.Match("user:10")).To(BeFalse())
+	})
+
+	It("should reject malformed patterns", func() {
+		_, err := redis.CompileMatch("user:[0-9")
+		Expect(err).To(MatchError("redis: MATCH pattern has unterminated character class"))
+
+		_, err = redis.CompileMatch(`user:\`)
+		Expect(err).To(MatchError("redis: MATCH pattern ends with escape"))
+
+		_, err = redis.CompileMatch(`user:[\]]`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = redis.CompileMatch("a**b")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,34 @@
+package redis
+
+import "strings"
+
+// MemoryIssue is an issue reported by MEMORY DOCTOR, e.g. with Title
+// "Peak memory" or "High fragmentation".
+type MemoryIssue struct {
+	Title  string
+	Advice string
+}
+
+// ParseMemoryDoctor returns issues listed in the MEMORY DOCTOR report.
+// Issues are reported as " * <title>: <advice>" lines and no issues
+// are returned when the server found no problems.
+func ParseMemoryDoctor(report string) []MemoryIssue {
+	var issues []MemoryIssue
+	for _, line := range strings.Split(report, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "* ") {
+			continue
+		}
+		line = strings.TrimSpace(line[2:])
+
+		var issue MemoryIssue
+		if i := strings.Index(line, ":"); i > 0 {
+			issue.Title = line[:i]
+			issue.Advice = strings.TrimSpace(line[i+1:])
+		} else {
+			issue.Advice = line
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
@@ -0,0 +1,30 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("ParseMemoryDoctor", func() {
+	It("should parse issues", func() {
+		report := "Sam, I detected a few issues in this Redis instance memory implants:\n\n" +
+			" * Peak memory: In the past this instance used more than 150% the memory that is currently using.\n\n" +
+			" * High allocator fragmentation: This instance has an allocator external fragmentation greater than 1.1.\n\n" +
+			"I'm here to keep you safe, Sam. I want to help you.\n"
+		Expect(redis.ParseMemoryDoctor(report)).To(Equal([]redis.MemoryIssue{{
+			Title:  "Peak memory",
+			Advice: "In the past this instance used more than 150% the memory that is currently using.",
+		}, {
+			Title:  "High allocator fragmentation",
+			Advice: "This instance has an allocator external fragmentation greater than 1.1.",
+		}}))
+	})
+
+	It("should return no issues for healthy report", func() {
+		report := "Hi Sam, I can't find any memory issue in your instance. " +
+			"I can only account for what occurs on this base."
+		Expect(redis.ParseMemoryDoctor(report)).To(BeEmpty())
+	})
+})
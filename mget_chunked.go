@@ -0,0 +1,104 @@
+package redis
+
+import "errors"
+
+var errInvalidChunkSize = errors.New("redis: chunk size must be positive")
+
+// mgetChunkedPipelineSize is the number of MGET commands sent in a
+// single pipeline, so at most that many replies are held in memory.
+const mgetChunkedPipelineSize = 10
+
+type mgetPipeliner interface {
+	MGet(keys ...string) *SliceCmd
+	Exec() ([]Cmder, error)
+	Close() error
+}
+
+// MGetChunked gets values of keys using MGET commands of at most
+// chunkSize keys each, so huge key lists don't produce huge replies.
+// fn is called for every chunk with the keys of the chunk and their
+// values in the same order. Nil value means the key does not exist.
+// Chunks are passed to fn in the order of keys.
+func (c *Client) MGetChunked(keys []string, chunkSize int, fn func(keys []string, vals []interface{}) error) error {
+	if chunkSize <= 0 {
+		return errInvalidChunkSize
+	}
+	newPipe := func() mgetPipeliner {
+		return c.Pipeline()
+	}
+	return mgetChunked(newPipe, splitKeys(keys, chunkSize), fn)
+}
+
+// MGetChunked gets values of keys using MGET commands of at most
+// chunkSize keys each. Keys are grouped by slot, so every chunk is
+// served by a single node and chunks are passed to fn grouped by slot
+// instead of in the order of keys. See Client.MGetChunked.
+func (c *ClusterClient) MGetChunked(keys []string, chunkSize int, fn func(keys []string, vals []interface{}) error) error {
+	if chunkSize <= 0 {
+		return errInvalidChunkSize
+	}
+
+	var slots []int
+	slotKeys := make(map[int][]string)
+	for _, key := range keys {
+		slot := hashSlot(key)
+		if _, ok := slotKeys[slot]; !ok {
+			slots = append(slots, slot)
+		}
+		slotKeys[slot] = append(slotKeys[slot], key)
+	}
+
+	var chunks [][]string
+	for _, slot := range slots {
+		chunks = append(chunks, splitKeys(slotKeys[slot], chunkSize)...)
+	}
+
+	newPipe := func() mgetPipeliner {
+		return c.Pipeline()
+	}
+	return mgetChunked(newPipe, chunks, fn)
+}
+
+func splitKeys(keys []string, size int) [][]string {
+	var chunks [][]string
+	for len(keys) > size {
+		chunks = append(chunks, keys[:size:size])
+		keys = keys[size:]
+	}
+	if len(keys) > 0 {
+		chunks = append(chunks, keys)
+	}
+	return chunks
+}
+
+func mgetChunked(
+	newPipe func() mgetPipeliner,
+	chunks [][]string,
+	fn func(keys []string, vals []interface{}) error,
+) error {
+	for len(chunks) > 0 {
+		n := mgetChunkedPipelineSize
+		if n > len(chunks) {
+			n = len(chunks)
+		}
+
+		pipe := newPipe()
+		cmds := make([]*SliceCmd, n)
+		for i, chunk := range chunks[:n] {
+			cmds[i] = pipe.MGet(chunk...)
+		}
+		_, err := pipe.Exec()
+		pipe.Close()
+		if err != nil {
+			return err
+		}
+
+		for i, chunk := range chunks[:n] {
+			if err := fn(chunk, cmds[i].Val()); err != nil {
+				return err
+			}
+		}
+		chunks = chunks[n:]
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package redis_test
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("MGetChunked", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should get values in chunks", func() {
+		var keys []string
+		for i := 0; i < 25; i++ {
+			key := "key" + strconv.Itoa(i)
+			keys = append(keys, key)
+			if i%5 != 0 {
+				Expect(client.Set(key, i, 0).Err()).NotTo(HaveOccurred())
+			}
+		}
+
+		var gotKeys []string
+		var chunkLens []int
+		err := client.MGetChunked(keys, 2, func(keys []string, vals []interface{}) error {
+			Expect(vals).To(HaveLen(len(keys)))
+			for i, key := range keys {
+				n, _ := strconv.Atoi(key[3:])
+				if n%5 == 0 {
+					Expect(vals[i]).To(BeNil())
+				} else {
+					Expect(vals[i]).To(Equal(strconv.Itoa(n)))
+				}
+			}
+			gotKeys = append(gotKeys, keys...)
+			chunkLens = append(chunkLens, len(keys))
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gotKeys).To(Equal(keys))
+		Expect(chunkLens).To(HaveLen(13))
+		Expect(chunkLens[12]).To(Equal(1))
+	})
+
+	It("should stop on callback error", func() {
+		calls := 0
+		err := client.MGetChunked([]string{"a", "b", "c"}, 1, func(keys []string, vals []interface{}) error {
+			calls++
+			return redis.Nil
+		})
+		Expect(err).To(Equal(redis.Nil))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should reject invalid chunk size", func() {
+		err := client.MGetChunked([]string{"a"}, 0, nil)
+		Expect(err).To(MatchError("redis: chunk size must be positive"))
+	})
+})
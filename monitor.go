@@ -0,0 +1,173 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Monitor streams every command processed by the server as described
+// in http://redis.io/commands/monitor.
+type Monitor struct {
+	*baseClient
+}
+
+// Monitor switches a dedicated connection to the monitor mode.
+func (c *Client) Monitor() (*Monitor, error) {
+	m := &Monitor{
+		baseClient: &baseClient{
+			opt:      c.opt,
+			connPool: newSingleConnPool(c.connPool, false),
+		},
+	}
+
+	cn, err := m.conn()
+	if err != nil {
+		return nil, err
+	}
+	cmd := NewStatusCmd("MONITOR")
+	if err := cn.writeCmds(cmd); err != nil {
+		m.Close()
+		return nil, err
+	}
+	if err := cmd.parseReply(cn.rd); err != nil {
+		m.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// MonitorEvent is a command processed by the server.
+type MonitorEvent struct {
+	Time time.Time
+	DB   int
+	// Address of the client that issued the command, e.g.
+	// "127.0.0.1:60866", "lua" or "unix:/tmp/redis.sock".
+	Addr string
+	Args []string
+}
+
+func (e *MonitorEvent) String() string {
+	return fmt.Sprintf("MonitorEvent<%d %s: %s>", e.DB, e.Addr, strings.Join(e.Args, " "))
+}
+
+// Receive blocks until next command is processed by the server.
+func (m *Monitor) Receive() (*MonitorEvent, error) {
+	return m.ReceiveTimeout(0)
+}
+
+// ReceiveTimeout acts like Receive but returns an error if command
+// is not received in time.
+func (m *Monitor) ReceiveTimeout(timeout time.Duration) (*MonitorEvent, error) {
+	cn, err := m.conn()
+	if err != nil {
+		return nil, err
+	}
+	cn.ReadTimeout = timeout
+
+	cmd := NewStatusCmd()
+	if err := cmd.parseReply(cn.rd); err != nil {
+		return nil, err
+	}
+	return parseMonitorEvent(cmd.Val())
+}
+
+// parseMonitorEvent parses lines like
+// `1339518083.107412 [0 127.0.0.1:60866] "keys" "*"`.
+func parseMonitorEvent(line string) (*MonitorEvent, error) {
+	i := strings.IndexByte(line, ' ')
+	j := strings.IndexByte(line, ']')
+	if i == -1 || j == -1 || j < i || line[i+1] != '[' {
+		return nil, fmt.Errorf("redis: can't parse monitor event: %q", line)
+	}
+
+	sec, err := strconv.ParseFloat(line[:i], 64)
+	if err != nil {
+		return nil, err
+	}
+
+	header := strings.SplitN(line[i+2:j], " ", 2)
+	if len(header) != 2 {
+		return nil, fmt.Errorf("redis: can't parse monitor event: %q", line)
+	}
+	db, err := strconv.Atoi(header[0])
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := splitQuoted(line[j+1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &MonitorEvent{
+		Time: time.Unix(0, int64(sec*float64(time.Second))),
+		DB:   db,
+		Addr: header[1],
+		Args: args,
+	}, nil
+}
+
+var errUnbalancedQuotes = errors.New("redis: unbalanced quotes in monitor event")
+
+// splitQuoted splits space separated strings quoted by Redis with
+// sdscatrepr.
+func splitQuoted(s string) ([]string, error) {
+	var args []string
+	for i := 0; i < len(s); i++ {
+		if s[i] == ' ' {
+			continue
+		}
+		if s[i] != '"' {
+			return nil, errUnbalancedQuotes
+		}
+
+		var b []byte
+		for i++; ; i++ {
+			if i >= len(s) {
+				return nil, errUnbalancedQuotes
+			}
+			c := s[i]
+			if c == '"' {
+				break
+			}
+			if c != '\\' {
+				b = append(b, c)
+				continue
+			}
+
+			i++
+			if i >= len(s) {
+				return nil, errUnbalancedQuotes
+			}
+			switch s[i] {
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case 'a':
+				b = append(b, '\a')
+			case 'b':
+				b = append(b, '\b')
+			case 'x':
+				if i+2 >= len(s) {
+					return nil, errUnbalancedQuotes
+				}
+				n, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+				if err != nil {
+					return nil, err
+				}
+				b = append(b, byte(n))
+				i += 2
+			default:
+				b = append(b, s[i])
+			}
+		}
+		args = append(args, string(b))
+	}
+	return args, nil
+}
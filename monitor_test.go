@@ -0,0 +1,53 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("Monitor", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should receive commands", func() {
+		monitor, err := client.Monitor()
+		Expect(err).NotTo(HaveOccurred())
+		defer monitor.Close()
+
+		Expect(client.Set("key", "hello \"world\"\n", 0).Err()).NotTo(HaveOccurred())
+
+		event, err := monitor.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(event.DB).To(Equal(0))
+		Expect(event.Addr).NotTo(BeEmpty())
+		Expect(event.Args).To(Equal([]string{"SET", "key", "hello \"world\"\n"}))
+		Expect(event.Time).To(BeTemporally("~", time.Now(), time.Second))
+	})
+
+	It("should parse events", func() {
+		event, err := redis.ParseMonitorEvent(
+			`1339518083.107412 [1 lua] "SET" "k\x00" "a\\b" "\t"`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(event.DB).To(Equal(1))
+		Expect(event.Addr).To(Equal("lua"))
+		Expect(event.Args).To(Equal([]string{"SET", "k\x00", `a\b`, "\t"}))
+		Expect(event.Time.Unix()).To(Equal(int64(1339518083)))
+
+		_, err = redis.ParseMonitorEvent(`1339518083.107412 [0 lua] "SET`)
+		Expect(err).To(MatchError("redis: unbalanced quotes in monitor event"))
+	})
+})
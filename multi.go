@@ -15,6 +15,8 @@ type Multi struct {
 
 	base *baseClient
 	cmds []Cmder
+
+	readOnly bool
 }
 
 func (c *Client) Multi() *Multi {
@@ -90,6 +92,22 @@ func (c *Multi) Exec(f func() error) ([]Cmder, error) {
 		return []Cmder{}, nil
 	}
 
+	if policy := c.base.opt.KeyPolicy; policy != nil {
+		if err := policy.checkCmds(cmds[1 : len(cmds)-1]); err != nil {
+			return cmds[1 : len(cmds)-1], err
+		}
+	}
+	if c.base.opt.DisableDestructiveCommands {
+		if err := checkDestructiveCmds(cmds[1 : len(cmds)-1]); err != nil {
+			return cmds[1 : len(cmds)-1], err
+		}
+	}
+	if c.readOnly {
+		if err := checkReadOnlyCmds(cmds[1 : len(cmds)-1]); err != nil {
+			return cmds[1 : len(cmds)-1], err
+		}
+	}
+
 	cn, err := c.base.conn()
 	if err != nil {
 		setCmdsErr(cmds[1:len(cmds)-1], err)
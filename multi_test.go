@@ -46,6 +46,47 @@ var _ = Describe("Multi", func() {
 		Expect(get.Val()).To(Equal("hello"))
 	})
 
+	It("should exec read-only transaction", func() {
+		Expect(client.Set("key1", "hello1", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Set("key2", "hello2", 0).Err()).NotTo(HaveOccurred())
+
+		multi := client.ReadOnlyMulti()
+		defer func() {
+			Expect(multi.Close()).NotTo(HaveOccurred())
+		}()
+
+		var (
+			get  *redis.StringCmd
+			mget *redis.SliceCmd
+		)
+		cmds, err := multi.Exec(func() error {
+			get = multi.Get("key1")
+			mget = multi.MGet("key1", "key2")
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmds).To(HaveLen(2))
+		Expect(get.Val()).To(Equal("hello1"))
+		Expect(mget.Val()).To(Equal([]interface{}{"hello1", "hello2"}))
+	})
+
+	It("should reject writes in read-only transaction", func() {
+		multi := client.ReadOnlyMulti()
+		defer func() {
+			Expect(multi.Close()).NotTo(HaveOccurred())
+		}()
+
+		var get *redis.StringCmd
+		_, err := multi.Exec(func() error {
+			get = multi.Get("key")
+			multi.Set("key", "hello", 0)
+			return nil
+		})
+		Expect(err).To(MatchError("redis: SET is not allowed in read-only transaction"))
+		Expect(get.Err()).To(Equal(err))
+		Expect(client.Exists("key").Val()).To(BeFalse())
+	})
+
 	It("should discard", func() {
 		multi := client.Multi()
 		defer func() {
@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+var errOfflineQueueFull = errors.New("redis: offline queue is full")
+
+type offlineResult struct {
+	cn  *conn
+	err error
+}
+
+type offlineWaiter struct {
+	deadline time.Time
+	ch       chan offlineResult
+}
+
+// offlineQueue buffers commands while connection to the server can't
+// be established. Queued commands get connections in the order they
+// were queued.
+type offlineQueue struct {
+	pool    pool
+	state   *connState
+	clock   Clock
+	timeout time.Duration
+
+	waiters chan *offlineWaiter
+	// Reports whether queued commands are being flushed.
+	flushing uint32
+}
+
+func newOfflineQueue(opt *Options, pool pool, state *connState) *offlineQueue {
+	if opt.OfflineQueueSize <= 0 {
+		return nil
+	}
+	return &offlineQueue{
+		pool:    pool,
+		state:   state,
+		clock:   opt.getClock(),
+		timeout: opt.getOfflineQueueTimeout(),
+		waiters: make(chan *offlineWaiter, opt.OfflineQueueSize),
+	}
+}
+
+// active reports whether there are queued commands. New commands must
+// be queued too to preserve the order.
+func (q *offlineQueue) active() bool {
+	return atomic.LoadUint32(&q.flushing) == 1
+}
+
+// queues reports whether the command that failed to get a connection
+// with err should be queued.
+func (q *offlineQueue) queues(err error) bool {
+	return err != errClosed && err != errPoolTimeout
+}
+
+// conn queues the caller and blocks until a connection is established
+// or the queue timeout is reached.
+func (q *offlineQueue) conn() (*conn, error) {
+	w := &offlineWaiter{
+		deadline: q.clock.Now().Add(q.timeout),
+		ch:       make(chan offlineResult, 1),
+	}
+	select {
+	case q.waiters <- w:
+	default:
+		return nil, errOfflineQueueFull
+	}
+
+	if atomic.CompareAndSwapUint32(&q.flushing, 0, 1) {
+		go q.flush()
+	}
+
+	res := <-w.ch
+	return res.cn, res.err
+}
+
+func (q *offlineQueue) flush() {
+	for {
+		select {
+		case w := <-q.waiters:
+			q.serve(w)
+		default:
+			atomic.StoreUint32(&q.flushing, 0)
+			// Waiter may be queued after the queue was found empty.
+			if len(q.waiters) == 0 || !atomic.CompareAndSwapUint32(&q.flushing, 0, 1) {
+				return
+			}
+		}
+	}
+}
+
+func (q *offlineQueue) serve(w *offlineWaiter) {
+	for {
+		cn, err := q.pool.Get()
+		if err == nil || err == errClosed {
+			w.ch <- offlineResult{cn: cn, err: err}
+			return
+		}
+
+		q.state.disconnected(err)
+		timer := time.NewTimer(w.deadline.Sub(q.clock.Now()))
+		select {
+		case <-q.state.reconnected():
+			timer.Stop()
+		case <-timer.C:
+			err = fmt.Errorf("redis: offline queue timeout (last error: %v)", err)
+			w.ch <- offlineResult{err: err}
+			return
+		}
+	}
+}
@@ -3,8 +3,10 @@ package redis
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"net"
 	"strconv"
+	"time"
 
 	"gopkg.in/bufio.v1"
 )
@@ -27,6 +29,16 @@ type binaryUnmarshaler interface {
 	UnmarshalBinary(data []byte) error
 }
 
+// Copy of encoding.TextMarshaler.
+type textMarshaler interface {
+	MarshalText() (text []byte, err error)
+}
+
+// Copy of encoding.TextUnmarshaler.
+type textUnmarshaler interface {
+	UnmarshalText(text []byte) error
+}
+
 func appendString(b []byte, s string) []byte {
 	b = append(b, '$')
 	b = strconv.AppendUint(b, uint64(len(s)), 10)
@@ -45,7 +57,7 @@ func appendBytes(b, bb []byte) []byte {
 	return b
 }
 
-func appendArg(b []byte, val interface{}) ([]byte, error) {
+func appendValue(b []byte, val interface{}, enc *Encoding) ([]byte, error) {
 	switch v := val.(type) {
 	case nil:
 		b = appendString(b, "")
@@ -83,6 +95,12 @@ func appendArg(b []byte, val interface{}) ([]byte, error) {
 		} else {
 			b = appendString(b, "0")
 		}
+	case time.Time:
+		return appendTime(b, v, enc)
+	case time.Duration:
+		b = appendDuration(b, v, enc)
+	case *big.Int:
+		return appendBigInt(b, v)
 	default:
 		if bm, ok := val.(binaryMarshaler); ok {
 			bb, err := bm.MarshalBinary()
@@ -90,6 +108,14 @@ func appendArg(b []byte, val interface{}) ([]byte, error) {
 				return nil, err
 			}
 			b = appendBytes(b, bb)
+		} else if tm, ok := val.(textMarshaler); ok {
+			bb, err := tm.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			b = appendBytes(b, bb)
+		} else if enc.Gob {
+			return appendGob(b, val)
 		} else {
 			err := fmt.Errorf(
 				"redis: can't marshal %T (consider implementing BinaryMarshaler)", val)
@@ -99,21 +125,7 @@ func appendArg(b []byte, val interface{}) ([]byte, error) {
 	return b, nil
 }
 
-func appendArgs(b []byte, args []interface{}) ([]byte, error) {
-	b = append(b, '*')
-	b = strconv.AppendUint(b, uint64(len(args)), 10)
-	b = append(b, '\r', '\n')
-	for _, arg := range args {
-		var err error
-		b, err = appendArg(b, arg)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return b, nil
-}
-
-func scan(b []byte, val interface{}) error {
+func scanValue(b []byte, val interface{}, enc *Encoding) error {
 	switch v := val.(type) {
 	case nil:
 		return errorf("redis: Scan(nil)")
@@ -204,10 +216,22 @@ func scan(b []byte, val interface{}) error {
 	case *bool:
 		*v = len(b) == 1 && b[0] == '1'
 		return nil
+	case *time.Time:
+		return scanTime(b, v, enc)
+	case *time.Duration:
+		return scanDuration(b, v, enc)
+	case *big.Int:
+		return scanBigInt(b, v)
 	default:
 		if bu, ok := val.(binaryUnmarshaler); ok {
 			return bu.UnmarshalBinary(b)
 		}
+		if tu, ok := val.(textUnmarshaler); ok {
+			return tu.UnmarshalText(b)
+		}
+		if enc.Gob {
+			return scanGob(b, val)
+		}
 		err := fmt.Errorf(
 			"redis: can't unmarshal %T (consider implementing BinaryUnmarshaler)", val)
 		return err
@@ -376,6 +400,28 @@ func parseStringSlice(rd *bufio.Reader, n int64) (interface{}, error) {
 	return vals, nil
 }
 
+// parseIntSlice parses array of integers. Nil elements are parsed
+// as 0.
+func parseIntSlice(rd *bufio.Reader, n int64) (interface{}, error) {
+	vals := make([]int64, 0, n)
+	for i := int64(0); i < n; i++ {
+		viface, err := parseReply(rd, nil)
+		if err == Nil {
+			vals = append(vals, 0)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		v, ok := viface.(int64)
+		if !ok {
+			return nil, fmt.Errorf("got %T, expected int64", viface)
+		}
+		vals = append(vals, v)
+	}
+	return vals, nil
+}
+
 func parseBoolSlice(rd *bufio.Reader, n int64) (interface{}, error) {
 	vals := make([]bool, 0, n)
 	for i := int64(0); i < n; i++ {
@@ -449,6 +495,27 @@ func parseStringIntMap(rd *bufio.Reader, n int64) (interface{}, error) {
 	return m, nil
 }
 
+func parseZWithKey(rd *bufio.Reader, n int64) (interface{}, error) {
+	if n != 3 {
+		return nil, fmt.Errorf("got %d elements, expected 3", n)
+	}
+	var z ZWithKey
+	var err error
+	z.Key, err = parseStringReply(rd)
+	if err != nil {
+		return nil, err
+	}
+	z.Member, err = parseStringReply(rd)
+	if err != nil {
+		return nil, err
+	}
+	z.Score, err = parseFloatReply(rd)
+	if err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
 func parseZSlice(rd *bufio.Reader, n int64) (interface{}, error) {
 	zz := make([]Z, n/2)
 	for i := int64(0); i < n; i += 2 {
@@ -527,3 +594,717 @@ func parseClusterSlotInfoSlice(rd *bufio.Reader, n int64) (interface{}, error) {
 	}
 	return infos, nil
 }
+
+func newGeoLocationParser(q *GeoRadiusQuery) multiBulkParser {
+	return func(rd *bufio.Reader, n int64) (interface{}, error) {
+		var loc GeoLocation
+
+		name, err := parseReply(rd, nil)
+		if err != nil {
+			return nil, err
+		}
+		loc.Name = string(name.([]byte))
+
+		if q.WithDist {
+			loc.Dist, err = parseFloatReply(rd)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if q.WithGeoHash {
+			v, err := parseReply(rd, nil)
+			if err != nil {
+				return nil, err
+			}
+			hash, ok := v.(int64)
+			if !ok {
+				return nil, fmt.Errorf("got %T, expected int64", v)
+			}
+			loc.GeoHash = hash
+		}
+		if q.WithCoord {
+			v, err := parseReply(rd, parseGeoPos)
+			if err != nil {
+				return nil, err
+			}
+			pos := v.(*GeoPos)
+			loc.Longitude = pos.Longitude
+			loc.Latitude = pos.Latitude
+		}
+
+		return &loc, nil
+	}
+}
+
+func newGeoLocationSliceParser(q *GeoRadiusQuery) multiBulkParser {
+	return func(rd *bufio.Reader, n int64) (interface{}, error) {
+		locs := make([]GeoLocation, 0, n)
+		for i := int64(0); i < n; i++ {
+			v, err := parseReply(rd, newGeoLocationParser(q))
+			if err != nil {
+				return nil, err
+			}
+			switch vv := v.(type) {
+			case []byte:
+				locs = append(locs, GeoLocation{Name: string(vv)})
+			case *GeoLocation:
+				locs = append(locs, *vv)
+			default:
+				return nil, fmt.Errorf("got %T, expected string or *GeoLocation", v)
+			}
+		}
+		return locs, nil
+	}
+}
+
+func parseGeoPos(rd *bufio.Reader, n int64) (interface{}, error) {
+	if n != 2 {
+		return nil, fmt.Errorf("got %d elements, expected 2", n)
+	}
+	lng, err := parseFloatReply(rd)
+	if err != nil {
+		return nil, err
+	}
+	lat, err := parseFloatReply(rd)
+	if err != nil {
+		return nil, err
+	}
+	return &GeoPos{Longitude: lng, Latitude: lat}, nil
+}
+
+func parseGeoPosSlice(rd *bufio.Reader, n int64) (interface{}, error) {
+	positions := make([]*GeoPos, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := parseReply(rd, parseGeoPos)
+		if err == Nil {
+			positions = append(positions, nil)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, v.(*GeoPos))
+	}
+	return positions, nil
+}
+
+func parseFloatReply(rd *bufio.Reader) (float64, error) {
+	v, err := parseReply(rd, nil)
+	if err != nil {
+		return 0, err
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		return 0, fmt.Errorf("got %T, expected string", v)
+	}
+	return strconv.ParseFloat(bytesToString(b), 64)
+}
+
+func parseXMessageSlice(rd *bufio.Reader, n int64) (interface{}, error) {
+	msgs := make([]XMessage, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := parseReply(rd, parseXMessage)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, v.(XMessage))
+	}
+	return msgs, nil
+}
+
+func parseXMessage(rd *bufio.Reader, n int64) (interface{}, error) {
+	if n != 2 {
+		return nil, fmt.Errorf("got %d elements, expected 2", n)
+	}
+
+	v, err := parseReply(rd, nil)
+	if err != nil {
+		return nil, err
+	}
+	id, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("got %T, expected string", v)
+	}
+
+	// Values of deleted messages are nil, e.g. in XREADGROUP replies.
+	v, err = parseReply(rd, parseXValues)
+	if err != nil && err != Nil {
+		return nil, err
+	}
+	values, _ := v.(map[string]interface{})
+
+	return XMessage{ID: string(id), Values: values}, nil
+}
+
+func parseXValues(rd *bufio.Reader, n int64) (interface{}, error) {
+	values := make(map[string]interface{}, n/2)
+	for i := int64(0); i < n; i += 2 {
+		key, err := parseReply(rd, nil)
+		if err != nil {
+			return nil, err
+		}
+		keyb, ok := key.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("got %T, expected string", key)
+		}
+
+		value, err := parseReply(rd, nil)
+		if err != nil {
+			return nil, err
+		}
+		valueb, ok := value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("got %T, expected string", value)
+		}
+
+		values[string(keyb)] = string(valueb)
+	}
+	return values, nil
+}
+
+func parseXStreamSlice(rd *bufio.Reader, n int64) (interface{}, error) {
+	streams := make([]XStream, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := parseReply(rd, parseXStream)
+		if err != nil {
+			return nil, err
+		}
+		streams = append(streams, v.(XStream))
+	}
+	return streams, nil
+}
+
+func parseXStream(rd *bufio.Reader, n int64) (interface{}, error) {
+	if n != 2 {
+		return nil, fmt.Errorf("got %d elements, expected 2", n)
+	}
+
+	v, err := parseReply(rd, nil)
+	if err != nil {
+		return nil, err
+	}
+	stream, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("got %T, expected string", v)
+	}
+
+	v, err = parseReply(rd, parseXMessageSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	return XStream{Stream: string(stream), Messages: v.([]XMessage)}, nil
+}
+
+func parseXPending(rd *bufio.Reader, n int64) (interface{}, error) {
+	if n != 4 {
+		return nil, fmt.Errorf("got %d elements, expected 4", n)
+	}
+
+	count, err := parseIntReply(rd)
+	if err != nil {
+		return nil, err
+	}
+	pending := &XPending{Count: count}
+
+	// Lower and higher IDs and consumers are nil when there are no
+	// pending messages.
+	lower, err := parseReply(rd, nil)
+	if err != nil && err != Nil {
+		return nil, err
+	}
+	higher, err := parseReply(rd, nil)
+	if err != nil && err != Nil {
+		return nil, err
+	}
+	if b, ok := lower.([]byte); ok {
+		pending.Lower = string(b)
+	}
+	if b, ok := higher.([]byte); ok {
+		pending.Higher = string(b)
+	}
+
+	v, err := parseReply(rd, parseXPendingConsumers)
+	if err != nil && err != Nil {
+		return nil, err
+	}
+	pending.Consumers, _ = v.(map[string]int64)
+
+	return pending, nil
+}
+
+func parseXPendingConsumers(rd *bufio.Reader, n int64) (interface{}, error) {
+	consumers := make(map[string]int64, n)
+	for i := int64(0); i < n; i++ {
+		v, err := parseReply(rd, parseStringSlice)
+		if err != nil {
+			return nil, err
+		}
+		pair := v.([]string)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("got %d elements, expected 2", len(pair))
+		}
+		count, err := strconv.ParseInt(pair[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		consumers[pair[0]] = count
+	}
+	return consumers, nil
+}
+
+func parseXPendingExtSlice(rd *bufio.Reader, n int64) (interface{}, error) {
+	pending := make([]XPendingExt, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := parseReply(rd, parseXPendingExt)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, v.(XPendingExt))
+	}
+	return pending, nil
+}
+
+func parseXPendingExt(rd *bufio.Reader, n int64) (interface{}, error) {
+	if n != 4 {
+		return nil, fmt.Errorf("got %d elements, expected 4", n)
+	}
+
+	id, err := parseStringReply(rd)
+	if err != nil {
+		return nil, err
+	}
+	consumer, err := parseStringReply(rd)
+	if err != nil {
+		return nil, err
+	}
+	idle, err := parseIntReply(rd)
+	if err != nil {
+		return nil, err
+	}
+	retryCount, err := parseIntReply(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	return XPendingExt{
+		ID:         id,
+		Consumer:   consumer,
+		Idle:       time.Duration(idle) * time.Millisecond,
+		RetryCount: retryCount,
+	}, nil
+}
+
+func parseIntReply(rd *bufio.Reader) (int64, error) {
+	v, err := parseReply(rd, nil)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := v.(int64)
+	if !ok {
+		return 0, fmt.Errorf("got %T, expected int64", v)
+	}
+	return n, nil
+}
+
+func parseStringReply(rd *bufio.Reader) (string, error) {
+	v, err := parseReply(rd, nil)
+	if err != nil {
+		return "", err
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		return "", fmt.Errorf("got %T, expected string", v)
+	}
+	return string(b), nil
+}
+
+type xAutoClaimReply struct {
+	start string
+	val   interface{}
+}
+
+func newXAutoClaimParser(p multiBulkParser) multiBulkParser {
+	return func(rd *bufio.Reader, n int64) (interface{}, error) {
+		// Redis 7 also returns IDs of deleted messages.
+		if n != 2 && n != 3 {
+			return nil, fmt.Errorf("got %d elements, expected 2 or 3", n)
+		}
+
+		start, err := parseStringReply(rd)
+		if err != nil {
+			return nil, err
+		}
+		val, err := parseReply(rd, p)
+		if err != nil {
+			return nil, err
+		}
+		if n == 3 {
+			if _, err := parseReply(rd, parseSlice); err != nil {
+				return nil, err
+			}
+		}
+		return &xAutoClaimReply{start: start, val: val}, nil
+	}
+}
+
+type keyValuesReply struct {
+	key string
+	val interface{}
+}
+
+// newKeyValuesParser returns a parser of LMPOP and ZMPOP replies
+// consisting of the key and values parsed by p.
+func newKeyValuesParser(p multiBulkParser) multiBulkParser {
+	return func(rd *bufio.Reader, n int64) (interface{}, error) {
+		if n != 2 {
+			return nil, fmt.Errorf("got %d elements, expected 2", n)
+		}
+
+		key, err := parseStringReply(rd)
+		if err != nil {
+			return nil, err
+		}
+		val, err := parseReply(rd, p)
+		if err != nil {
+			return nil, err
+		}
+		return &keyValuesReply{key: key, val: val}, nil
+	}
+}
+
+// parseZPairSlice parses members with scores returned as [member,
+// score] pairs.
+func parseZPairSlice(rd *bufio.Reader, n int64) (interface{}, error) {
+	zz := make([]Z, n)
+	for i := int64(0); i < n; i++ {
+		v, err := parseReply(rd, parseZSlice)
+		if err != nil {
+			return nil, err
+		}
+		pair := v.([]Z)
+		if len(pair) != 1 {
+			return nil, fmt.Errorf("got %d members, expected 1", len(pair))
+		}
+		zz[i] = pair[0]
+	}
+	return zz, nil
+}
+
+// parseXInfoMap parses XINFO reply consisting of field names followed
+// by values. Values of fields not handled by fn are skipped.
+func parseXInfoMap(
+	rd *bufio.Reader, n int64, fn func(field string) (handled bool, err error),
+) error {
+	for i := int64(0); i < n; i += 2 {
+		field, err := parseStringReply(rd)
+		if err != nil {
+			return err
+		}
+		handled, err := fn(field)
+		if err != nil {
+			return err
+		}
+		if !handled {
+			if _, err := parseReply(rd, parseSlice); err != nil && err != Nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseXInfoEntry(rd *bufio.Reader) (*XMessage, error) {
+	v, err := parseReply(rd, parseXMessage)
+	if err == Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	msg := v.(XMessage)
+	return &msg, nil
+}
+
+func parseXInfoStream(rd *bufio.Reader, n int64) (interface{}, error) {
+	info := &XInfoStream{}
+	err := parseXInfoMap(rd, n, func(field string) (bool, error) {
+		var err error
+		switch field {
+		case "length":
+			info.Length, err = parseIntReply(rd)
+		case "radix-tree-keys":
+			info.RadixTreeKeys, err = parseIntReply(rd)
+		case "radix-tree-nodes":
+			info.RadixTreeNodes, err = parseIntReply(rd)
+		case "groups":
+			info.Groups, err = parseIntReply(rd)
+		case "last-generated-id":
+			info.LastGeneratedID, err = parseStringReply(rd)
+		case "first-entry":
+			info.FirstEntry, err = parseXInfoEntry(rd)
+		case "last-entry":
+			info.LastEntry, err = parseXInfoEntry(rd)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func parseXInfoGroups(rd *bufio.Reader, n int64) (interface{}, error) {
+	groups := make([]XInfoGroup, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := parseReply(rd, parseXInfoGroup)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, v.(XInfoGroup))
+	}
+	return groups, nil
+}
+
+func parseXInfoGroup(rd *bufio.Reader, n int64) (interface{}, error) {
+	var group XInfoGroup
+	err := parseXInfoMap(rd, n, func(field string) (bool, error) {
+		var err error
+		switch field {
+		case "name":
+			group.Name, err = parseStringReply(rd)
+		case "consumers":
+			group.Consumers, err = parseIntReply(rd)
+		case "pending":
+			group.Pending, err = parseIntReply(rd)
+		case "last-delivered-id":
+			group.LastDeliveredID, err = parseStringReply(rd)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func parseXInfoConsumers(rd *bufio.Reader, n int64) (interface{}, error) {
+	consumers := make([]XInfoConsumer, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := parseReply(rd, parseXInfoConsumer)
+		if err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, v.(XInfoConsumer))
+	}
+	return consumers, nil
+}
+
+func parseXInfoConsumer(rd *bufio.Reader, n int64) (interface{}, error) {
+	var consumer XInfoConsumer
+	err := parseXInfoMap(rd, n, func(field string) (bool, error) {
+		var err error
+		switch field {
+		case "name":
+			consumer.Name, err = parseStringReply(rd)
+		case "pending":
+			consumer.Pending, err = parseIntReply(rd)
+		case "idle":
+			var idle int64
+			idle, err = parseIntReply(rd)
+			consumer.Idle = time.Duration(idle) * time.Millisecond
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return consumer, nil
+}
+
+func parseClientTrackingInfo(rd *bufio.Reader, n int64) (interface{}, error) {
+	info := &ClientTrackingInfo{}
+	err := parseXInfoMap(rd, n, func(field string) (bool, error) {
+		var v interface{}
+		var err error
+		switch field {
+		case "flags":
+			v, err = parseReply(rd, parseStringSlice)
+			if err == nil {
+				info.Flags = v.([]string)
+			}
+		case "redirect":
+			info.Redirect, err = parseIntReply(rd)
+		case "prefixes":
+			v, err = parseReply(rd, parseStringSlice)
+			if err == nil {
+				info.Prefixes = v.([]string)
+			}
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func parseLCSIdx(rd *bufio.Reader, n int64) (interface{}, error) {
+	match := &LCSMatch{}
+	err := parseXInfoMap(rd, n, func(field string) (bool, error) {
+		var err error
+		switch field {
+		case "matches":
+			var v interface{}
+			v, err = parseReply(rd, parseLCSMatches)
+			if err == nil {
+				match.Matches = v.([]LCSMatchedPosition)
+			}
+		case "len":
+			match.Len, err = parseIntReply(rd)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+func parseLCSMatches(rd *bufio.Reader, n int64) (interface{}, error) {
+	matches := make([]LCSMatchedPosition, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := parseReply(rd, parseLCSMatchedPosition)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, v.(LCSMatchedPosition))
+	}
+	return matches, nil
+}
+
+func parseLCSMatchedPosition(rd *bufio.Reader, n int64) (interface{}, error) {
+	if n != 2 && n != 3 {
+		return nil, fmt.Errorf("got %d elements, expected 2 or 3", n)
+	}
+	var pos LCSMatchedPosition
+	for _, p := range []*LCSPosition{&pos.Key1, &pos.Key2} {
+		v, err := parseReply(rd, parseIntSlice)
+		if err != nil {
+			return nil, err
+		}
+		ints := v.([]int64)
+		if len(ints) != 2 {
+			return nil, fmt.Errorf("got %d elements, expected 2", len(ints))
+		}
+		p.Start, p.End = ints[0], ints[1]
+	}
+	if n == 3 {
+		var err error
+		pos.MatchLen, err = parseIntReply(rd)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pos, nil
+}
+
+func parseMemoryStats(rd *bufio.Reader, n int64) (interface{}, error) {
+	stats := &MemoryStats{DBs: make(map[int64]MemoryStatsDB)}
+	ints := map[string]*int64{
+		"peak.allocated":      &stats.PeakAllocated,
+		"total.allocated":     &stats.TotalAllocated,
+		"startup.allocated":   &stats.StartupAllocated,
+		"replication.backlog": &stats.ReplicationBacklog,
+		"clients.slaves":      &stats.ClientsReplicas,
+		"clients.normal":      &stats.ClientsNormal,
+		"aof.buffer":          &stats.AOFBuffer,
+		"lua.caches":          &stats.LuaCaches,
+		"overhead.total":      &stats.OverheadTotal,
+		"keys.count":          &stats.KeysCount,
+		"keys.bytes-per-key":  &stats.KeysBytesPerKey,
+		"dataset.bytes":       &stats.DatasetBytes,
+		"fragmentation.bytes": &stats.FragmentationBytes,
+	}
+	floats := map[string]*float64{
+		"dataset.percentage": &stats.DatasetPercentage,
+		"peak.percentage":    &stats.PeakPercentage,
+		"fragmentation":      &stats.Fragmentation,
+	}
+
+	err := parseXInfoMap(rd, n, func(field string) (bool, error) {
+		if p, ok := ints[field]; ok {
+			v, err := parseNumberReply(rd)
+			*p = int64(v)
+			return true, err
+		}
+		if p, ok := floats[field]; ok {
+			v, err := parseNumberReply(rd)
+			*p = v
+			return true, err
+		}
+		if len(field) > 3 && field[:3] == "db." {
+			db, err := strconv.ParseInt(field[3:], 10, 64)
+			if err != nil {
+				return false, nil
+			}
+			v, err := parseReply(rd, parseMemoryStatsDB)
+			if err != nil {
+				return true, err
+			}
+			stats.DBs[db] = v.(MemoryStatsDB)
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func parseMemoryStatsDB(rd *bufio.Reader, n int64) (interface{}, error) {
+	var db MemoryStatsDB
+	err := parseXInfoMap(rd, n, func(field string) (bool, error) {
+		var err error
+		switch field {
+		case "overhead.hashtable.main":
+			db.OverheadHashtableMain, err = parseIntReply(rd)
+		case "overhead.hashtable.expires":
+			db.OverheadHashtableExpires, err = parseIntReply(rd)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// parseNumberReply parses an integer reply or a float formatted as a
+// bulk string.
+func parseNumberReply(rd *bufio.Reader) (float64, error) {
+	v, err := parseReply(rd, nil)
+	if err != nil {
+		return 0, err
+	}
+	switch v := v.(type) {
+	case int64:
+		return float64(v), nil
+	case []byte:
+		return strconv.ParseFloat(bytesToString(v), 64)
+	}
+	return 0, fmt.Errorf("got %T, expected number", v)
+}
@@ -48,7 +48,8 @@ func benchmarkParseReply(b *testing.B, reply string, p multiBulkParser, wanterr
 func BenchmarkAppendArgs(b *testing.B) {
 	buf := make([]byte, 0, 64)
 	args := []interface{}{"hello", "world", "foo", "bar"}
+	c := &codec{}
 	for i := 0; i < b.N; i++ {
-		appendArgs(buf, args)
+		c.appendArgs(buf, args)
 	}
 }
@@ -11,6 +11,9 @@ type Pipeline struct {
 
 	cmds   []Cmder
 	closed bool
+
+	flushEvery int
+	flushErr   error
 }
 
 func (c *Client) Pipeline() *Pipeline {
@@ -34,6 +37,30 @@ func (c *Client) Pipelined(fn func(*Pipeline) error) ([]Cmder, error) {
 
 func (pipe *Pipeline) process(cmd Cmder) {
 	pipe.cmds = append(pipe.cmds, cmd)
+	if pipe.flushEvery > 0 && len(pipe.cmds) >= pipe.flushEvery {
+		if _, err := pipe.exec(); err != nil && pipe.flushErr == nil {
+			pipe.flushErr = err
+		}
+	}
+}
+
+// FlushEvery makes the pipeline execute queued commands as soon as n
+// commands are queued, which limits memory used by long batches.
+// Results of flushed commands are available through the commands
+// themselves and the first error is returned by the next Exec. Zero n
+// disables auto-flush.
+func (pipe *Pipeline) FlushEvery(n int) {
+	pipe.flushEvery = n
+}
+
+// Len returns the number of queued commands.
+func (pipe *Pipeline) Len() int {
+	return len(pipe.cmds)
+}
+
+// Cmds returns queued commands.
+func (pipe *Pipeline) Cmds() []Cmder {
+	return pipe.cmds
 }
 
 func (pipe *Pipeline) Close() error {
@@ -48,15 +75,26 @@ func (pipe *Pipeline) Discard() error {
 		return errClosed
 	}
 	pipe.cmds = pipe.cmds[:0]
+	pipe.flushErr = nil
 	return nil
 }
 
 // Exec always returns list of commands and error of the first failed
-// command if any.
-func (pipe *Pipeline) Exec() (cmds []Cmder, retErr error) {
+// command if any. With FlushEvery only commands queued since the last
+// flush are returned.
+func (pipe *Pipeline) Exec() ([]Cmder, error) {
 	if pipe.closed {
 		return nil, errClosed
 	}
+	cmds, err := pipe.exec()
+	if pipe.flushErr != nil {
+		err = pipe.flushErr
+		pipe.flushErr = nil
+	}
+	return cmds, err
+}
+
+func (pipe *Pipeline) exec() (cmds []Cmder, retErr error) {
 	if len(pipe.cmds) == 0 {
 		return pipe.cmds, nil
 	}
@@ -64,6 +102,17 @@ func (pipe *Pipeline) Exec() (cmds []Cmder, retErr error) {
 	cmds = pipe.cmds
 	pipe.cmds = make([]Cmder, 0, 10)
 
+	if policy := pipe.client.opt.KeyPolicy; policy != nil {
+		if err := policy.checkCmds(cmds); err != nil {
+			return cmds, err
+		}
+	}
+	if pipe.client.opt.DisableDestructiveCommands {
+		if err := checkDestructiveCmds(cmds); err != nil {
+			return cmds, err
+		}
+	}
+
 	failedCmds := cmds
 	for i := 0; i <= pipe.client.opt.MaxRetries; i++ {
 		cn, err := pipe.client.conn()
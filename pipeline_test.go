@@ -64,6 +64,58 @@ var _ = Describe("Pipelining", func() {
 		Expect(pipeline.Close()).NotTo(HaveOccurred())
 	})
 
+	It("should return queued commands", func() {
+		pipeline := client.Pipeline()
+		defer pipeline.Close()
+
+		Expect(pipeline.Len()).To(Equal(0))
+		get := pipeline.Get("key")
+		incr := pipeline.Incr("key")
+		Expect(pipeline.Len()).To(Equal(2))
+		Expect(pipeline.Cmds()).To(Equal([]redis.Cmder{get, incr}))
+
+		Expect(pipeline.Discard()).NotTo(HaveOccurred())
+		Expect(pipeline.Len()).To(Equal(0))
+		Expect(pipeline.Cmds()).To(BeEmpty())
+	})
+
+	It("should flush every n commands", func() {
+		pipeline := client.Pipeline()
+		defer pipeline.Close()
+		pipeline.FlushEvery(2)
+
+		incr1 := pipeline.Incr("key")
+		Expect(pipeline.Len()).To(Equal(1))
+		incr2 := pipeline.Incr("key")
+		Expect(pipeline.Len()).To(Equal(0))
+		Expect(incr1.Val()).To(Equal(int64(1)))
+		Expect(incr2.Val()).To(Equal(int64(2)))
+
+		incr3 := pipeline.Incr("key")
+		Expect(pipeline.Len()).To(Equal(1))
+		Expect(incr3.Val()).To(Equal(int64(0)))
+
+		cmds, err := pipeline.Exec()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmds).To(Equal([]redis.Cmder{incr3}))
+		Expect(incr3.Val()).To(Equal(int64(3)))
+	})
+
+	It("should return error of flushed commands from Exec", func() {
+		pipeline := client.Pipeline()
+		defer pipeline.Close()
+		pipeline.FlushEvery(1)
+
+		get := pipeline.Get("key")
+		Expect(get.Err()).To(Equal(redis.Nil))
+
+		_, err := pipeline.Exec()
+		Expect(err).To(Equal(redis.Nil))
+
+		_, err = pipeline.Exec()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
 	It("should support block style", func() {
 		var get *redis.StringCmd
 		cmds, err := client.Pipelined(func(pipe *redis.Pipeline) error {
@@ -116,12 +116,28 @@ func (l *connList) Close() (retErr error) {
 	return retErr
 }
 
+// Names returns names of connections in the list.
+func (l *connList) Names() []string {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	names := make([]string, 0, len(l.cns))
+	for _, cn := range l.cns {
+		if cn.name != "" {
+			names = append(names, cn.name)
+		}
+	}
+	return names
+}
+
 func (l *connList) closed() bool {
 	return l.cns == nil
 }
 
 type connPool struct {
 	dialer func() (*conn, error)
+	// Prefix of connection names or empty if connections are not named.
+	namePrefix string
 
 	rl        *ratelimit.RateLimiter
 	opt       *Options
@@ -134,8 +150,13 @@ type connPool struct {
 }
 
 func newConnPool(opt *Options) *connPool {
+	var namePrefix string
+	if opt.ClientName != "" {
+		namePrefix = opt.ClientName + "-" + formatUint(atomic.AddUint64(&connNameSeq, 1))
+	}
 	p := &connPool{
-		dialer: newConnDialer(opt),
+		dialer:     newConnDialer(opt, namePrefix),
+		namePrefix: namePrefix,
 
 		rl:        ratelimit.New(2*opt.getPoolSize(), time.Second),
 		opt:       opt,
@@ -153,7 +174,7 @@ func (p *connPool) closed() bool {
 }
 
 func (p *connPool) isIdle(cn *conn) bool {
-	return p.opt.getIdleTimeout() > 0 && time.Since(cn.usedAt) > p.opt.getIdleTimeout()
+	return p.opt.getIdleTimeout() > 0 && since(p.opt.getClock(), cn.usedAt) > p.opt.getIdleTimeout()
 }
 
 // First returns first non-idle connection from the pool or nil if
@@ -248,7 +269,7 @@ func (p *connPool) Put(cn *conn) error {
 		return p.Remove(cn)
 	}
 	if p.opt.getIdleTimeout() > 0 {
-		cn.usedAt = time.Now()
+		cn.usedAt = p.opt.getClock().Now()
 	}
 	p.freeConns <- cn
 	return nil
@@ -0,0 +1,137 @@
+package redis
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// PresenceOptions are used to configure a presence tracker.
+type PresenceOptions struct {
+	// Members that did not send a heartbeat for TTL are offline.
+	// Default is 1 minute.
+	TTL time.Duration
+	// Source of time used for heartbeats and TTL checks.
+	// Default is the system clock.
+	Clock Clock
+}
+
+func (opt *PresenceOptions) getTTL() time.Duration {
+	if opt.TTL == 0 {
+		return time.Minute
+	}
+	return opt.TTL
+}
+
+type presenceClient interface {
+	ZAdd(key string, members ...Z) *IntCmd
+	ZRem(key string, members ...string) *IntCmd
+	ZScore(key, member string) *FloatCmd
+	ZRangeByScore(key string, opt ZRangeByScore) *StringSliceCmd
+	ZRemRangeByScore(key, min, max string) *IntCmd
+}
+
+// Presence tracks which members are online, e.g. users of a chat.
+// Members are stored in a sorted set scored by the time of the last
+// heartbeat in milliseconds, so clocks of processes sending heartbeats
+// must be synchronized.
+type Presence struct {
+	client presenceClient
+	key    string
+	opt    *PresenceOptions
+}
+
+// NewPresence returns a presence tracker storing members in key.
+func NewPresence(client presenceClient, key string, opt *PresenceOptions) *Presence {
+	if opt == nil {
+		opt = &PresenceOptions{}
+	}
+	return &Presence{
+		client: client,
+		key:    key,
+		opt:    opt,
+	}
+}
+
+func (opt *PresenceOptions) getClock() Clock {
+	if opt.Clock == nil {
+		return systemClock{}
+	}
+	return opt.Clock
+}
+
+func presenceScore(tm time.Time) string {
+	return formatInt(tm.UnixNano() / int64(time.Millisecond))
+}
+
+// Heartbeat marks the member as online.
+func (p *Presence) Heartbeat(member string) error {
+	score := float64(p.opt.getClock().Now().UnixNano() / int64(time.Millisecond))
+	return p.client.ZAdd(p.key, Z{Score: score, Member: member}).Err()
+}
+
+// Leave marks the member as offline.
+func (p *Presence) Leave(member string) error {
+	return p.client.ZRem(p.key, member).Err()
+}
+
+// IsOnline reports whether the member sent a heartbeat within TTL.
+func (p *Presence) IsOnline(member string) (bool, error) {
+	score, err := p.client.ZScore(p.key, member).Result()
+	if err == Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return score >= float64(p.opt.getClock().Now().Add(-p.opt.getTTL()).UnixNano()/int64(time.Millisecond)), nil
+}
+
+// Online returns members that sent a heartbeat since the given time,
+// ordered from the least recently seen. Zero time means within TTL.
+func (p *Presence) Online(since time.Time) ([]string, error) {
+	if since.IsZero() {
+		since = p.opt.getClock().Now().Add(-p.opt.getTTL())
+	}
+	return p.client.ZRangeByScore(p.key, ZRangeByScore{
+		Min: presenceScore(since),
+		Max: "+inf",
+	}).Result()
+}
+
+// Reap removes members that did not send a heartbeat within TTL and
+// returns the number of removed members.
+func (p *Presence) Reap() (int64, error) {
+	max := "(" + presenceScore(p.opt.getClock().Now().Add(-p.opt.getTTL()))
+	return p.client.ZRemRangeByScore(p.key, "-inf", max).Result()
+}
+
+// StartReaper calls Reap every interval in a background goroutine until
+// the returned stop function is called. Default interval is TTL. Stop
+// may be called more than once.
+func (p *Presence) StartReaper(interval time.Duration) (stop func()) {
+	if interval == 0 {
+		interval = p.opt.getTTL()
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			if _, err := p.Reap(); err != nil {
+				log.Printf("redis: presence reaper failed: %s", err)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("Presence", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should track online members", func() {
+		presence := redis.NewPresence(client, "online", nil)
+		Expect(presence.Heartbeat("alice")).NotTo(HaveOccurred())
+		Expect(presence.Heartbeat("bob")).NotTo(HaveOccurred())
+
+		online, err := presence.Online(time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(online).To(ConsistOf("alice", "bob"))
+
+		ok, err := presence.IsOnline("alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		Expect(presence.Leave("alice")).NotTo(HaveOccurred())
+
+		online, err = presence.Online(time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(online).To(Equal([]string{"bob"}))
+
+		ok, err = presence.IsOnline("alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should query members by last heartbeat", func() {
+		presence := redis.NewPresence(client, "online", nil)
+		Expect(presence.Heartbeat("alice")).NotTo(HaveOccurred())
+		time.Sleep(50 * time.Millisecond)
+		since := time.Now()
+		time.Sleep(10 * time.Millisecond)
+		Expect(presence.Heartbeat("bob")).NotTo(HaveOccurred())
+
+		online, err := presence.Online(since)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(online).To(Equal([]string{"bob"}))
+	})
+
+	It("should reap offline members", func() {
+		presence := redis.NewPresence(client, "online", &redis.PresenceOptions{
+			TTL: 100 * time.Millisecond,
+		})
+		Expect(presence.Heartbeat("alice")).NotTo(HaveOccurred())
+		time.Sleep(200 * time.Millisecond)
+		Expect(presence.Heartbeat("bob")).NotTo(HaveOccurred())
+
+		ok, err := presence.IsOnline("alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		n, err := presence.Reap()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(1)))
+
+		members, err := client.ZRange("online", 0, -1).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(members).To(Equal([]string{"bob"}))
+	})
+
+	It("should reap in background", func() {
+		presence := redis.NewPresence(client, "online", &redis.PresenceOptions{
+			TTL: 100 * time.Millisecond,
+		})
+		stop := presence.StartReaper(50 * time.Millisecond)
+		defer stop()
+
+		Expect(presence.Heartbeat("alice")).NotTo(HaveOccurred())
+		Eventually(func() int64 {
+			return client.ZCard("online").Val()
+		}).Should(Equal(int64(0)))
+
+		// Stop may be called more than once.
+		stop()
+	})
+})
@@ -0,0 +1,96 @@
+package redis
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// popHighestScript emulates ZPOPMAX on servers older than Redis 5.0.
+var popHighestScript = NewScript(`
+local v = redis.call("ZREVRANGE", KEYS[1], 0, 0, "WITHSCORES")
+if #v == 0 then
+  return false
+end
+redis.call("ZREM", KEYS[1], v[1])
+return v
+`)
+
+type priorityQueueClient interface {
+	scripter
+	ZAdd(key string, members ...Z) *IntCmd
+	ZCard(key string) *IntCmd
+	ZPopMax(key string, count int64) *ZSliceCmd
+	BZPopMax(timeout time.Duration, keys ...string) *ZWithKeyCmd
+}
+
+// PriorityQueue is a queue of unique items stored in a sorted set
+// scored by priority. Items with the highest priority are popped
+// first and items with equal priority are popped in reverse
+// lexicographical order.
+type PriorityQueue struct {
+	client priorityQueueClient
+	key    string
+
+	noZPop int32 // Set when ZPOPMAX is not supported.
+}
+
+// NewPriorityQueue returns a priority queue stored in key.
+func NewPriorityQueue(client priorityQueueClient, key string) *PriorityQueue {
+	return &PriorityQueue{
+		client: client,
+		key:    key,
+	}
+}
+
+// Push adds the item to the queue or updates priority of the item
+// already in the queue.
+func (q *PriorityQueue) Push(item string, priority float64) error {
+	return q.client.ZAdd(q.key, Z{Score: priority, Member: item}).Err()
+}
+
+// Len returns the number of items in the queue.
+func (q *PriorityQueue) Len() (int64, error) {
+	return q.client.ZCard(q.key).Result()
+}
+
+// PopHighest atomically removes and returns the item with the highest
+// priority. It returns Nil error when the queue is empty. ZPOPMAX is
+// used when the server supports it and Lua script otherwise.
+func (q *PriorityQueue) PopHighest() (item string, priority float64, err error) {
+	if atomic.LoadInt32(&q.noZPop) == 0 {
+		zz, err := q.client.ZPopMax(q.key, 1).Result()
+		if !isUnknownCommandError(err) {
+			if err != nil {
+				return "", 0, err
+			}
+			if len(zz) == 0 {
+				return "", 0, Nil
+			}
+			return zz[0].Member.(string), zz[0].Score, nil
+		}
+		atomic.StoreInt32(&q.noZPop, 1)
+	}
+
+	v, err := popHighestScript.Run(q.client, []string{q.key}, nil).Result()
+	if err != nil {
+		return "", 0, err
+	}
+	vals := v.([]interface{})
+	priority, err = strconv.ParseFloat(vals[1].(string), 64)
+	if err != nil {
+		return "", 0, err
+	}
+	return vals[0].(string), priority, nil
+}
+
+// BPopHighest is like PopHighest, but waits up to timeout for an item
+// when the queue is empty. Zero timeout means waiting forever. It
+// returns Nil error on timeout. Requires Redis 5.0.
+func (q *PriorityQueue) BPopHighest(timeout time.Duration) (item string, priority float64, err error) {
+	z, err := q.client.BZPopMax(timeout, q.key).Result()
+	if err != nil {
+		return "", 0, err
+	}
+	return z.Member.(string), z.Score, nil
+}
@@ -0,0 +1,71 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("PriorityQueue", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should pop items with the highest priority first", func() {
+		queue := redis.NewPriorityQueue(client, "queue")
+		Expect(queue.Push("low", 1)).NotTo(HaveOccurred())
+		Expect(queue.Push("high", 10)).NotTo(HaveOccurred())
+		Expect(queue.Push("medium", 5)).NotTo(HaveOccurred())
+		Expect(queue.Push("low", 7)).NotTo(HaveOccurred())
+
+		n, err := queue.Len()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(3)))
+
+		for _, want := range []redis.Z{{10, "high"}, {7, "low"}, {5, "medium"}} {
+			item, priority, err := queue.PopHighest()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(item).To(Equal(want.Member))
+			Expect(priority).To(Equal(want.Score))
+		}
+
+		_, _, err = queue.PopHighest()
+		Expect(err).To(Equal(redis.Nil))
+	})
+
+	It("should block until an item is pushed", func() {
+		queue := redis.NewPriorityQueue(client, "queue")
+
+		done := make(chan string)
+		go func() {
+			defer GinkgoRecover()
+
+			item, priority, err := queue.BPopHighest(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(priority).To(Equal(float64(3)))
+			done <- item
+		}()
+
+		Consistently(done).ShouldNot(Receive())
+		Expect(queue.Push("item", 3)).NotTo(HaveOccurred())
+		Eventually(done).Should(Receive(Equal("item")))
+	})
+
+	It("should return Nil on timeout", func() {
+		queue := redis.NewPriorityQueue(client, "queue")
+		_, _, err := queue.BPopHighest(time.Second)
+		Expect(err).To(Equal(redis.Nil))
+	})
+})
@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+)
+
+var errNoReplicas = errors.New("redis: there are no reachable replicas to promote")
+
+// PromoteOptions are used to configure PromoteReplica.
+type PromoteOptions struct {
+	// The maximum replication lag in bytes between the master and the
+	// replica that is promoted. Lag is checked only when the master is
+	// reachable.
+	// Default is to not check the lag.
+	MaxLag int64
+
+	// Optional callback that is called with host:port address of the
+	// new master after successful promotion. It can be used to update
+	// address used by the application.
+	OnPromote func(addr string)
+}
+
+// PromoteReplica promotes the freshest replica among given nodes to
+// master using REPLICAOF NO ONE and repoints remaining reachable nodes,
+// including the old master, to the new master. It is a lightweight
+// alternative to Redis Sentinel for small self-managed setups.
+func PromoteReplica(nodes []*Client, opt *PromoteOptions) (*Client, error) {
+	if opt == nil {
+		opt = &PromoteOptions{}
+	}
+
+	var master, best *Client
+	var masterOffset, bestOffset int64
+	var reachable []*Client
+	for _, node := range nodes {
+		s, err := node.Info("replication").Result()
+		if err != nil {
+			log.Printf("redis: %s is unreachable: %s", node, err)
+			continue
+		}
+		reachable = append(reachable, node)
+
+		info := parseInfo(s)
+		switch info["role"] {
+		case "master":
+			master = node
+			masterOffset, _ = strconv.ParseInt(info["master_repl_offset"], 10, 64)
+		case "slave":
+			offset, _ := strconv.ParseInt(info["slave_repl_offset"], 10, 64)
+			if best == nil || offset > bestOffset {
+				best = node
+				bestOffset = offset
+			}
+		}
+	}
+
+	if best == nil {
+		return nil, errNoReplicas
+	}
+	if master != nil && opt.MaxLag > 0 && masterOffset-bestOffset > opt.MaxLag {
+		return nil, fmt.Errorf(
+			"redis: replica %s lags %d bytes behind the master (max lag is %d)",
+			best, masterOffset-bestOffset, opt.MaxLag,
+		)
+	}
+
+	if err := best.SlaveOf("NO", "ONE").Err(); err != nil {
+		return nil, err
+	}
+
+	host, port, err := net.SplitHostPort(best.opt.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+
+	for _, node := range reachable {
+		if node == best {
+			continue
+		}
+		if err := node.SlaveOf(host, port).Err(); err != nil {
+			log.Printf("redis: %s can't replicate new master: %s", node, err)
+		}
+	}
+
+	if opt.OnPromote != nil {
+		opt.OnPromote(net.JoinHostPort(host, port))
+	}
+	return best, nil
+}
+
+// parseInfo parses INFO reply into a map of fields.
+func parseInfo(s string) map[string]string {
+	m := make(map[string]string)
+	for _, line := range strings.Split(s, "\r\n") {
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if i := strings.IndexByte(line, ':'); i > 0 {
+			m[line[:i]] = line[i+1:]
+		}
+	}
+	return m
+}
@@ -0,0 +1,67 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("PromoteReplica", func() {
+	const (
+		masterPort  = "8330"
+		replicaPort = "8331"
+	)
+
+	var master, replica *redisProcess
+
+	BeforeEach(func() {
+		var err error
+
+		master, err = startRedis(masterPort)
+		Expect(err).NotTo(HaveOccurred())
+
+		replica, err = startRedis(replicaPort, "--slaveof", "127.0.0.1", masterPort)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(master.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+		Eventually(func() string {
+			return replica.Get("key").Val()
+		}, "5s").Should(Equal("hello"))
+	})
+
+	AfterEach(func() {
+		Expect(replica.Close()).NotTo(HaveOccurred())
+		Expect(master.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should promote replica", func() {
+		var promoted string
+		client, err := redis.PromoteReplica(
+			[]*redis.Client{master.Client, replica.Client},
+			&redis.PromoteOptions{
+				OnPromote: func(addr string) {
+					promoted = addr
+				},
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client).To(Equal(replica.Client))
+		Expect(promoted).To(Equal("127.0.0.1:" + replicaPort))
+
+		Expect(replica.Info("replication").Val()).To(ContainSubstring("role:master"))
+		Expect(master.Info("replication").Val()).To(ContainSubstring("role:slave"))
+
+		Eventually(func() error {
+			return replica.Set("key", "world", 0).Err()
+		}, "5s").ShouldNot(HaveOccurred())
+		Eventually(func() string {
+			return master.Get("key").Val()
+		}, "5s").Should(Equal("world"))
+	})
+
+	It("should return an error when there are no replicas", func() {
+		_, err := redis.PromoteReplica([]*redis.Client{master.Client}, nil)
+		Expect(err).To(MatchError("redis: there are no reachable replicas to promote"))
+	})
+})
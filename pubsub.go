@@ -1,10 +1,16 @@
 package redis
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 )
 
+// ErrReceiveCanceled is returned by PubSub.ReceiveCancel when receiving
+// is canceled.
+var ErrReceiveCanceled = errors.New("redis: receive canceled")
+
 // Posts a message to the given channel.
 func (c *Client) Publish(channel, message string) *IntCmd {
 	req := NewIntCmd("PUBLISH", channel, message)
@@ -16,6 +22,9 @@ func (c *Client) Publish(channel, message string) *IntCmd {
 // http://redis.io/topics/pubsub.
 type PubSub struct {
 	*baseClient
+
+	handlersMx sync.RWMutex
+	handlers   []pubSubHandler
 }
 
 // Deprecated. Use Subscribe/PSubscribe instead.
@@ -152,6 +161,27 @@ func (c *PubSub) ReceiveTimeout(timeout time.Duration) (interface{}, error) {
 	return newMessage(cmd.Val())
 }
 
+// ReceiveCancel acts like Receive but returns ErrReceiveCanceled if
+// cancel is closed before a message is received, e.g. on shutdown.
+// Subscriptions are kept, so receiving can be resumed later.
+func (c *PubSub) ReceiveCancel(cancel <-chan struct{}) (interface{}, error) {
+	cn, err := c.conn()
+	if err != nil {
+		return nil, err
+	}
+	cn.ReadTimeout = 0
+
+	if err := cn.peekCancel(cancel); err != nil {
+		return nil, err
+	}
+
+	cmd := NewSliceCmd()
+	if err := cmd.parseReply(cn.rd); err != nil {
+		return nil, err
+	}
+	return newMessage(cmd.Val())
+}
+
 func (c *PubSub) subscribe(cmd string, channels ...string) error {
 	cn, err := c.conn()
 	if err != nil {
@@ -0,0 +1,86 @@
+package redis
+
+import (
+	"log"
+	"sync"
+)
+
+type pubSubHandler struct {
+	pattern string
+	fn      func(*Message)
+}
+
+// Handle registers fn to be called by Run for messages published to
+// channels matching the glob-style pattern, e.g. "news.*". Messages
+// matching several patterns are passed to every matching handler.
+// Messages received by pattern subscriptions are passed as Message
+// with the pattern dropped.
+func (c *PubSub) Handle(pattern string, fn func(msg *Message)) {
+	c.handlersMx.Lock()
+	c.handlers = append(c.handlers, pubSubHandler{pattern: pattern, fn: fn})
+	c.handlersMx.Unlock()
+}
+
+func (c *PubSub) matchHandlers(channel string) []func(*Message) {
+	c.handlersMx.RLock()
+	defer c.handlersMx.RUnlock()
+
+	var fns []func(*Message)
+	for _, h := range c.handlers {
+		if matchGlob(h.pattern, channel) {
+			fns = append(fns, h.fn)
+		}
+	}
+	return fns
+}
+
+// Run receives messages and dispatches them to handlers registered
+// with Handle until cancel is closed, in which case it waits for
+// running handlers and returns nil. Handlers are called by up to
+// workers goroutines, so messages may be handled out of order when
+// workers is greater than 1. Panics in handlers are recovered and
+// logged. Run returns the error if receiving fails.
+func (c *PubSub) Run(cancel <-chan struct{}, workers int) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+	defer wg.Wait()
+
+	for {
+		msgi, err := c.ReceiveCancel(cancel)
+		if err == ErrReceiveCanceled {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var msg *Message
+		switch m := msgi.(type) {
+		case *Message:
+			msg = m
+		case *PMessage:
+			msg = &Message{Channel: m.Channel, Payload: m.Payload}
+		default:
+			continue
+		}
+
+		for _, fn := range c.matchHandlers(msg.Channel) {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(fn func(*Message)) {
+				defer func() {
+					if v := recover(); v != nil {
+						log.Printf("redis: pubsub handler of %q panicked: %v", msg.Channel, v)
+					}
+					<-sem
+					wg.Done()
+				}()
+				fn(msg)
+			}(fn)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("PubSub dispatcher", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should dispatch messages to matching handlers", func() {
+		pubsub, err := client.PSubscribe("*")
+		Expect(err).NotTo(HaveOccurred())
+		defer pubsub.Close()
+
+		news := make(chan string, 10)
+		all := make(chan string, 10)
+		pubsub.Handle("news.*", func(msg *redis.Message) {
+			news <- msg.Payload
+		})
+		pubsub.Handle("*", func(msg *redis.Message) {
+			all <- msg.Channel
+		})
+		pubsub.Handle("sports", func(msg *redis.Message) {
+			panic("boom")
+		})
+
+		cancel := make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- pubsub.Run(cancel, 4)
+		}()
+
+		Eventually(func() int64 {
+			return client.Publish("news.tech", "hello").Val()
+		}).Should(Equal(int64(1)))
+		Expect(client.Publish("sports", "goal").Err()).NotTo(HaveOccurred())
+		Expect(client.Publish("news.world", "world").Err()).NotTo(HaveOccurred())
+
+		var got []string
+		for i := 0; i < 2; i++ {
+			var payload string
+			Eventually(news).Should(Receive(&payload))
+			got = append(got, payload)
+		}
+		Expect(got).To(ConsistOf("hello", "world"))
+
+		var channels []string
+		for i := 0; i < 3; i++ {
+			var channel string
+			Eventually(all).Should(Receive(&channel))
+			channels = append(channels, channel)
+		}
+		Expect(channels).To(ConsistOf("news.tech", "sports", "news.world"))
+
+		close(cancel)
+		var runErr error
+		Eventually(done).Should(Receive(&runErr))
+		Expect(runErr).NotTo(HaveOccurred())
+	})
+
+	It("should stop under steady traffic", func() {
+		pubsub, err := client.Subscribe("mychannel")
+		Expect(err).NotTo(HaveOccurred())
+		defer pubsub.Close()
+
+		received := make(chan struct{}, 1)
+		pubsub.Handle("mychannel", func(msg *redis.Message) {
+			select {
+			case received <- struct{}{}:
+			default:
+			}
+		})
+
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					client.Publish("mychannel", "hello")
+				}
+			}
+		}()
+
+		cancel := make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- pubsub.Run(cancel, 1)
+		}()
+		Eventually(received).Should(Receive())
+
+		close(cancel)
+		var runErr error
+		Eventually(done).Should(Receive(&runErr))
+		Expect(runErr).NotTo(HaveOccurred())
+	})
+})
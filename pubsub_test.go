@@ -193,6 +193,63 @@ var _ = Describe("PubSub", func() {
 		}
 	})
 
+	It("should cancel blocked receive", func() {
+		pubsub, err := client.Subscribe("mychannel")
+		Expect(err).NotTo(HaveOccurred())
+		defer pubsub.Close()
+
+		cancel := make(chan struct{})
+		msgi, err := pubsub.ReceiveCancel(cancel)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msgi).To(BeAssignableToTypeOf(&redis.Subscription{}))
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			close(cancel)
+		}()
+		start := time.Now()
+		_, err = pubsub.ReceiveCancel(cancel)
+		Expect(err).To(Equal(redis.ErrReceiveCanceled))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+
+		_, err = pubsub.ReceiveCancel(cancel)
+		Expect(err).To(Equal(redis.ErrReceiveCanceled))
+
+		Expect(client.Publish("mychannel", "hello").Err()).NotTo(HaveOccurred())
+		msgi, err = pubsub.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msgi).To(Equal(&redis.Message{Channel: "mychannel", Payload: "hello"}))
+	})
+
+	It("should cancel receive while messages keep arriving", func() {
+		pubsub, err := client.Subscribe("mychannel")
+		Expect(err).NotTo(HaveOccurred())
+		defer pubsub.Close()
+
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					client.Publish("mychannel", "hello")
+				}
+			}
+		}()
+
+		cancel := make(chan struct{})
+		Eventually(func() interface{} {
+			msgi, _ := pubsub.ReceiveCancel(cancel)
+			return msgi
+		}).Should(BeAssignableToTypeOf(&redis.Message{}))
+
+		close(cancel)
+		_, err = pubsub.ReceiveCancel(cancel)
+		Expect(err).To(Equal(redis.ErrReceiveCanceled))
+	})
+
 	It("should ping/pong", func() {
 		pubsub, err := client.Subscribe("mychannel")
 		Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,107 @@
+package redis
+
+import "io"
+
+// defaultRangeChunkSize is used when RangeReader chunk size is not
+// positive.
+const defaultRangeChunkSize = 64 * 1024
+
+type getRanger interface {
+	GetRange(key string, start, end int64) *StringCmd
+}
+
+// RangeReader reads a string value in chunks using GETRANGE, so large
+// values like append-only logs can be consumed without loading the
+// whole value in memory. It also implements io.Reader.
+type RangeReader struct {
+	c         getRanger
+	key       string
+	chunkSize int64
+
+	offset int64
+	chunk  string
+	buf    string
+	err    error
+}
+
+func newRangeReader(c getRanger, key string, chunkSize int64) *RangeReader {
+	if chunkSize <= 0 {
+		chunkSize = defaultRangeChunkSize
+	}
+	return &RangeReader{
+		c:         c,
+		key:       key,
+		chunkSize: chunkSize,
+	}
+}
+
+// RangeReader returns a reader of the string stored at key that
+// fetches chunkSize bytes at a time. Default chunk size used for zero
+// or negative chunkSize is 64KB.
+func (c *Client) RangeReader(key string, chunkSize int64) *RangeReader {
+	return newRangeReader(c, key, chunkSize)
+}
+
+// RangeReader returns a reader of the string stored at key that
+// fetches chunkSize bytes at a time. See Client.RangeReader.
+func (c *ClusterClient) RangeReader(key string, chunkSize int64) *RangeReader {
+	return newRangeReader(c, key, chunkSize)
+}
+
+// Next fetches next chunk. It returns false when the end of the value
+// is reached or an error occurs.
+func (r *RangeReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	chunk, err := r.c.GetRange(r.key, r.offset, r.offset+r.chunkSize-1).Result()
+	if err != nil {
+		r.err = err
+		return false
+	}
+	if chunk == "" {
+		return false
+	}
+	r.chunk = chunk
+	r.offset += int64(len(chunk))
+	return true
+}
+
+// Val returns the chunk fetched by the last call to Next.
+func (r *RangeReader) Val() string {
+	return r.chunk
+}
+
+// Offset returns the offset of the next chunk. It can be saved to
+// resume reading later using SetOffset.
+func (r *RangeReader) Offset() int64 {
+	return r.offset
+}
+
+// SetOffset sets the offset of the next chunk.
+func (r *RangeReader) SetOffset(offset int64) {
+	r.offset = offset
+	r.chunk = ""
+	r.buf = ""
+}
+
+// Err returns the error occurred while fetching chunks.
+func (r *RangeReader) Err() error {
+	return r.err
+}
+
+// Read implements io.Reader.
+func (r *RangeReader) Read(b []byte) (int, error) {
+	if r.buf == "" {
+		if !r.Next() {
+			if r.err != nil {
+				return 0, r.err
+			}
+			return 0, io.EOF
+		}
+		r.buf = r.chunk
+	}
+	n := copy(b, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
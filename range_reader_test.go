@@ -0,0 +1,77 @@
+package redis_test
+
+import (
+	"io/ioutil"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("RangeReader", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should iterate over chunks", func() {
+		Expect(client.Set("log", "hello world", 0).Err()).NotTo(HaveOccurred())
+
+		rd := client.RangeReader("log", 4)
+		var chunks []string
+		for rd.Next() {
+			chunks = append(chunks, rd.Val())
+		}
+		Expect(rd.Err()).NotTo(HaveOccurred())
+		Expect(chunks).To(Equal([]string{"hell", "o wo", "rld"}))
+		Expect(rd.Offset()).To(Equal(int64(11)))
+
+		Expect(client.Append("log", "!").Err()).NotTo(HaveOccurred())
+		Expect(rd.Next()).To(BeTrue())
+		Expect(rd.Val()).To(Equal("!"))
+		Expect(rd.Next()).To(BeFalse())
+
+		rd.SetOffset(6)
+		Expect(rd.Next()).To(BeTrue())
+		Expect(rd.Val()).To(Equal("worl"))
+	})
+
+	It("should implement io.Reader", func() {
+		value := strings.Repeat("x", 10000)
+		Expect(client.Set("log", value, 0).Err()).NotTo(HaveOccurred())
+
+		b, err := ioutil.ReadAll(client.RangeReader("log", 1024))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(value))
+	})
+
+	It("should use default chunk size", func() {
+		Expect(client.Set("log", "hello", 0).Err()).NotTo(HaveOccurred())
+
+		for _, size := range []int64{0, -1} {
+			rd := client.RangeReader("log", size)
+			Expect(rd.Next()).To(BeTrue())
+			Expect(rd.Val()).To(Equal("hello"))
+			Expect(rd.Next()).To(BeFalse())
+			Expect(rd.Err()).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should return error for wrong type", func() {
+		Expect(client.LPush("log", "hello").Err()).NotTo(HaveOccurred())
+
+		rd := client.RangeReader("log", 4)
+		Expect(rd.Next()).To(BeFalse())
+		Expect(rd.Err()).To(HaveOccurred())
+	})
+})
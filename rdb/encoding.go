@@ -0,0 +1,317 @@
+package rdb
+
+import (
+	"encoding/binary"
+	"strconv"
+)
+
+// buffer is a bounds-checked reader of in-memory encodings.
+type buffer struct {
+	b   []byte
+	pos int
+	err error
+}
+
+func (b *buffer) next(n int) []byte {
+	if b.err != nil {
+		return nil
+	}
+	if n < 0 || b.pos+n > len(b.b) {
+		b.err = errCorrupted
+		return nil
+	}
+	p := b.b[b.pos : b.pos+n]
+	b.pos += n
+	return p
+}
+
+// byte returns next byte or end marker on error.
+func (b *buffer) byte() byte {
+	p := b.next(1)
+	if p == nil {
+		return 0xff
+	}
+	return p[0]
+}
+
+func (b *buffer) uint16() uint16 {
+	if p := b.next(2); p != nil {
+		return binary.LittleEndian.Uint16(p)
+	}
+	return 0
+}
+
+func (b *buffer) uint24() uint32 {
+	if p := b.next(3); p != nil {
+		return uint32(p[0]) | uint32(p[1])<<8 | uint32(p[2])<<16
+	}
+	return 0
+}
+
+func (b *buffer) uint32() uint32 {
+	if p := b.next(4); p != nil {
+		return binary.LittleEndian.Uint32(p)
+	}
+	return 0
+}
+
+func (b *buffer) uint64() uint64 {
+	if p := b.next(8); p != nil {
+		return binary.LittleEndian.Uint64(p)
+	}
+	return 0
+}
+
+func int24(v uint32) int64 {
+	return int64(int32(v<<8) >> 8)
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func parseZiplist(data []byte) ([]string, error) {
+	b := &buffer{b: data}
+	b.next(8) // zlbytes and zltail
+	vals := make([]string, 0, b.uint16())
+
+	for {
+		// Entry starts with the length of the previous entry.
+		prevlen := b.byte()
+		if b.err != nil {
+			return nil, b.err
+		}
+		if prevlen == 0xff {
+			break
+		}
+		if prevlen == 0xfe {
+			b.next(4)
+		}
+
+		enc := b.byte()
+		var val string
+		switch {
+		case enc>>6 == 0:
+			val = string(b.next(int(enc & 0x3f)))
+		case enc>>6 == 1:
+			val = string(b.next(int(enc&0x3f)<<8 | int(b.byte())))
+		case enc == 0x80:
+			n := b.next(4)
+			if n != nil {
+				val = string(b.next(int(binary.BigEndian.Uint32(n))))
+			}
+		case enc == 0xc0:
+			val = itoa(int64(int16(b.uint16())))
+		case enc == 0xd0:
+			val = itoa(int64(int32(b.uint32())))
+		case enc == 0xe0:
+			val = itoa(int64(b.uint64()))
+		case enc == 0xf0:
+			val = itoa(int24(b.uint24()))
+		case enc == 0xfe:
+			val = itoa(int64(int8(b.byte())))
+		case enc >= 0xf1 && enc <= 0xfd:
+			val = itoa(int64(enc&0x0f) - 1)
+		default:
+			return nil, errCorrupted
+		}
+		if b.err != nil {
+			return nil, b.err
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
+
+func parseListpack(data []byte) ([]string, error) {
+	b := &buffer{b: data}
+	b.next(4) // total bytes
+	vals := make([]string, 0, b.uint16())
+
+	for {
+		enc := b.byte()
+		if b.err != nil {
+			return nil, b.err
+		}
+		if enc == 0xff {
+			break
+		}
+
+		var val string
+		var size int
+		switch {
+		case enc&0x80 == 0:
+			val, size = itoa(int64(enc)), 1
+		case enc&0xc0 == 0x80:
+			n := int(enc & 0x3f)
+			val, size = string(b.next(n)), 1+n
+		case enc&0xe0 == 0xc0:
+			n := int64(enc&0x1f)<<8 | int64(b.byte())
+			if n >= 1<<12 {
+				n -= 1 << 13
+			}
+			val, size = itoa(n), 2
+		case enc&0xf0 == 0xe0:
+			n := int(enc&0x0f)<<8 | int(b.byte())
+			val, size = string(b.next(n)), 2+n
+		case enc == 0xf0:
+			n := int(b.uint32())
+			val, size = string(b.next(n)), 5+n
+		case enc == 0xf1:
+			val, size = itoa(int64(int16(b.uint16()))), 3
+		case enc == 0xf2:
+			val, size = itoa(int24(b.uint24())), 4
+		case enc == 0xf3:
+			val, size = itoa(int64(int32(b.uint32()))), 5
+		case enc == 0xf4:
+			val, size = itoa(int64(b.uint64())), 9
+		default:
+			return nil, errCorrupted
+		}
+		b.next(backlenSize(size))
+		if b.err != nil {
+			return nil, b.err
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
+
+// backlenSize returns number of bytes used to store entry size at the
+// end of listpack entry.
+func backlenSize(n int) int {
+	switch {
+	case n < 1<<7:
+		return 1
+	case n < 1<<14-1:
+		return 2
+	case n < 1<<21-1:
+		return 3
+	case n < 1<<28-1:
+		return 4
+	default:
+		return 5
+	}
+}
+
+func parseIntset(data []byte) ([]string, error) {
+	b := &buffer{b: data}
+	enc := b.uint32()
+	n := int(b.uint32())
+	if b.err != nil {
+		return nil, b.err
+	}
+	if enc != 2 && enc != 4 && enc != 8 || n*int(enc) != len(data)-8 {
+		return nil, errCorrupted
+	}
+
+	vals := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		var v int64
+		switch enc {
+		case 2:
+			v = int64(int16(b.uint16()))
+		case 4:
+			v = int64(int32(b.uint32()))
+		case 8:
+			v = int64(b.uint64())
+		default:
+			return nil, errCorrupted
+		}
+		if b.err != nil {
+			return nil, b.err
+		}
+		vals = append(vals, itoa(v))
+	}
+	return vals, nil
+}
+
+func parseZipmap(data []byte) ([]string, error) {
+	b := &buffer{b: data}
+	b.next(1) // zmlen
+
+	var vals []string
+	for {
+		n, ok := zipmapLen(b)
+		if !ok {
+			break
+		}
+		key := string(b.next(n))
+
+		n, ok = zipmapLen(b)
+		if !ok {
+			return nil, errCorrupted
+		}
+		free := int(b.byte())
+		val := string(b.next(n))
+		b.next(free)
+
+		if b.err != nil {
+			return nil, b.err
+		}
+		vals = append(vals, key, val)
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return vals, nil
+}
+
+// zipmapLen reads zipmap length and reports false on end marker.
+func zipmapLen(b *buffer) (int, bool) {
+	switch c := b.byte(); c {
+	case 254:
+		return int(b.uint32()), true
+	case 255:
+		return 0, false
+	default:
+		return int(c), true
+	}
+}
+
+func lzfDecompress(in []byte, n uint64) ([]byte, error) {
+	out := make([]byte, 0, capHint(n))
+	for i := 0; i < len(in); {
+		ctrl := int(in[i])
+		i++
+
+		if ctrl < 1<<5 {
+			// Literal run.
+			ctrl++
+			if i+ctrl > len(in) {
+				return nil, errCorrupted
+			}
+			out = append(out, in[i:i+ctrl]...)
+			i += ctrl
+			continue
+		}
+
+		// Back reference.
+		length := ctrl >> 5
+		if length == 7 {
+			if i >= len(in) {
+				return nil, errCorrupted
+			}
+			length += int(in[i])
+			i++
+		}
+		if i >= len(in) {
+			return nil, errCorrupted
+		}
+		ref := len(out) - (ctrl&0x1f)<<8 - int(in[i]) - 1
+		i++
+		if ref < 0 {
+			return nil, errCorrupted
+		}
+		if uint64(len(out)+length+2) > n {
+			return nil, errCorrupted
+		}
+		for j := 0; j < length+2; j++ {
+			out = append(out, out[ref+j])
+		}
+	}
+	if uint64(len(out)) != n {
+		return nil, errCorrupted
+	}
+	return out, nil
+}
@@ -0,0 +1,851 @@
+// Package rdb implements a parser for Redis RDB snapshots. It can be
+// used to analyze RDB files offline or together with the replica
+// package to process snapshot sent by the master on full
+// resynchronization.
+//
+// Strings, lists, sets, sorted sets and hashes are supported in all
+// encodings. Streams and values of modules are skipped and returned
+// without value.
+package rdb
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"time"
+)
+
+var errCorrupted = errors.New("rdb: corrupted payload")
+
+// maxPrealloc limits memory allocated upfront for lengths read from
+// the payload, so corrupted payloads fail with an error instead of
+// huge allocations. Larger values grow as they are read.
+const maxPrealloc = 1 << 16
+
+func capHint(n uint64) int {
+	if n > maxPrealloc {
+		return maxPrealloc
+	}
+	return int(n)
+}
+
+const (
+	opSlotInfo   = 0xF4
+	opFunction2  = 0xF5
+	opModuleAux  = 0xF7
+	opIdle       = 0xF8
+	opFreq       = 0xF9
+	opAux        = 0xFA
+	opResizeDB   = 0xFB
+	opExpireTime = 0xFC
+	opExpire     = 0xFD
+	opSelectDB   = 0xFE
+	opEOF        = 0xFF
+)
+
+const (
+	typeString           = 0
+	typeList             = 1
+	typeSet              = 2
+	typeZSet             = 3
+	typeHash             = 4
+	typeZSet2            = 5
+	typeHashZipmap       = 9
+	typeListZiplist      = 10
+	typeSetIntset        = 11
+	typeZSetZiplist      = 12
+	typeModule2          = 7
+	typeHashZiplist      = 13
+	typeListQuicklist    = 14
+	typeStreamListpacks  = 15
+	typeHashListpack     = 16
+	typeZSetListpack     = 17
+	typeListQuicklist2   = 18
+	typeStreamListpacks2 = 19
+	typeSetListpack      = 20
+	typeStreamListpacks3 = 21
+	quicklistNodePlain   = 1
+	quicklistNodePacked  = 2
+)
+
+// Type is a type of the value stored at the key.
+type Type int
+
+const (
+	String Type = iota
+	List
+	Set
+	ZSet
+	Hash
+	Stream
+	Module
+)
+
+func (t Type) String() string {
+	switch t {
+	case String:
+		return "string"
+	case List:
+		return "list"
+	case Set:
+		return "set"
+	case ZSet:
+		return "zset"
+	case Hash:
+		return "hash"
+	case Stream:
+		return "stream"
+	case Module:
+		return "module"
+	}
+	return "unknown"
+}
+
+// Encoding is an encoding used to store the value in RDB, e.g.
+// "ziplist" or "listpack".
+type Encoding string
+
+const (
+	EncodingRaw        Encoding = "raw"
+	EncodingLinkedList Encoding = "linkedlist"
+	EncodingHashtable  Encoding = "hashtable"
+	EncodingSkiplist   Encoding = "skiplist"
+	EncodingZipmap     Encoding = "zipmap"
+	EncodingZiplist    Encoding = "ziplist"
+	EncodingIntset     Encoding = "intset"
+	EncodingQuicklist  Encoding = "quicklist"
+	EncodingListpack   Encoding = "listpack"
+)
+
+// Z is a sorted set member.
+type Z struct {
+	Score  float64
+	Member string
+}
+
+// Record is a key read from RDB.
+type Record struct {
+	DB       int
+	Key      string
+	Type     Type
+	Encoding Encoding
+	// Zero if the key does not have an expiration.
+	ExpireAt time.Time
+	// Value is string for strings, []string for lists and sets, []Z
+	// for sorted sets and map[string]string for hashes. Values of
+	// streams and modules are skipped and Value is nil.
+	Value interface{}
+}
+
+// ReadHeader reads RDB magic string and returns RDB version.
+func ReadHeader(rd io.Reader) (int, error) {
+	b := make([]byte, 9)
+	if _, err := io.ReadFull(rd, b); err != nil {
+		return 0, err
+	}
+	if string(b[:5]) != "REDIS" {
+		return 0, fmt.Errorf("rdb: invalid header: %q", b)
+	}
+	return strconv.Atoi(string(b[5:]))
+}
+
+// Parser reads records from RDB.
+type Parser struct {
+	rd *bufio.Reader
+
+	version int
+	db      int
+	aux     map[string]string
+}
+
+// NewParser returns a parser reading RDB from rd.
+func NewParser(rd io.Reader) *Parser {
+	return &Parser{
+		rd:      bufio.NewReader(rd),
+		version: -1,
+		aux:     make(map[string]string),
+	}
+}
+
+// Version returns RDB version. It is available after the first call
+// to Next.
+func (p *Parser) Version() int {
+	return p.version
+}
+
+// Aux returns auxiliary fields read so far, e.g. "redis-ver".
+func (p *Parser) Aux() map[string]string {
+	return p.aux
+}
+
+// Next returns next record. It returns io.EOF when there are no more
+// records.
+func (p *Parser) Next() (*Record, error) {
+	if p.version == -1 {
+		version, err := ReadHeader(p.rd)
+		if err != nil {
+			return nil, err
+		}
+		p.version = version
+	}
+
+	var expireAt time.Time
+	for {
+		op, err := p.rd.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case opAux:
+			key, err := p.readString()
+			if err != nil {
+				return nil, err
+			}
+			val, err := p.readString()
+			if err != nil {
+				return nil, err
+			}
+			p.aux[key] = val
+		case opResizeDB:
+			if err := p.skipLengths(2); err != nil {
+				return nil, err
+			}
+		case opSlotInfo:
+			if err := p.skipLengths(3); err != nil {
+				return nil, err
+			}
+		case opExpireTime:
+			b, err := p.readN(8)
+			if err != nil {
+				return nil, err
+			}
+			ms := int64(binary.LittleEndian.Uint64(b))
+			expireAt = time.Unix(0, ms*int64(time.Millisecond))
+		case opExpire:
+			b, err := p.readN(4)
+			if err != nil {
+				return nil, err
+			}
+			expireAt = time.Unix(int64(binary.LittleEndian.Uint32(b)), 0)
+		case opSelectDB:
+			db, err := p.readLength()
+			if err != nil {
+				return nil, err
+			}
+			p.db = int(db)
+		case opFreq:
+			if _, err := p.rd.ReadByte(); err != nil {
+				return nil, err
+			}
+		case opIdle:
+			if err := p.skipLengths(1); err != nil {
+				return nil, err
+			}
+		case opFunction2:
+			if _, err := p.readBytes(); err != nil {
+				return nil, err
+			}
+		case opModuleAux:
+			if err := p.skipModuleAux(); err != nil {
+				return nil, err
+			}
+		case opEOF:
+			if p.version >= 5 {
+				// Skip CRC64 checksum.
+				if _, err := p.readN(8); err != nil {
+					return nil, err
+				}
+			}
+			return nil, io.EOF
+		default:
+			rec, err := p.readRecord(op, expireAt)
+			if err == io.EOF {
+				// Payload is truncated in the middle of the record.
+				err = io.ErrUnexpectedEOF
+			}
+			return rec, err
+		}
+	}
+}
+
+func (p *Parser) readRecord(typ byte, expireAt time.Time) (*Record, error) {
+	key, err := p.readString()
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &Record{
+		DB:       p.db,
+		Key:      key,
+		ExpireAt: expireAt,
+	}
+	switch typ {
+	case typeString:
+		rec.Type, rec.Encoding = String, EncodingRaw
+		rec.Value, err = p.readString()
+	case typeList:
+		rec.Type, rec.Encoding = List, EncodingLinkedList
+		rec.Value, err = p.readStrings(1)
+	case typeListZiplist:
+		rec.Type, rec.Encoding = List, EncodingZiplist
+		rec.Value, err = p.readEncoded(parseZiplist)
+	case typeListQuicklist:
+		rec.Type, rec.Encoding = List, EncodingQuicklist
+		rec.Value, err = p.readQuicklist(false)
+	case typeListQuicklist2:
+		rec.Type, rec.Encoding = List, EncodingQuicklist
+		rec.Value, err = p.readQuicklist(true)
+	case typeSet:
+		rec.Type, rec.Encoding = Set, EncodingHashtable
+		rec.Value, err = p.readStrings(1)
+	case typeSetIntset:
+		rec.Type, rec.Encoding = Set, EncodingIntset
+		rec.Value, err = p.readEncoded(parseIntset)
+	case typeSetListpack:
+		rec.Type, rec.Encoding = Set, EncodingListpack
+		rec.Value, err = p.readEncoded(parseListpack)
+	case typeZSet, typeZSet2:
+		rec.Type, rec.Encoding = ZSet, EncodingSkiplist
+		rec.Value, err = p.readZSet(typ == typeZSet2)
+	case typeZSetZiplist:
+		rec.Type, rec.Encoding = ZSet, EncodingZiplist
+		rec.Value, err = p.readEncodedZSet(parseZiplist)
+	case typeZSetListpack:
+		rec.Type, rec.Encoding = ZSet, EncodingListpack
+		rec.Value, err = p.readEncodedZSet(parseListpack)
+	case typeHash:
+		rec.Type, rec.Encoding = Hash, EncodingHashtable
+		var vals []string
+		vals, err = p.readStrings(2)
+		if err == nil {
+			rec.Value, err = toMap(vals)
+		}
+	case typeHashZipmap:
+		rec.Type, rec.Encoding = Hash, EncodingZipmap
+		rec.Value, err = p.readEncodedHash(parseZipmap)
+	case typeHashZiplist:
+		rec.Type, rec.Encoding = Hash, EncodingZiplist
+		rec.Value, err = p.readEncodedHash(parseZiplist)
+	case typeHashListpack:
+		rec.Type, rec.Encoding = Hash, EncodingListpack
+		rec.Value, err = p.readEncodedHash(parseListpack)
+	case typeStreamListpacks, typeStreamListpacks2, typeStreamListpacks3:
+		rec.Type, rec.Encoding = Stream, EncodingListpack
+		err = p.skipStream(typ)
+	case typeModule2:
+		rec.Type = Module
+		err = p.skipModuleValue()
+	default:
+		return nil, fmt.Errorf("rdb: value type %d is not supported", typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (p *Parser) readN(n uint64) ([]byte, error) {
+	if n <= maxPrealloc {
+		b := make([]byte, n)
+		_, err := io.ReadFull(p.rd, b)
+		return b, err
+	}
+	if n > math.MaxInt64 {
+		return nil, errCorrupted
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, maxPrealloc))
+	_, err := io.CopyN(buf, p.rd, int64(n))
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return buf.Bytes(), err
+}
+
+// readLengthEncoded reads length-encoded integer. Encoded reports whether
+// the following value uses special encoding.
+func (p *Parser) readLengthEncoded() (length uint64, encoded bool, err error) {
+	c, err := p.rd.ReadByte()
+	if err != nil {
+		return 0, false, err
+	}
+
+	switch c >> 6 {
+	case 0:
+		return uint64(c & 0x3f), false, nil
+	case 1:
+		c2, err := p.rd.ReadByte()
+		if err != nil {
+			return 0, false, err
+		}
+		return uint64(c&0x3f)<<8 | uint64(c2), false, nil
+	case 2:
+		switch c {
+		case 0x80:
+			b, err := p.readN(4)
+			if err != nil {
+				return 0, false, err
+			}
+			return uint64(binary.BigEndian.Uint32(b)), false, nil
+		case 0x81:
+			b, err := p.readN(8)
+			if err != nil {
+				return 0, false, err
+			}
+			return binary.BigEndian.Uint64(b), false, nil
+		}
+		return 0, false, errCorrupted
+	default:
+		return uint64(c & 0x3f), true, nil
+	}
+}
+
+func (p *Parser) readLength() (uint64, error) {
+	n, encoded, err := p.readLengthEncoded()
+	if err != nil {
+		return 0, err
+	}
+	if encoded {
+		return 0, errCorrupted
+	}
+	return n, nil
+}
+
+func (p *Parser) skipLengths(n int) error {
+	for i := 0; i < n; i++ {
+		if _, err := p.readLength(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Parser) readBytes() ([]byte, error) {
+	n, encoded, err := p.readLengthEncoded()
+	if err != nil {
+		return nil, err
+	}
+	if !encoded {
+		return p.readN(n)
+	}
+
+	switch n {
+	case 0:
+		b, err := p.readN(1)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.AppendInt(nil, int64(int8(b[0])), 10), nil
+	case 1:
+		b, err := p.readN(2)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.AppendInt(nil, int64(int16(binary.LittleEndian.Uint16(b))), 10), nil
+	case 2:
+		b, err := p.readN(4)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.AppendInt(nil, int64(int32(binary.LittleEndian.Uint32(b))), 10), nil
+	case 3:
+		clen, err := p.readLength()
+		if err != nil {
+			return nil, err
+		}
+		ulen, err := p.readLength()
+		if err != nil {
+			return nil, err
+		}
+		b, err := p.readN(clen)
+		if err != nil {
+			return nil, err
+		}
+		return lzfDecompress(b, ulen)
+	}
+	return nil, fmt.Errorf("rdb: string encoding %d is not supported", n)
+}
+
+func (p *Parser) readString() (string, error) {
+	b, err := p.readBytes()
+	return string(b), err
+}
+
+// readStrings reads length-prefixed sequence of strings. Length is
+// multiplied by mul, e.g. 2 for field-value pairs.
+func (p *Parser) readStrings(mul int) ([]string, error) {
+	n, err := p.readLength()
+	if err != nil {
+		return nil, err
+	}
+	count := n * uint64(mul)
+	if count/uint64(mul) != n {
+		return nil, errCorrupted
+	}
+	vals := make([]string, 0, capHint(count))
+	for i := uint64(0); i < count; i++ {
+		val, err := p.readString()
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
+
+func (p *Parser) readEncoded(parse func([]byte) ([]string, error)) ([]string, error) {
+	b, err := p.readBytes()
+	if err != nil {
+		return nil, err
+	}
+	return parse(b)
+}
+
+func (p *Parser) readEncodedZSet(parse func([]byte) ([]string, error)) ([]Z, error) {
+	vals, err := p.readEncoded(parse)
+	if err != nil {
+		return nil, err
+	}
+	return toZ(vals)
+}
+
+func (p *Parser) readEncodedHash(parse func([]byte) ([]string, error)) (map[string]string, error) {
+	vals, err := p.readEncoded(parse)
+	if err != nil {
+		return nil, err
+	}
+	return toMap(vals)
+}
+
+func (p *Parser) readQuicklist(v2 bool) ([]string, error) {
+	n, err := p.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	var vals []string
+	for i := uint64(0); i < n; i++ {
+		container := uint64(quicklistNodePacked)
+		if v2 {
+			container, err = p.readLength()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		b, err := p.readBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case container == quicklistNodePlain:
+			vals = append(vals, string(b))
+		case v2:
+			node, err := parseListpack(b)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, node...)
+		default:
+			node, err := parseZiplist(b)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, node...)
+		}
+	}
+	return vals, nil
+}
+
+func (p *Parser) readZSet(binaryScore bool) ([]Z, error) {
+	n, err := p.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	zz := make([]Z, 0, capHint(n))
+	for i := uint64(0); i < n; i++ {
+		member, err := p.readString()
+		if err != nil {
+			return nil, err
+		}
+		score, err := p.readScore(binaryScore)
+		if err != nil {
+			return nil, err
+		}
+		zz = append(zz, Z{Score: score, Member: member})
+	}
+	return zz, nil
+}
+
+func (p *Parser) readScore(binaryScore bool) (float64, error) {
+	if binaryScore {
+		b, err := p.readN(8)
+		if err != nil {
+			return 0, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	}
+
+	n, err := p.rd.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	switch n {
+	case 253:
+		return math.NaN(), nil
+	case 254:
+		return math.Inf(1), nil
+	case 255:
+		return math.Inf(-1), nil
+	}
+	b, err := p.readN(uint64(n))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(string(b), 64)
+}
+
+func (p *Parser) skipN(n uint64) error {
+	if n > math.MaxInt64 {
+		return errCorrupted
+	}
+	_, err := io.CopyN(ioutil.Discard, p.rd, int64(n))
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (p *Parser) skipString() error {
+	n, encoded, err := p.readLengthEncoded()
+	if err != nil {
+		return err
+	}
+	if !encoded {
+		return p.skipN(n)
+	}
+
+	switch n {
+	case 0:
+		return p.skipN(1)
+	case 1:
+		return p.skipN(2)
+	case 2:
+		return p.skipN(4)
+	case 3:
+		clen, err := p.readLength()
+		if err != nil {
+			return err
+		}
+		if _, err := p.readLength(); err != nil {
+			return err
+		}
+		return p.skipN(clen)
+	}
+	return fmt.Errorf("rdb: string encoding %d is not supported", n)
+}
+
+// skipStream skips stream entries, consumer groups and their pending
+// entries.
+func (p *Parser) skipStream(typ byte) error {
+	n, err := p.readLength()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < n; i++ {
+		// Node key and listpack of entries.
+		if err := p.skipString(); err != nil {
+			return err
+		}
+		if err := p.skipString(); err != nil {
+			return err
+		}
+	}
+
+	// Length and last ID. Newer versions add first ID, max deleted ID
+	// and number of added entries.
+	lengths := 3
+	if typ >= typeStreamListpacks2 {
+		lengths += 5
+	}
+	if err := p.skipLengths(lengths); err != nil {
+		return err
+	}
+
+	groups, err := p.readLength()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < groups; i++ {
+		if err := p.skipString(); err != nil {
+			return err
+		}
+		// Last delivered ID and number of read entries.
+		lengths := 2
+		if typ >= typeStreamListpacks2 {
+			lengths++
+		}
+		if err := p.skipLengths(lengths); err != nil {
+			return err
+		}
+
+		// Pending entries with delivery time and count.
+		pending, err := p.readLength()
+		if err != nil {
+			return err
+		}
+		for j := uint64(0); j < pending; j++ {
+			if err := p.skipN(16 + 8); err != nil {
+				return err
+			}
+			if err := p.skipLengths(1); err != nil {
+				return err
+			}
+		}
+
+		consumers, err := p.readLength()
+		if err != nil {
+			return err
+		}
+		for j := uint64(0); j < consumers; j++ {
+			if err := p.skipString(); err != nil {
+				return err
+			}
+			// Seen time and active time.
+			times := uint64(8)
+			if typ >= typeStreamListpacks3 {
+				times += 8
+			}
+			if err := p.skipN(times); err != nil {
+				return err
+			}
+			// IDs of pending entries.
+			ids, err := p.readLength()
+			if err != nil {
+				return err
+			}
+			for k := uint64(0); k < ids; k++ {
+				if err := p.skipN(16); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+const (
+	moduleOpcodeEOF    = 0
+	moduleOpcodeSInt   = 1
+	moduleOpcodeUInt   = 2
+	moduleOpcodeFloat  = 3
+	moduleOpcodeDouble = 4
+	moduleOpcodeString = 5
+)
+
+// skipModuleValue skips module ID and value, which is a sequence of
+// typed fields terminated by the EOF opcode.
+func (p *Parser) skipModuleValue() error {
+	if err := p.skipLengths(1); err != nil {
+		return err
+	}
+	return p.skipModuleFields()
+}
+
+func (p *Parser) skipModuleAux() error {
+	// Module ID, when opcode and when.
+	if err := p.skipLengths(3); err != nil {
+		return err
+	}
+	return p.skipModuleFields()
+}
+
+func (p *Parser) skipModuleFields() error {
+	for {
+		opcode, err := p.readLength()
+		if err != nil {
+			return err
+		}
+		switch opcode {
+		case moduleOpcodeEOF:
+			return nil
+		case moduleOpcodeSInt, moduleOpcodeUInt:
+			err = p.skipLengths(1)
+		case moduleOpcodeFloat:
+			err = p.skipN(4)
+		case moduleOpcodeDouble:
+			err = p.skipN(8)
+		case moduleOpcodeString:
+			err = p.skipString()
+		default:
+			return errCorrupted
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func toMap(vals []string) (map[string]string, error) {
+	if len(vals)%2 != 0 {
+		return nil, errCorrupted
+	}
+	m := make(map[string]string, len(vals)/2)
+	for i := 0; i < len(vals); i += 2 {
+		m[vals[i]] = vals[i+1]
+	}
+	return m, nil
+}
+
+func toZ(vals []string) ([]Z, error) {
+	if len(vals)%2 != 0 {
+		return nil, errCorrupted
+	}
+	zz := make([]Z, 0, len(vals)/2)
+	for i := 0; i < len(vals); i += 2 {
+		score, err := strconv.ParseFloat(vals[i+1], 64)
+		if err != nil {
+			return nil, err
+		}
+		zz = append(zz, Z{Score: score, Member: vals[i]})
+	}
+	return zz, nil
+}
+
+// Parse reads all records from rd and calls fn for each of them.
+func Parse(rd io.Reader, fn func(*Record) error) error {
+	return parse(NewParser(rd), fn)
+}
+
+// Handler returns a function that parses RDB payload and calls fn for
+// each record. It can be used as replica.Options.OnRDB.
+func Handler(fn func(*Record) error) func(version int, rd io.Reader) error {
+	return func(version int, rd io.Reader) error {
+		p := NewParser(rd)
+		p.version = version
+		return parse(p, fn)
+	}
+}
+
+func parse(p *Parser, fn func(*Record) error) error {
+	for {
+		rec, err := p.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+}
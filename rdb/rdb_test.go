@@ -0,0 +1,358 @@
+package rdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// builder writes RDB payloads for tests.
+type builder struct {
+	bytes.Buffer
+}
+
+func newBuilder() *builder {
+	b := &builder{}
+	b.WriteString("REDIS0011")
+	return b
+}
+
+func (b *builder) length(n int) *builder {
+	switch {
+	case n < 1<<6:
+		b.WriteByte(byte(n))
+	case n < 1<<14:
+		b.WriteByte(byte(n>>8) | 0x40)
+		b.WriteByte(byte(n))
+	default:
+		b.WriteByte(0x80)
+		binary.Write(b, binary.BigEndian, uint32(n))
+	}
+	return b
+}
+
+func (b *builder) str(s string) *builder {
+	b.length(len(s))
+	b.WriteString(s)
+	return b
+}
+
+func (b *builder) op(c byte) *builder {
+	b.WriteByte(c)
+	return b
+}
+
+func (b *builder) eof() []byte {
+	b.WriteByte(opEOF)
+	b.Write(make([]byte, 8))
+	return b.Bytes()
+}
+
+func listpack(vals ...interface{}) string {
+	var entries bytes.Buffer
+	for _, v := range vals {
+		switch v := v.(type) {
+		case int:
+			// 7 bit unsigned integer.
+			entries.WriteByte(byte(v))
+			entries.WriteByte(1)
+		case string:
+			// 6 bit string.
+			entries.WriteByte(0x80 | byte(len(v)))
+			entries.WriteString(v)
+			entries.WriteByte(byte(1 + len(v)))
+		}
+	}
+	entries.WriteByte(0xff)
+
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, uint32(6+entries.Len()))
+	binary.Write(&b, binary.LittleEndian, uint16(len(vals)))
+	b.Write(entries.Bytes())
+	return b.String()
+}
+
+func ziplist(vals ...interface{}) string {
+	var entries bytes.Buffer
+	prevlen := 0
+	for _, v := range vals {
+		entries.WriteByte(byte(prevlen))
+		switch v := v.(type) {
+		case int:
+			// 4 bit immediate integer.
+			entries.WriteByte(0xf1 + byte(v))
+			prevlen = 2
+		case string:
+			// 6 bit string.
+			entries.WriteByte(byte(len(v)))
+			entries.WriteString(v)
+			prevlen = 2 + len(v)
+		}
+	}
+	entries.WriteByte(0xff)
+
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, uint32(10+entries.Len()))
+	binary.Write(&b, binary.LittleEndian, uint32(0))
+	binary.Write(&b, binary.LittleEndian, uint16(len(vals)))
+	b.Write(entries.Bytes())
+	return b.String()
+}
+
+func parseAll(t *testing.T, payload []byte) []*Record {
+	var recs []*Record
+	err := Parse(bytes.NewReader(payload), func(rec *Record) error {
+		recs = append(recs, rec)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return recs
+}
+
+func TestStrings(t *testing.T) {
+	b := newBuilder()
+	b.op(opAux).str("redis-ver").str("7.0.0")
+	b.op(opSelectDB).length(3)
+	b.op(opResizeDB).length(3).length(1)
+
+	b.op(typeString).str("key1").str("value")
+
+	b.op(opExpireTime)
+	binary.Write(b, binary.LittleEndian, uint64(1500000000123))
+	b.op(typeString).str("key2")
+	// Integer encoded as 16 bit.
+	b.op(0xc1)
+	binary.Write(b, binary.LittleEndian, int16(-1234))
+
+	b.op(typeString).str("key3")
+	// LZF compressed "aaaaaaaaaa": literal "a" and back reference of 9 bytes.
+	b.op(0xc3).length(5).length(10)
+	b.Write([]byte{0x00, 'a', 0xe0, 0x00, 0x00})
+
+	p := NewParser(bytes.NewReader(b.eof()))
+	var recs []*Record
+	for {
+		rec, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, rec)
+	}
+
+	if p.Version() != 11 {
+		t.Errorf("got version %d, wanted 11", p.Version())
+	}
+	if p.Aux()["redis-ver"] != "7.0.0" {
+		t.Errorf("got aux %v", p.Aux())
+	}
+	if len(recs) != 3 {
+		t.Fatalf("got %d records, wanted 3", len(recs))
+	}
+
+	wanted := &Record{DB: 3, Key: "key1", Type: String, Encoding: EncodingRaw, Value: "value"}
+	if !reflect.DeepEqual(recs[0], wanted) {
+		t.Errorf("got %+v, wanted %+v", recs[0], wanted)
+	}
+	if recs[1].Value != "-1234" {
+		t.Errorf("got %q, wanted -1234", recs[1].Value)
+	}
+	if !recs[1].ExpireAt.Equal(time.Unix(1500000000, 123*int64(time.Millisecond))) {
+		t.Errorf("got %s", recs[1].ExpireAt)
+	}
+	if recs[2].Value != "aaaaaaaaaa" {
+		t.Errorf("got %q, wanted aaaaaaaaaa", recs[2].Value)
+	}
+	if !recs[2].ExpireAt.IsZero() {
+		t.Errorf("got %s, wanted zero", recs[2].ExpireAt)
+	}
+}
+
+func TestCollections(t *testing.T) {
+	b := newBuilder()
+
+	b.op(typeList).str("list").length(2).str("a").str("b")
+	b.op(typeListQuicklist2).str("quicklist2").length(2)
+	b.length(quicklistNodePacked).str(listpack("a", 1))
+	b.length(quicklistNodePlain).str("big")
+	b.op(typeListQuicklist).str("quicklist").length(1).str(ziplist("a", 2))
+
+	b.op(typeSetIntset).str("intset")
+	var intset bytes.Buffer
+	binary.Write(&intset, binary.LittleEndian, []int32{4, 2, -70000, 70000})
+	b.str(intset.String())
+	b.op(typeSetListpack).str("setlp").str(listpack("x", "y"))
+
+	b.op(typeZSet2).str("zset2").length(1).str("m")
+	binary.Write(b, binary.LittleEndian, math.Float64bits(1.5))
+	b.op(typeZSet).str("zset").length(2).str("m1")
+	b.op(3).WriteString("2.5")
+	b.str("m2").op(254)
+	b.op(typeZSetListpack).str("zsetlp").str(listpack("m", 7))
+
+	b.op(typeHash).str("hash").length(1).str("f").str("v")
+	b.op(typeHashListpack).str("hashlp").str(listpack("f", "v", "n", 1))
+	b.op(typeHashZiplist).str("hashzl").str(ziplist("f", "v"))
+	b.op(typeHashZipmap).str("hashzm").str("\x01\x01f\x01\x02v\x00\x00\xff")
+
+	recs := parseAll(t, b.eof())
+
+	wanted := []struct {
+		typ      Type
+		encoding Encoding
+		value    interface{}
+	}{
+		{List, EncodingLinkedList, []string{"a", "b"}},
+		{List, EncodingQuicklist, []string{"a", "1", "big"}},
+		{List, EncodingQuicklist, []string{"a", "2"}},
+		{Set, EncodingIntset, []string{"-70000", "70000"}},
+		{Set, EncodingListpack, []string{"x", "y"}},
+		{ZSet, EncodingSkiplist, []Z{{1.5, "m"}}},
+		{ZSet, EncodingSkiplist, []Z{{2.5, "m1"}, {math.Inf(1), "m2"}}},
+		{ZSet, EncodingListpack, []Z{{7, "m"}}},
+		{Hash, EncodingHashtable, map[string]string{"f": "v"}},
+		{Hash, EncodingListpack, map[string]string{"f": "v", "n": "1"}},
+		{Hash, EncodingZiplist, map[string]string{"f": "v"}},
+		{Hash, EncodingZipmap, map[string]string{"f": "v"}},
+	}
+	if len(recs) != len(wanted) {
+		t.Fatalf("got %d records, wanted %d", len(recs), len(wanted))
+	}
+	for i, w := range wanted {
+		rec := recs[i]
+		if rec.Type != w.typ || rec.Encoding != w.encoding {
+			t.Errorf("%s: got %s/%s, wanted %s/%s", rec.Key, rec.Type, rec.Encoding, w.typ, w.encoding)
+		}
+		if !reflect.DeepEqual(rec.Value, w.value) {
+			t.Errorf("%s: got %v, wanted %v", rec.Key, rec.Value, w.value)
+		}
+	}
+}
+
+func TestSkipUnsupported(t *testing.T) {
+	b := newBuilder()
+
+	// Module aux data with a single unsigned integer field.
+	b.op(opModuleAux).length(1).length(2).length(2)
+	b.length(2).length(42).length(0)
+
+	b.op(typeStreamListpacks3).str("stream")
+	b.length(1).str("node").str(listpack("f", "v"))
+	// Length, last ID, first ID, max deleted ID and entries added.
+	b.length(1).length(100).length(0).length(100).length(0).length(0).length(0).length(1)
+	// Consumer group with one pending entry.
+	b.length(1).str("group").length(100).length(0).length(1)
+	b.length(1)
+	b.Write(make([]byte, 16+8))
+	b.length(1)
+	// Consumer with seen and active time and one pending entry.
+	b.length(1).str("consumer")
+	b.Write(make([]byte, 8+8))
+	b.length(1)
+	b.Write(make([]byte, 16))
+
+	b.op(typeModule2).str("module").length(1)
+	b.length(5).str("value").length(4)
+	b.Write(make([]byte, 8))
+	b.length(0)
+
+	b.op(typeString).str("key").str("value")
+
+	recs := parseAll(t, b.eof())
+	if len(recs) != 3 {
+		t.Fatalf("got %d records, wanted 3", len(recs))
+	}
+	wanted := []*Record{
+		{Key: "stream", Type: Stream, Encoding: EncodingListpack},
+		{Key: "module", Type: Module},
+		{Key: "key", Type: String, Encoding: EncodingRaw, Value: "value"},
+	}
+	for i, w := range wanted {
+		if !reflect.DeepEqual(recs[i], w) {
+			t.Errorf("got %+v, wanted %+v", recs[i], w)
+		}
+	}
+}
+
+func TestBacklenSize(t *testing.T) {
+	tests := []struct {
+		n, size int
+	}{
+		{127, 1},
+		{128, 2},
+		{16382, 2},
+		{16383, 3},
+		{2097150, 3},
+		{2097151, 4},
+		{268435454, 4},
+		{268435455, 5},
+	}
+	for _, test := range tests {
+		if got := backlenSize(test.n); got != test.size {
+			t.Errorf("backlenSize(%d) = %d, wanted %d", test.n, got, test.size)
+		}
+	}
+}
+
+func TestCorrupted(t *testing.T) {
+	b := newBuilder()
+	b.op(typeHashListpack).str("key").str(listpack("f", "v")[:8])
+
+	err := Parse(bytes.NewReader(b.eof()), func(*Record) error {
+		return nil
+	})
+	if err != errCorrupted {
+		t.Fatalf("got %v, wanted %v", err, errCorrupted)
+	}
+}
+
+func TestHugeLengths(t *testing.T) {
+	payloads := map[string][]byte{}
+
+	b := newBuilder()
+	b.op(typeString).str("key").op(0x81)
+	binary.Write(b, binary.BigEndian, uint64(1<<62))
+	payloads["string"] = b.Bytes()
+
+	b = newBuilder()
+	b.op(typeList).str("key").op(0x81)
+	binary.Write(b, binary.BigEndian, uint64(1<<62))
+	payloads["list"] = b.Bytes()
+
+	b = newBuilder()
+	b.op(typeString).str("key").op(0xc3).length(1)
+	b.op(0x81)
+	binary.Write(b, binary.BigEndian, uint64(1<<62))
+	b.WriteByte(0)
+	payloads["lzf"] = b.eof()
+
+	b = newBuilder()
+	var intset bytes.Buffer
+	binary.Write(&intset, binary.LittleEndian, []uint32{8, 1 << 31})
+	b.op(typeSetIntset).str("key").str(intset.String())
+	payloads["intset"] = b.eof()
+
+	for name, payload := range payloads {
+		err := Parse(bytes.NewReader(payload), func(*Record) error {
+			return nil
+		})
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestInvalidHeader(t *testing.T) {
+	_, err := ReadHeader(bytes.NewReader([]byte("RDB000001")))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,91 @@
+package redis
+
+// readOnlyCommands are commands that don't modify data.
+var readOnlyCommands = map[string]bool{
+	"bitcount":             true,
+	"bitfield_ro":          true,
+	"bitpos":               true,
+	"dbsize":               true,
+	"dump":                 true,
+	"echo":                 true,
+	"exists":               true,
+	"geodist":              true,
+	"geohash":              true,
+	"geopos":               true,
+	"georadius_ro":         true,
+	"georadiusbymember_ro": true,
+	"geosearch":            true,
+	"get":                  true,
+	"getbit":               true,
+	"getrange":             true,
+	"hexists":              true,
+	"hget":                 true,
+	"hgetall":              true,
+	"hkeys":                true,
+	"hlen":                 true,
+	"hmget":                true,
+	"hpttl":                true,
+	"hrandfield":           true,
+	"hscan":                true,
+	"hstrlen":              true,
+	"httl":                 true,
+	"hvals":                true,
+	"keys":                 true,
+	"lcs":                  true,
+	"lindex":               true,
+	"llen":                 true,
+	"lpos":                 true,
+	"lrange":               true,
+	"mget":                 true,
+	"object":               true,
+	"pfcount":              true,
+	"ping":                 true,
+	"pttl":                 true,
+	"randomkey":            true,
+	"scan":                 true,
+	"scard":                true,
+	"sdiff":                true,
+	"sinter":               true,
+	"sismember":            true,
+	"smembers":             true,
+	"smismember":           true,
+	"sort_ro":              true,
+	"srandmember":          true,
+	"sscan":                true,
+	"strlen":               true,
+	"substr":               true,
+	"sunion":               true,
+	"touch":                true,
+	"ttl":                  true,
+	"type":                 true,
+	"xinfo":                true,
+	"xlen":                 true,
+	"xpending":             true,
+	"xrange":               true,
+	"xread":                true,
+	"xrevrange":            true,
+	"zcard":                true,
+	"zcount":               true,
+	"zdiff":                true,
+	"zinter":               true,
+	"zintercard":           true,
+	"zlexcount":            true,
+	"zrandmember":          true,
+	"zrange":               true,
+	"zrangebylex":          true,
+	"zrangebyscore":        true,
+	"zrank":                true,
+	"zrevrange":            true,
+	"zrevrangebylex":       true,
+	"zrevrangebyscore":     true,
+	"zrevrank":             true,
+	"zscan":                true,
+	"zscore":               true,
+	"zunion":               true,
+}
+
+// isReadOnlyCommand reports whether command with lower-cased name
+// doesn't modify data.
+func isReadOnlyCommand(name string) bool {
+	return readOnlyCommands[name]
+}
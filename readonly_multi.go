@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+)
+
+func checkReadOnlyCmds(cmds []Cmder) error {
+	for _, cmd := range cmds {
+		name := cmd.Name()
+		if !isReadOnlyCommand(name) {
+			err := fmt.Errorf("redis: %s is not allowed in read-only transaction", strings.ToUpper(name))
+			setCmdsErr(cmds, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadOnlyMulti is like Multi, but Exec fails without sending commands
+// to the server when any queued command modifies data. It is used to
+// read a consistent snapshot of multiple keys, e.g. from a replica.
+func (c *Client) ReadOnlyMulti() *Multi {
+	multi := c.Multi()
+	multi.readOnly = true
+	return multi
+}
+
+// ReadOnlyMulti returns a read-only transaction executed on a replica
+// serving the slot of the key, or on the master if the slot has no
+// replicas. All keys used in the transaction must be in the same slot.
+// See Client.ReadOnlyMulti.
+func (c *ClusterClient) ReadOnlyMulti(key string) (*Multi, error) {
+	client, err := c.getClient(c.slotReplicaAddr(hashSlot(key)))
+	if err != nil {
+		return nil, err
+	}
+
+	multi := client.ReadOnlyMulti()
+	// Allow the connection to serve reads of the replica slots.
+	if err := multi.ReadOnly().Err(); err != nil {
+		multi.Close()
+		return nil, err
+	}
+	return multi, nil
+}
@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"sync"
 	"time"
 )
 
 type baseClient struct {
 	connPool pool
 	opt      *Options
+	poolName string
+
+	state   *connState
+	offline *offlineQueue
 }
 
 func (c *baseClient) String() string {
@@ -17,7 +22,64 @@ func (c *baseClient) String() string {
 }
 
 func (c *baseClient) conn() (*conn, error) {
-	return c.connPool.Get()
+	cn, err := c.getConn()
+	if err != nil {
+		return nil, err
+	}
+	if cn.db != c.opt.DB {
+		if err := c.selectDB(cn); err != nil {
+			c.connPool.Remove(cn)
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+func (c *baseClient) getConn() (*conn, error) {
+	if c.offline != nil && c.offline.active() {
+		return c.offline.conn()
+	}
+	cn, err := c.connPool.Get()
+	if err != nil {
+		c.connFailed(err)
+		if c.offline != nil && c.offline.queues(err) {
+			return c.offline.conn()
+		}
+	}
+	return cn, err
+}
+
+// selectDB selects the database of the client on a connection that was
+// used by a client bound to another database, see Client.DB.
+func (c *baseClient) selectDB(cn *conn) error {
+	cn.WriteTimeout = c.opt.WriteTimeout
+	cn.ReadTimeout = c.opt.ReadTimeout
+
+	cmd := newKeylessStatusCmd("SELECT", formatInt(c.opt.DB))
+	if err := cn.writeCmds(cmd); err != nil {
+		return err
+	}
+	if err := cmd.parseReply(cn.rd); err != nil {
+		return err
+	}
+	cn.db = c.opt.DB
+	return nil
+}
+
+// connFailed reports failures to get a connection from the pool, i.e.
+// dial and connection setup failures other than timeouts, to the
+// connection state tracker. Errors of pooled connections, e.g. EOF of
+// a connection closed by the server, are not reported, because they
+// are handled by retries and the next dial reports the server if it
+// is really unreachable.
+func (c *baseClient) connFailed(err error) {
+	if c.state == nil {
+		return
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return
+	}
+	c.state.disconnected(err)
 }
 
 func (c *baseClient) putConn(cn *conn, ei error) {
@@ -37,16 +99,43 @@ func (c *baseClient) putConn(cn *conn, ei error) {
 }
 
 func (c *baseClient) process(cmd Cmder) {
+	if c.opt.KeyPolicy != nil {
+		if err := c.opt.KeyPolicy.Check(cmd); err != nil {
+			cmd.setErr(err)
+			return
+		}
+	}
+	if c.opt.DisableDestructiveCommands {
+		if err := checkDestructive(cmd); err != nil {
+			cmd.setErr(err)
+			return
+		}
+	}
+
 	for i := 0; i <= c.opt.MaxRetries; i++ {
 		if i > 0 {
 			cmd.reset()
 		}
 
+		info := ProcessInfo{
+			Cmd:     cmd,
+			Family:  cmd.Name(),
+			Addr:    c.opt.Addr,
+			Pool:    c.poolName,
+			Attempt: i,
+		}
+		clock := c.opt.getClock()
+		start := clock.Now()
+
 		cn, err := c.conn()
 		if err != nil {
 			cmd.setErr(err)
+			info.PoolWait = since(clock, start)
+			c.onProcess(&info)
 			return
 		}
+		info.Addr = cn.RemoteAddr().String()
+		info.PoolWait = since(clock, start)
 
 		if timeout := cmd.writeTimeout(); timeout != nil {
 			cn.WriteTimeout = *timeout
@@ -54,23 +143,44 @@ func (c *baseClient) process(cmd Cmder) {
 			cn.WriteTimeout = c.opt.WriteTimeout
 		}
 
+		adaptive := c.opt.AdaptiveTimeout
 		if timeout := cmd.readTimeout(); timeout != nil {
 			cn.ReadTimeout = *timeout
+			adaptive = nil
+		} else if adaptive != nil {
+			cn.ReadTimeout = adaptive.Timeout(info.Family, c.opt.ReadTimeout)
 		} else {
 			cn.ReadTimeout = c.opt.ReadTimeout
 		}
 
+		start = clock.Now()
 		if err := cn.writeCmds(cmd); err != nil {
 			c.putConn(cn, err)
 			cmd.setErr(err)
+			info.Write = since(clock, start)
+			c.onProcess(&info)
 			if shouldRetry(err) {
 				continue
 			}
 			return
 		}
+		info.Write = since(clock, start)
 
+		start = clock.Now()
+		if c.opt.OnProcess != nil {
+			// Wait for the first byte of the reply to separate server
+			// time from the time spent reading the reply.
+			cn.rd.Peek(1)
+			info.Server = since(clock, start)
+			start = clock.Now()
+		}
 		err = cmd.parseReply(cn.rd)
+		info.Read = since(clock, start)
+		if adaptive != nil {
+			adaptive.observe(info.Family, info.Server+info.Read, err)
+		}
 		c.putConn(cn, err)
+		c.onProcess(&info)
 		if shouldRetry(err) {
 			continue
 		}
@@ -79,6 +189,12 @@ func (c *baseClient) process(cmd Cmder) {
 	}
 }
 
+func (c *baseClient) onProcess(info *ProcessInfo) {
+	if c.opt.OnProcess != nil {
+		c.opt.OnProcess(info)
+	}
+}
+
 // Close closes the client, releasing any open resources.
 func (c *baseClient) Close() error {
 	return c.connPool.Close()
@@ -97,11 +213,27 @@ type Options struct {
 	// Network and Addr options.
 	Dialer func() (net.Conn, error)
 
+	// Name of DNS SRV record, e.g. "_redis._tcp.example.com", used to
+	// discover server address. Targets are dialed in order of their
+	// priority. It has priority over Addr option.
+	DiscoverSRV string
+	// Specifies how long addresses discovered via DNS SRV are cached.
+	// Default is 30 seconds.
+	SRVRefreshInterval time.Duration
+
 	// An optional password. Must match the password specified in the
 	// requirepass server configuration option.
 	Password string
 	// A database to be selected after connecting to server.
 	DB int64
+	// Enables read-only queries on a Redis Cluster replica node by
+	// sending READONLY after connecting to server.
+	ReadOnly bool
+	// Optional name set with CLIENT SETNAME on new connections. Every
+	// connection is named "<ClientName>-<pool>-<conn>", where pool and
+	// conn are sequence numbers unique within the process, so
+	// connections can be matched with CLIENT LIST, e.g. by ConnLeaks.
+	ClientName string
 
 	// The maximum number of retries before giving up.
 	// Default is to not retry failed commands.
@@ -116,6 +248,11 @@ type Options struct {
 	// Sets the deadline for socket writes. If reached, commands will
 	// fail with a timeout instead of blocking.
 	WriteTimeout time.Duration
+	// Optional adaptive read timeouts derived from observed latency of
+	// each command family. ReadTimeout is used until enough samples
+	// are collected and as the upper bound. Commands with explicit
+	// timeouts, e.g. blocking commands, are not affected.
+	AdaptiveTimeout *AdaptiveTimeout
 
 	// The maximum number of socket connections.
 	// Default is 10 connections.
@@ -128,6 +265,84 @@ type Options struct {
 	// connections. Should be less than server's timeout.
 	// Default is to not close idle connections.
 	IdleTimeout time.Duration
+
+	// The maximum number of commands waiting for a connection while
+	// it can't be established, e.g. when server is restarting. Queued
+	// commands get connections in order once the server is reachable.
+	// Default is to fail commands immediately.
+	OfflineQueueSize int
+	// Specifies amount of time a command waits in the offline queue
+	// before failing.
+	// Default is 5 seconds.
+	OfflineQueueTimeout time.Duration
+
+	// Minimum backoff between attempts to re-establish connection
+	// after the server became unreachable. Backoff is doubled after
+	// every failed attempt.
+	// Default is 100 milliseconds.
+	MinReconnectBackoff time.Duration
+	// Maximum backoff between attempts to re-establish connection.
+	// Default is 5 seconds.
+	MaxReconnectBackoff time.Duration
+
+	// Optional hook that is called after every attempt to process a
+	// command with a timing breakdown of the attempt. It is not
+	// called for pipelined and transactional commands.
+	OnProcess func(*ProcessInfo)
+
+	// Optional policy that validates keys before commands are sent.
+	// Commands using keys that are not allowed fail without being
+	// sent to the server.
+	KeyPolicy *KeyPolicy
+	// Rejects commands deleting all keys, i.e. FLUSHDB and FLUSHALL,
+	// so clients created from shared configuration can't wipe the
+	// database by accident.
+	DisableDestructiveCommands bool
+
+	// Source of time used for idle connection reaping, reconnect
+	// backoff, adaptive timeouts, offline queue timeout and
+	// MaintenancePause.
+	// Default is the system clock.
+	Clock Clock
+
+	// Hooks called around marshaling of command arguments and
+	// unmarshaling of replies by Scan.
+	CodecHooks *CodecHooks
+	// Encoding of time, duration and gob values used as command
+	// arguments and decoded by Scan.
+	// Default is Encoding zero value.
+	Encoding *Encoding
+}
+
+// ProcessInfo describes a single attempt to process a command and is
+// passed to the Options.OnProcess hook.
+type ProcessInfo struct {
+	Cmd Cmder
+	// Command family, i.e. lower-cased command name such as "get".
+	Family string
+	// Address of the node that processed the command.
+	Addr string
+	// Name of the sub-pool created by SubPool that processed the
+	// command. Empty for the main pool.
+	Pool string
+	// Attempt number starting from 0. Non-zero values mean that the
+	// command is retried.
+	Attempt int
+
+	// Time spent waiting for a connection from the pool.
+	PoolWait time.Duration
+	// Time spent writing the command to the socket.
+	Write time.Duration
+	// Time spent waiting for the first byte of the reply, i.e.
+	// server processing time plus network round trip.
+	Server time.Duration
+	// Time spent reading and parsing the rest of the reply.
+	Read time.Duration
+}
+
+// Total returns total time spent processing the command.
+func (info *ProcessInfo) Total() time.Duration {
+	return info.PoolWait + info.Write + info.Server + info.Read
 }
 
 func (opt *Options) getNetwork() string {
@@ -138,6 +353,12 @@ func (opt *Options) getNetwork() string {
 }
 
 func (opt *Options) getDialer() func() (net.Conn, error) {
+	if opt.Dialer == nil && opt.DiscoverSRV != "" {
+		srv := newSRVResolver(opt.DiscoverSRV, opt.SRVRefreshInterval)
+		opt.Dialer = func() (net.Conn, error) {
+			return srv.dial(opt.getNetwork(), opt.getDialTimeout())
+		}
+	}
 	if opt.Dialer == nil {
 		opt.Dialer = func() (net.Conn, error) {
 			return net.DialTimeout(opt.getNetwork(), opt.Addr, opt.getDialTimeout())
@@ -167,15 +388,52 @@ func (opt *Options) getPoolTimeout() time.Duration {
 	return opt.PoolTimeout
 }
 
+func (opt *Options) getClock() Clock {
+	if opt.Clock == nil {
+		return systemClock{}
+	}
+	return opt.Clock
+}
+
 func (opt *Options) getIdleTimeout() time.Duration {
 	return opt.IdleTimeout
 }
 
+func (opt *Options) getMinReconnectBackoff() time.Duration {
+	if opt.MinReconnectBackoff == 0 {
+		return 100 * time.Millisecond
+	}
+	return opt.MinReconnectBackoff
+}
+
+func (opt *Options) getMaxReconnectBackoff() time.Duration {
+	if opt.MaxReconnectBackoff == 0 {
+		return 5 * time.Second
+	}
+	return opt.MaxReconnectBackoff
+}
+
+func (opt *Options) getOfflineQueueTimeout() time.Duration {
+	if opt.OfflineQueueTimeout == 0 {
+		return 5 * time.Second
+	}
+	return opt.OfflineQueueTimeout
+}
+
 //------------------------------------------------------------------------------
 
 type Client struct {
 	*baseClient
 	commandable
+
+	dbs   map[int64]*Client
+	pools map[string]*Client
+	dbsMx sync.Mutex // Protects dbs and pools.
+
+	// Set for clients returned by DB, which don't own the pool.
+	shared bool
+
+	noGetDel int32 // Set by ConsumeToken when GETDEL is not supported.
 }
 
 func newClient(opt *Options, pool pool) *Client {
@@ -186,7 +444,63 @@ func newClient(opt *Options, pool pool) *Client {
 	}
 }
 
+// DB returns a client that runs commands against database n. Unlike
+// Select it is safe for concurrent use. The client shares options and
+// the connection pool with c: a connection is switched to database n
+// with SELECT when it is taken from the pool and was last used with
+// another database, so PoolSize limits connections of all databases
+// together. The client is created once per database and is closed
+// together with c; closing it has no effect.
+func (c *Client) DB(n int64) *Client {
+	if n == c.opt.DB {
+		return c
+	}
+
+	c.dbsMx.Lock()
+	defer c.dbsMx.Unlock()
+
+	if client, ok := c.dbs[n]; ok {
+		return client
+	}
+	if c.dbs == nil {
+		c.dbs = make(map[int64]*Client)
+	}
+	opt := *c.opt
+	opt.DB = n
+	client := newClient(&opt, c.connPool)
+	client.poolName = c.poolName
+	client.state = c.state
+	client.offline = c.offline
+	client.shared = true
+	c.dbs[n] = client
+	return client
+}
+
+// Close closes the client and clients returned by DB and SubPool,
+// releasing any open resources.
+//
+// It is rare to Close a Client, as the Client is meant to be
+// long-lived and shared between many goroutines.
+func (c *Client) Close() error {
+	if c.shared {
+		return nil
+	}
+
+	c.dbsMx.Lock()
+	for name, client := range c.pools {
+		if err := client.Close(); err != nil {
+			log.Printf("redis: closing sub-pool %q client failed: %s", name, err)
+		}
+		delete(c.pools, name)
+	}
+	c.dbsMx.Unlock()
+	return c.baseClient.Close()
+}
+
 func NewClient(opt *Options) *Client {
 	pool := newConnPool(opt)
-	return newClient(opt, pool)
+	client := newClient(opt, pool)
+	client.state = newConnState(opt, pool)
+	client.offline = newOfflineQueue(opt, pool, client.state)
+	return client
 }
@@ -2,7 +2,10 @@ package redis_test
 
 import (
 	"bytes"
+	"errors"
 	"net"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -134,6 +137,105 @@ var _ = Describe("Client", func() {
 		Expect(db1.FlushDb().Err()).NotTo(HaveOccurred())
 	})
 
+	It("should support DB views", func() {
+		db1 := client.DB(1)
+		Expect(client.DB(1) == db1).To(BeTrue())
+		Expect(client.DB(0) == client).To(BeTrue())
+		Expect(db1.String()).To(Equal("Redis<:6380 db:1>"))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				db := client.DB(int64(i % 2))
+				Expect(db.Incr("counter").Err()).NotTo(HaveOccurred())
+			}(i)
+		}
+		wg.Wait()
+
+		Expect(client.Get("counter").Val()).To(Equal("5"))
+		Expect(db1.Get("counter").Val()).To(Equal("5"))
+		Expect(db1.FlushDb().Err()).NotTo(HaveOccurred())
+		Expect(client.Del("counter").Err()).NotTo(HaveOccurred())
+
+		Expect(client.Close()).NotTo(HaveOccurred())
+		Expect(db1.Ping().Err()).To(MatchError("redis: client is closed"))
+	})
+
+	It("should share the pool with DB views", func() {
+		single := redis.NewClient(&redis.Options{
+			Addr:     redisAddr,
+			PoolSize: 1,
+		})
+		defer single.Close()
+
+		db1 := single.DB(1)
+		Expect(db1.Pool() == single.Pool()).To(BeTrue())
+
+		for i := 0; i < 3; i++ {
+			Expect(single.Set("key", "db0", 0).Err()).NotTo(HaveOccurred())
+			Expect(db1.Set("key", "db1", 0).Err()).NotTo(HaveOccurred())
+			Expect(single.Get("key").Val()).To(Equal("db0"))
+			Expect(db1.Get("key").Val()).To(Equal("db1"))
+		}
+		Expect(single.Pool().Len()).To(Equal(1))
+
+		Expect(db1.Close()).NotTo(HaveOccurred())
+		Expect(single.Ping().Err()).NotTo(HaveOccurred())
+
+		Expect(db1.FlushDb().Err()).NotTo(HaveOccurred())
+		Expect(single.Del("key").Err()).NotTo(HaveOccurred())
+	})
+
+	It("should support sub-pools", func() {
+		var pools []string
+		var mu sync.Mutex
+		parent := redis.NewClient(&redis.Options{
+			Addr:     redisAddr,
+			PoolSize: 10,
+			OnProcess: func(info *redis.ProcessInfo) {
+				mu.Lock()
+				pools = append(pools, info.Pool)
+				mu.Unlock()
+			},
+		})
+
+		blocking := parent.SubPool("blocking", &redis.SubPoolOptions{
+			PoolSize:    1,
+			PoolTimeout: 100 * time.Millisecond,
+		})
+		Expect(parent.SubPool("blocking", nil) == blocking).To(BeTrue())
+
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			err := blocking.BLPop(time.Second, "list").Err()
+			Expect(err).To(Equal(redis.Nil))
+		}()
+
+		Eventually(func() int {
+			return blocking.Pool().Len() - blocking.Pool().FreeLen()
+		}).Should(Equal(1))
+
+		// The blocking pool is exhausted, but the parent is not affected.
+		err := blocking.Ping().Err()
+		Expect(err).To(MatchError("redis: connection pool timeout"))
+		Expect(parent.Ping().Err()).NotTo(HaveOccurred())
+		Eventually(done, 2*time.Second).Should(BeClosed())
+
+		mu.Lock()
+		Expect(pools).To(ContainElement("blocking"))
+		Expect(pools).To(ContainElement(""))
+		mu.Unlock()
+
+		Expect(parent.Close()).NotTo(HaveOccurred())
+		Expect(blocking.Ping().Err()).To(MatchError("redis: client is closed"))
+	})
+
 	It("should support DB selection with read timeout (issue #135)", func() {
 		for i := 0; i < 100; i++ {
 			db1 := redis.NewClient(&redis.Options{
@@ -148,6 +250,169 @@ var _ = Describe("Client", func() {
 		}
 	})
 
+	It("should call OnProcess hook", func() {
+		var infos []redis.ProcessInfo
+		hooked := redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+			OnProcess: func(info *redis.ProcessInfo) {
+				infos = append(infos, *info)
+			},
+		})
+		defer hooked.Close()
+
+		Expect(hooked.Ping().Err()).NotTo(HaveOccurred())
+		Expect(infos).To(HaveLen(1))
+
+		info := infos[0]
+		Expect(info.Family).To(Equal("ping"))
+		Expect(info.Addr).To(ContainSubstring(redisPort))
+		Expect(info.Attempt).To(Equal(0))
+		Expect(info.Cmd.Err()).NotTo(HaveOccurred())
+		Expect(info.Server).To(BeNumerically(">", 0))
+		Expect(info.Total()).To(BeNumerically(">=", info.Server))
+	})
+
+	It("should use adaptive timeouts", func() {
+		adaptive := &redis.AdaptiveTimeout{
+			MinSamples: 10,
+			Min:        50 * time.Millisecond,
+		}
+		client := redis.NewClient(&redis.Options{
+			Addr:            redisAddr,
+			ReadTimeout:     time.Minute,
+			AdaptiveTimeout: adaptive,
+		})
+		defer client.Close()
+
+		debugSleep := func(dur string) error {
+			cmd := redis.NewStatusCmd("DEBUG", "SLEEP", dur)
+			client.Process(cmd)
+			return cmd.Err()
+		}
+
+		Expect(adaptive.Timeout("debug", time.Minute)).To(Equal(time.Minute))
+		for i := 0; i < 10; i++ {
+			Expect(debugSleep("0")).NotTo(HaveOccurred())
+		}
+		Expect(adaptive.Timeout("debug", time.Minute)).To(Equal(50 * time.Millisecond))
+		Expect(adaptive.Timeout("get", time.Minute)).To(Equal(time.Minute))
+
+		err := debugSleep("0.2")
+		Expect(err).To(HaveOccurred())
+		Expect(err.(net.Error).Timeout()).To(BeTrue())
+		time.Sleep(200 * time.Millisecond)
+	})
+
+	It("should queue commands while server is unreachable", func() {
+		var online int32
+		offline := redis.NewClient(&redis.Options{
+			Dialer: func() (net.Conn, error) {
+				if atomic.LoadInt32(&online) == 0 {
+					return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+				}
+				return net.Dial("tcp", redisAddr)
+			},
+			// One command is being served and one is waiting.
+			OfflineQueueSize:    1,
+			OfflineQueueTimeout: time.Second,
+		})
+		defer offline.Close()
+
+		errs := make(chan error, 3)
+		for i := 0; i < 3; i++ {
+			go func() {
+				errs <- offline.Ping().Err()
+			}()
+		}
+
+		err := <-errs
+		Expect(err).To(MatchError("redis: offline queue is full"))
+
+		time.Sleep(100 * time.Millisecond)
+		atomic.StoreInt32(&online, 1)
+		Expect(<-errs).NotTo(HaveOccurred())
+		Expect(<-errs).NotTo(HaveOccurred())
+	})
+
+	It("should fail queued commands after timeout", func() {
+		offline := redis.NewClient(&redis.Options{
+			Dialer: func() (net.Conn, error) {
+				return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+			},
+			OfflineQueueSize:    1,
+			OfflineQueueTimeout: 100 * time.Millisecond,
+		})
+		defer offline.Close()
+
+		start := time.Now()
+		err := offline.Ping().Err()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("redis: offline queue timeout"))
+		Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+	})
+
+	It("should reconnect with backoff and emit events", func() {
+		var online int32
+		flapping := redis.NewClient(&redis.Options{
+			Dialer: func() (net.Conn, error) {
+				if atomic.LoadInt32(&online) == 0 {
+					return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+				}
+				return net.Dial("tcp", redisAddr)
+			},
+			MinReconnectBackoff: 10 * time.Millisecond,
+			MaxReconnectBackoff: 20 * time.Millisecond,
+		})
+		defer flapping.Close()
+
+		events := flapping.ConnEvents(100)
+		defer events.Close()
+
+		Expect(flapping.Ping().Err()).To(HaveOccurred())
+
+		event := <-events.C
+		Expect(event.Type).To(Equal(redis.ConnDisconnected))
+		Expect(event.Err).To(MatchError("dial: connection refused"))
+
+		event = <-events.C
+		Expect(event.Type).To(Equal(redis.ConnReconnectAttempt))
+		Expect(event.Attempt).To(Equal(1))
+
+		atomic.StoreInt32(&online, 1)
+		Eventually(func() redis.ConnEventType {
+			return (<-events.C).Type
+		}, "1s").Should(Equal(redis.ConnConnected))
+		Expect(flapping.Ping().Err()).NotTo(HaveOccurred())
+	})
+
+	It("should reject destructive commands", func() {
+		safe := redis.NewClient(&redis.Options{
+			Addr:                       redisAddr,
+			DisableDestructiveCommands: true,
+		})
+		defer safe.Close()
+
+		Expect(safe.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+		err := safe.FlushDb().Err()
+		Expect(err).To(MatchError("redis: FLUSHDB is disabled by DisableDestructiveCommands option"))
+		err = safe.FlushAll().Err()
+		Expect(err).To(MatchError("redis: FLUSHALL is disabled by DisableDestructiveCommands option"))
+		err = safe.FlushDbAsync().Err()
+		Expect(err).To(MatchError("redis: FLUSHDB is disabled by DisableDestructiveCommands option"))
+		err = safe.FlushAllAsync().Err()
+		Expect(err).To(MatchError("redis: FLUSHALL is disabled by DisableDestructiveCommands option"))
+
+		_, err = safe.Pipelined(func(pipe *redis.Pipeline) error {
+			pipe.Ping()
+			pipe.FlushDb()
+			return nil
+		})
+		Expect(err).To(MatchError("redis: FLUSHDB is disabled by DisableDestructiveCommands option"))
+
+		Expect(safe.Get("key").Val()).To(Equal("hello"))
+	})
+
 	It("should retry command on network error", func() {
 		Expect(client.Close()).NotTo(HaveOccurred())
 
@@ -165,6 +430,20 @@ var _ = Describe("Client", func() {
 		err = client.Ping().Err()
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("should not report bad pooled connection as disconnect", func() {
+		events := client.ConnEvents(10)
+		defer events.Close()
+
+		cn, err := client.Pool().Get()
+		Expect(err).NotTo(HaveOccurred())
+		cn.SetNetConn(newBadNetConn())
+		Expect(client.Pool().Put(cn)).NotTo(HaveOccurred())
+
+		Expect(client.Ping().Err()).To(HaveOccurred())
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+		Consistently(events.C).ShouldNot(Receive())
+	})
 })
 
 //------------------------------------------------------------------------------
@@ -0,0 +1,430 @@
+// Package replica implements an experimental consumer of the Redis
+// replication stream. It connects to the master as a replica using
+// PSYNC, reads the RDB snapshot sent by the master and streams
+// subsequent write commands to a user callback. It can be used to
+// build CDC-style pipelines, e.g. cache invalidation or search
+// indexing fed directly from Redis replication.
+package replica
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/redis.v3/rdb"
+)
+
+var errClosed = errors.New("replica: replica is closed")
+
+const (
+	// maxArgs and maxArgLen bound commands read from the replication
+	// stream. maxArgLen is the default proto-max-bulk-len of Redis.
+	maxArgs   = 1 << 20
+	maxArgLen = 512 << 20
+	// maxPrealloc bounds allocations made before data is read, so
+	// truncated streams can't allocate maxArgLen bytes upfront.
+	maxPrealloc = 1 << 16
+)
+
+// Options are used to configure a replica and should be passed to Dial.
+type Options struct {
+	// The network type, either tcp or unix.
+	// Default is tcp.
+	Network string
+	// host:port address of the master.
+	Addr string
+
+	// Dialer creates new network connection and has priority over
+	// Network and Addr options.
+	Dialer func() (net.Conn, error)
+
+	// An optional password. Must match the password specified in the
+	// requirepass server configuration option.
+	Password string
+
+	// Replication ID and offset of the last command processed by the
+	// previous replica. When set, partial resynchronization is
+	// requested. Otherwise master sends full RDB snapshot.
+	ReplID string
+	Offset int64
+
+	// Optional callback that is called with RDB version and RDB payload
+	// on full resynchronization. Unread part of the payload is
+	// discarded. Use rdb.Handler to process payload as key records.
+	OnRDB func(version int, rd io.Reader) error
+
+	// Sets the deadline for establishing new connections.
+	// Default is 5 seconds.
+	DialTimeout time.Duration
+	// How often replica acknowledges processed offset to the master.
+	// Default is 1 second.
+	AckInterval time.Duration
+}
+
+func (opt *Options) getNetwork() string {
+	if opt.Network == "" {
+		return "tcp"
+	}
+	return opt.Network
+}
+
+func (opt *Options) getDialer() func() (net.Conn, error) {
+	if opt.Dialer == nil {
+		return func() (net.Conn, error) {
+			return net.DialTimeout(opt.getNetwork(), opt.Addr, opt.getDialTimeout())
+		}
+	}
+	return opt.Dialer
+}
+
+func (opt *Options) getDialTimeout() time.Duration {
+	if opt.DialTimeout == 0 {
+		return 5 * time.Second
+	}
+	return opt.DialTimeout
+}
+
+func (opt *Options) getAckInterval() time.Duration {
+	if opt.AckInterval == 0 {
+		return time.Second
+	}
+	return opt.AckInterval
+}
+
+// Command is a write command received from the master.
+type Command struct {
+	Args []string
+	// Replication offset after the command.
+	Offset int64
+}
+
+// Name returns lower-cased command name.
+func (c *Command) Name() string {
+	if len(c.Args) == 0 {
+		return ""
+	}
+	return strings.ToLower(c.Args[0])
+}
+
+func (c *Command) String() string {
+	return strings.Join(c.Args, " ")
+}
+
+// Replica is a connection to the master that speaks replication
+// protocol.
+type Replica struct {
+	opt *Options
+
+	netcn net.Conn
+	rd    *bufio.Reader
+	wrMx  sync.Mutex
+
+	replID string
+	offset int64 // protected by wrMx
+
+	closed chan struct{}
+}
+
+// Dial connects to the master and starts replication handshake.
+func Dial(opt *Options) (*Replica, error) {
+	netcn, err := opt.getDialer()()
+	if err != nil {
+		return nil, err
+	}
+	r := &Replica{
+		opt:    opt,
+		netcn:  netcn,
+		rd:     bufio.NewReader(netcn),
+		closed: make(chan struct{}),
+	}
+	if err := r.handshake(); err != nil {
+		netcn.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// ReplID returns replication ID of the master.
+func (r *Replica) ReplID() string {
+	return r.replID
+}
+
+// Offset returns replication offset of the last processed command.
+func (r *Replica) Offset() int64 {
+	r.wrMx.Lock()
+	offset := r.offset
+	r.wrMx.Unlock()
+	return offset
+}
+
+func (r *Replica) setOffset(offset int64) {
+	r.wrMx.Lock()
+	r.offset = offset
+	r.wrMx.Unlock()
+}
+
+func (r *Replica) handshake() error {
+	if _, err := r.call("PING"); err != nil {
+		return err
+	}
+	if r.opt.Password != "" {
+		if _, err := r.call("AUTH", r.opt.Password); err != nil {
+			return err
+		}
+	}
+	if _, err := r.call("REPLCONF", "listening-port", "0"); err != nil {
+		return err
+	}
+	if _, err := r.call("REPLCONF", "capa", "psync2"); err != nil {
+		return err
+	}
+
+	replID, offset := "?", "-1"
+	if r.opt.ReplID != "" {
+		replID = r.opt.ReplID
+		offset = strconv.FormatInt(r.opt.Offset+1, 10)
+	}
+	reply, err := r.call("PSYNC", replID, offset)
+	if err != nil {
+		return err
+	}
+
+	parts := strings.Fields(reply)
+	if len(parts) == 0 {
+		return fmt.Errorf("replica: can't parse PSYNC reply: %q", reply)
+	}
+	switch parts[0] {
+	case "FULLRESYNC":
+		if len(parts) != 3 {
+			return fmt.Errorf("replica: can't parse PSYNC reply: %q", reply)
+		}
+		n, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return err
+		}
+		r.replID = parts[1]
+		r.setOffset(n)
+		return r.readRDB()
+	case "CONTINUE":
+		r.replID = r.opt.ReplID
+		if len(parts) > 1 {
+			r.replID = parts[1]
+		}
+		r.setOffset(r.opt.Offset)
+		return nil
+	default:
+		return fmt.Errorf("replica: unsupported PSYNC reply: %q", reply)
+	}
+}
+
+// call writes command and reads status reply.
+func (r *Replica) call(args ...string) (string, error) {
+	if err := r.write(args...); err != nil {
+		return "", err
+	}
+	line, err := r.readLine()
+	if err != nil {
+		return "", err
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return "", errors.New(line[1:])
+	default:
+		return "", fmt.Errorf("replica: can't parse %q", line)
+	}
+}
+
+func (r *Replica) write(args ...string) error {
+	b := make([]byte, 0, 64)
+	b = append(b, '*')
+	b = strconv.AppendInt(b, int64(len(args)), 10)
+	b = append(b, '\r', '\n')
+	for _, arg := range args {
+		b = append(b, '$')
+		b = strconv.AppendInt(b, int64(len(arg)), 10)
+		b = append(b, '\r', '\n')
+		b = append(b, arg...)
+		b = append(b, '\r', '\n')
+	}
+
+	r.wrMx.Lock()
+	_, err := r.netcn.Write(b)
+	r.wrMx.Unlock()
+	return err
+}
+
+// readLine reads a line skipping newlines that master sends to keep
+// connection alive while it prepares the reply.
+func (r *Replica) readLine() (string, error) {
+	for {
+		line, err := r.rd.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line != "" {
+			return line, nil
+		}
+	}
+}
+
+// readRDB reads RDB payload of the full resynchronization.
+func (r *Replica) readRDB() error {
+	line, err := r.readLine()
+	if err != nil {
+		return err
+	}
+	if line[0] != '$' {
+		return fmt.Errorf("replica: expected RDB payload, but got %q", line)
+	}
+	n, err := strconv.ParseInt(line[1:], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	rd := io.LimitReader(r.rd, n)
+	version, err := rdb.ReadHeader(rd)
+	if err != nil {
+		return err
+	}
+	if r.opt.OnRDB != nil {
+		if err := r.opt.OnRDB(version, rd); err != nil {
+			return err
+		}
+	}
+	_, err = io.Copy(ioutil.Discard, rd)
+	return err
+}
+
+// Run reads replication stream and calls fn for every write command.
+// PING and REPLCONF commands are handled by replica and are not passed
+// to fn. Run blocks until an error occurs, fn returns an error or
+// replica is closed.
+func (r *Replica) Run(fn func(*Command) error) error {
+	go r.ack()
+
+	for {
+		args, n, err := r.readCommand()
+		if err != nil {
+			select {
+			case <-r.closed:
+				return errClosed
+			default:
+				return err
+			}
+		}
+		offset := r.Offset() + n
+		r.setOffset(offset)
+
+		cmd := &Command{Args: args, Offset: offset}
+		switch cmd.Name() {
+		case "ping":
+		case "replconf":
+			if len(args) > 1 && strings.ToLower(args[1]) == "getack" {
+				r.sendAck()
+			}
+		default:
+			if err := fn(cmd); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readCommand reads command from the replication stream and returns
+// its arguments and size in bytes.
+func (r *Replica) readCommand() ([]string, int64, error) {
+	line, err := r.rd.ReadString('\n')
+	if err != nil {
+		return nil, 0, err
+	}
+	size := int64(len(line))
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" || line[0] != '*' {
+		return nil, 0, fmt.Errorf("replica: expected '*', but got %q", line)
+	}
+	argc, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, 0, err
+	}
+	if argc < 1 || argc > maxArgs {
+		return nil, 0, fmt.Errorf("replica: invalid number of arguments: %q", line)
+	}
+
+	args := make([]string, argc)
+	for i := range args {
+		line, err := r.rd.ReadString('\n')
+		if err != nil {
+			return nil, 0, err
+		}
+		size += int64(len(line))
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" || line[0] != '$' {
+			return nil, 0, fmt.Errorf("replica: expected '$', but got %q", line)
+		}
+		argLen, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, 0, err
+		}
+		if argLen < 0 || argLen > maxArgLen {
+			return nil, 0, fmt.Errorf("replica: invalid argument length: %q", line)
+		}
+
+		n := int64(argLen + 2)
+		prealloc := n
+		if prealloc > maxPrealloc {
+			prealloc = maxPrealloc
+		}
+		buf := bytes.NewBuffer(make([]byte, 0, prealloc))
+		if _, err := io.CopyN(buf, r.rd, n); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, 0, err
+		}
+		size += n
+		args[i] = string(buf.Bytes()[:argLen])
+	}
+	return args, size, nil
+}
+
+func (r *Replica) sendAck() error {
+	return r.write("REPLCONF", "ACK", strconv.FormatInt(r.Offset(), 10))
+}
+
+// ack periodically acknowledges processed offset to the master.
+func (r *Replica) ack() {
+	ticker := time.NewTicker(r.opt.getAckInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.sendAck(); err != nil {
+				return
+			}
+		case <-r.closed:
+			return
+		}
+	}
+}
+
+// Close closes the connection to the master.
+func (r *Replica) Close() error {
+	select {
+	case <-r.closed:
+		return errClosed
+	default:
+		close(r.closed)
+	}
+	return r.netcn.Close()
+}
@@ -0,0 +1,256 @@
+package replica
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/redis.v3/rdb"
+)
+
+// fakeMaster reads commands sent by replica and replies with canned
+// responses.
+type fakeMaster struct {
+	t  *testing.T
+	cn net.Conn
+	rd *bufio.Reader
+}
+
+func (m *fakeMaster) readCommand() []string {
+	line, err := m.rd.ReadString('\n')
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	argc, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, argc)
+	for i := range args {
+		if _, err := m.rd.ReadString('\n'); err != nil {
+			m.t.Fatal(err)
+		}
+		arg, err := m.rd.ReadString('\n')
+		if err != nil {
+			m.t.Fatal(err)
+		}
+		args[i] = strings.TrimSpace(arg)
+	}
+	return args
+}
+
+func (m *fakeMaster) expect(reply string, wanted ...string) {
+	args := m.readCommand()
+	if strings.Join(args, " ") != strings.Join(wanted, " ") {
+		m.t.Errorf("got %q, wanted %q", args, wanted)
+	}
+	m.write(reply)
+}
+
+func (m *fakeMaster) write(s string) {
+	if _, err := io.WriteString(m.cn, s); err != nil {
+		m.t.Fatal(err)
+	}
+}
+
+func multiBulk(args ...string) string {
+	s := "*" + strconv.Itoa(len(args)) + "\r\n"
+	for _, arg := range args {
+		s += "$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n"
+	}
+	return s
+}
+
+func dialFake(t *testing.T, opt *Options, master func(*fakeMaster)) *Replica {
+	client, server := net.Pipe()
+	opt.Dialer = func() (net.Conn, error) {
+		return client, nil
+	}
+	opt.AckInterval = time.Hour
+
+	go master(&fakeMaster{t: t, cn: server, rd: bufio.NewReader(server)})
+
+	r, err := Dial(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func handshake(m *fakeMaster) {
+	m.expect("+PONG\r\n", "PING")
+	m.expect("+OK\r\n", "REPLCONF", "listening-port", "0")
+	m.expect("+OK\r\n", "REPLCONF", "capa", "psync2")
+	m.readCommand()
+}
+
+func TestFullResync(t *testing.T) {
+	set := multiBulk("SET", "key", "value")
+	ping := multiBulk("PING")
+	del := multiBulk("DEL", "key")
+
+	var rdbVersion int
+	var rdbPayload string
+	r := dialFake(t, &Options{
+		OnRDB: func(version int, rd io.Reader) error {
+			rdbVersion = version
+			b, err := ioutil.ReadAll(rd)
+			rdbPayload = string(b)
+			return err
+		},
+	}, func(m *fakeMaster) {
+		handshake(m)
+		m.write("+FULLRESYNC abc 100\r\n\n\n$13\r\nREDIS0009data")
+		m.write(set + ping + del)
+		m.cn.Close()
+	})
+	defer r.Close()
+
+	if r.ReplID() != "abc" {
+		t.Errorf("got %q, wanted abc", r.ReplID())
+	}
+	if rdbVersion != 9 || rdbPayload != "data" {
+		t.Errorf("got version=%d payload=%q", rdbVersion, rdbPayload)
+	}
+
+	var cmds []*Command
+	err := r.Run(func(cmd *Command) error {
+		cmds = append(cmds, cmd)
+		return nil
+	})
+	if err != io.EOF {
+		t.Fatalf("got %v, wanted io.EOF", err)
+	}
+
+	if len(cmds) != 2 {
+		t.Fatalf("got %d commands, wanted 2", len(cmds))
+	}
+	if cmds[0].String() != "SET key value" {
+		t.Errorf("got %q", cmds[0])
+	}
+	if got, wanted := cmds[0].Offset, int64(100+len(set)); got != wanted {
+		t.Errorf("got offset %d, wanted %d", got, wanted)
+	}
+	if cmds[1].Name() != "del" {
+		t.Errorf("got %q, wanted del", cmds[1].Name())
+	}
+	if got, wanted := r.Offset(), int64(100+len(set+ping+del)); got != wanted {
+		t.Errorf("got offset %d, wanted %d", got, wanted)
+	}
+}
+
+func TestFullResyncRecords(t *testing.T) {
+	payload := "REDIS0009\x00\x03key\x05value\xff" + strings.Repeat("\x00", 8)
+
+	var recs []*rdb.Record
+	r := dialFake(t, &Options{
+		OnRDB: rdb.Handler(func(rec *rdb.Record) error {
+			recs = append(recs, rec)
+			return nil
+		}),
+	}, func(m *fakeMaster) {
+		handshake(m)
+		m.write("+FULLRESYNC abc 0\r\n$" + strconv.Itoa(len(payload)) + "\r\n" + payload)
+	})
+	defer r.Close()
+
+	if len(recs) != 1 {
+		t.Fatalf("got %d records, wanted 1", len(recs))
+	}
+	if recs[0].Key != "key" || recs[0].Value != "value" {
+		t.Errorf("got %+v", recs[0])
+	}
+}
+
+func TestPartialResync(t *testing.T) {
+	r := dialFake(t, &Options{
+		Password: "secret",
+		ReplID:   "abc",
+		Offset:   100,
+	}, func(m *fakeMaster) {
+		m.expect("+PONG\r\n", "PING")
+		m.expect("+OK\r\n", "AUTH", "secret")
+		m.expect("+OK\r\n", "REPLCONF", "listening-port", "0")
+		m.expect("+OK\r\n", "REPLCONF", "capa", "psync2")
+		m.expect("+CONTINUE def\r\n", "PSYNC", "abc", "101")
+		m.write(multiBulk("REPLCONF", "GETACK", "*"))
+		m.expect("", "REPLCONF", "ACK", "137")
+		m.cn.Close()
+	})
+	defer r.Close()
+
+	if r.ReplID() != "def" {
+		t.Errorf("got %q, wanted def", r.ReplID())
+	}
+
+	err := r.Run(func(cmd *Command) error {
+		t.Errorf("unexpected command: %s", cmd)
+		return nil
+	})
+	if err != io.EOF {
+		t.Fatalf("got %v, wanted io.EOF", err)
+	}
+}
+
+func TestPSYNCError(t *testing.T) {
+	client, server := net.Pipe()
+	go func() {
+		m := &fakeMaster{t: t, cn: server, rd: bufio.NewReader(server)}
+		m.expect("-NOAUTH Authentication required.\r\n", "PING")
+	}()
+
+	_, err := Dial(&Options{
+		Dialer: func() (net.Conn, error) {
+			return client, nil
+		},
+	})
+	if err == nil || err.Error() != "NOAUTH Authentication required." {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestEmptyPSYNCReply(t *testing.T) {
+	client, server := net.Pipe()
+	go func() {
+		m := &fakeMaster{t: t, cn: server, rd: bufio.NewReader(server)}
+		handshake(m)
+		m.write("+ \r\n")
+	}()
+
+	_, err := Dial(&Options{
+		Dialer: func() (net.Conn, error) {
+			return client, nil
+		},
+	})
+	if err == nil || err.Error() != `replica: can't parse PSYNC reply: " "` {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestMalformedCommand(t *testing.T) {
+	frames := []string{
+		"*-1\r\n",
+		"*0\r\n",
+		"*100000000\r\n",
+		"*1\r\n$-1\r\n",
+		"*1\r\n$1000000000000\r\n",
+	}
+	for _, frame := range frames {
+		r := dialFake(t, &Options{}, func(m *fakeMaster) {
+			handshake(m)
+			m.write("+CONTINUE\r\n")
+			m.write(frame)
+		})
+
+		err := r.Run(func(cmd *Command) error {
+			t.Errorf("unexpected command: %s", cmd)
+			return nil
+		})
+		if err == nil || !strings.HasPrefix(err.Error(), "replica: invalid") {
+			t.Errorf("%q: got %v", frame, err)
+		}
+		r.Close()
+	}
+}
@@ -19,11 +19,16 @@ var (
 type RingOptions struct {
 	// A map of name => host:port addresses of ring shards.
 	Addrs map[string]string
+	// Optional provider of shard addresses used in addition to Addrs.
+	// Shards are named by their addresses and are added and removed
+	// when the provider reports changes.
+	Endpoints EndpointProvider
 
 	// Following options are copied from Options struct.
 
-	DB       int64
-	Password string
+	DB         int64
+	Password   string
+	ClientName string
 
 	MaxRetries int
 
@@ -31,23 +36,46 @@ type RingOptions struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 
+	AdaptiveTimeout *AdaptiveTimeout
+
 	PoolSize    int
 	PoolTimeout time.Duration
 	IdleTimeout time.Duration
+
+	OnProcess func(*ProcessInfo)
+	KeyPolicy *KeyPolicy
+
+	DisableDestructiveCommands bool
+
+	Clock      Clock
+	CodecHooks *CodecHooks
+	Encoding   *Encoding
 }
 
 func (opt *RingOptions) clientOptions() *Options {
 	return &Options{
-		DB:       opt.DB,
-		Password: opt.Password,
+		DB:         opt.DB,
+		Password:   opt.Password,
+		ClientName: opt.ClientName,
 
 		DialTimeout:  opt.DialTimeout,
 		ReadTimeout:  opt.ReadTimeout,
 		WriteTimeout: opt.WriteTimeout,
 
+		AdaptiveTimeout: opt.AdaptiveTimeout,
+
 		PoolSize:    opt.PoolSize,
 		PoolTimeout: opt.PoolTimeout,
 		IdleTimeout: opt.IdleTimeout,
+
+		OnProcess: opt.OnProcess,
+		KeyPolicy: opt.KeyPolicy,
+
+		DisableDestructiveCommands: opt.DisableDestructiveCommands,
+
+		Clock:      opt.Clock,
+		CodecHooks: opt.CodecHooks,
+		Encoding:   opt.Encoding,
 	}
 }
 
@@ -113,8 +141,11 @@ type Ring struct {
 	mx     sync.RWMutex
 	hash   *consistenthash.Map
 	shards map[string]*ringShard
+	// Names of shards added by the endpoint provider.
+	endpoints map[string]struct{}
 
-	closed bool
+	stopWatch func()
+	closed    bool
 }
 
 func NewRing(opt *RingOptions) *Ring {
@@ -123,8 +154,9 @@ func NewRing(opt *RingOptions) *Ring {
 		opt:       opt,
 		nreplicas: nreplicas,
 
-		hash:   consistenthash.New(nreplicas, nil),
-		shards: make(map[string]*ringShard),
+		hash:      consistenthash.New(nreplicas, nil),
+		shards:    make(map[string]*ringShard),
+		endpoints: make(map[string]struct{}),
 	}
 	ring.commandable.process = ring.process
 	for name, addr := range opt.Addrs {
@@ -132,10 +164,55 @@ func NewRing(opt *RingOptions) *Ring {
 		clopt.Addr = addr
 		ring.addClient(name, NewClient(clopt))
 	}
+	if opt.Endpoints != nil {
+		addrs, err := opt.Endpoints.Addrs()
+		if err != nil {
+			log.Printf("redis: EndpointProvider.Addrs failed: %s", err)
+		} else {
+			ring.setEndpoints(addrs)
+		}
+		ring.stopWatch = opt.Endpoints.Watch(ring.setEndpoints)
+	}
 	go ring.heartbeat()
 	return ring
 }
 
+// setEndpoints adds shards for new addresses and removes shards which
+// addresses are no longer reported by the endpoint provider.
+func (ring *Ring) setEndpoints(addrs []string) {
+	var removed []*Client
+
+	ring.mx.Lock()
+	if ring.closed {
+		ring.mx.Unlock()
+		return
+	}
+	for _, addr := range addrs {
+		if _, ok := ring.shards[addr]; ok {
+			continue
+		}
+		clopt := ring.opt.clientOptions()
+		clopt.Addr = addr
+		ring.shards[addr] = &ringShard{Client: NewClient(clopt)}
+		ring.endpoints[addr] = struct{}{}
+	}
+	for name := range ring.endpoints {
+		if !contains(addrs, name) {
+			removed = append(removed, ring.shards[name].Client)
+			delete(ring.shards, name)
+			delete(ring.endpoints, name)
+		}
+	}
+	ring.mx.Unlock()
+
+	ring.rebalance()
+	for _, client := range removed {
+		if err := client.Close(); err != nil {
+			log.Printf("redis: closing ring shard failed: %s", err)
+		}
+	}
+}
+
 func (ring *Ring) addClient(name string, cl *Client) {
 	ring.mx.Lock()
 	ring.hash.Add(name)
@@ -225,6 +302,9 @@ func (ring *Ring) Close() (retErr error) {
 		return nil
 	}
 	ring.closed = true
+	if ring.stopWatch != nil {
+		ring.stopWatch()
+	}
 
 	for _, shard := range ring.shards {
 		if err := shard.Client.Close(); err != nil {
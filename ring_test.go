@@ -3,6 +3,7 @@ package redis_test
 import (
 	"crypto/rand"
 	"fmt"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -93,6 +94,42 @@ var _ = Describe("Redis ring", func() {
 		Expect(ringShard2.Info().Val()).To(ContainSubstring("keys=100"))
 	})
 
+	It("names shard connections using ClientName", func() {
+		named := redis.NewRing(&redis.RingOptions{
+			Addrs: map[string]string{
+				"ringShardOne": ":" + ringShard1Port,
+			},
+			ClientName: "ring",
+		})
+		defer named.Close()
+
+		Expect(named.Ping().Err()).NotTo(HaveOccurred())
+		Expect(ringShard1.ClientList().Val()).To(MatchRegexp(`name=ring-\d+-\d+`))
+	})
+
+	It("adds and removes shards reported by endpoint provider", func() {
+		endpoints := &staticEndpoints{addrs: []string{":" + ringShard1Port}}
+		ring := redis.NewRing(&redis.RingOptions{
+			Endpoints: endpoints,
+		})
+		defer ring.Close()
+
+		setRingKeys := func() {
+			for i := 0; i < 100; i++ {
+				err := ring.Set(fmt.Sprintf("key%d", i), "value", 0).Err()
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+
+		setRingKeys()
+		Expect(ringShard1.Info().Val()).To(ContainSubstring("keys=100"))
+		Expect(ringShard2.Info().Val()).NotTo(ContainSubstring("keys="))
+
+		endpoints.set([]string{":" + ringShard2Port})
+		setRingKeys()
+		Expect(ringShard2.Info().Val()).To(ContainSubstring("keys=100"))
+	})
+
 	Describe("pipelining", func() {
 		It("returns an error when all shards are down", func() {
 			ring := redis.NewRing(&redis.RingOptions{})
@@ -162,3 +199,39 @@ var _ = Describe("Redis ring", func() {
 		})
 	})
 })
+
+//------------------------------------------------------------------------------
+
+// staticEndpoints is EndpointProvider with addresses set by tests.
+type staticEndpoints struct {
+	mu    sync.Mutex
+	addrs []string
+	fn    func([]string)
+}
+
+func (e *staticEndpoints) Addrs() ([]string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.addrs, nil
+}
+
+func (e *staticEndpoints) Watch(fn func([]string)) func() {
+	e.mu.Lock()
+	e.fn = fn
+	e.mu.Unlock()
+	return func() {
+		e.mu.Lock()
+		e.fn = nil
+		e.mu.Unlock()
+	}
+}
+
+func (e *staticEndpoints) set(addrs []string) {
+	e.mu.Lock()
+	e.addrs = addrs
+	fn := e.fn
+	e.mu.Unlock()
+	if fn != nil {
+		fn(addrs)
+	}
+}
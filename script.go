@@ -32,7 +32,7 @@ func (s *Script) Load(c scripter) *StringCmd {
 }
 
 func (s *Script) Exists(c scripter) *BoolSliceCmd {
-	return c.ScriptExists(s.src)
+	return c.ScriptExists(s.hash)
 }
 
 func (s *Script) Eval(c scripter, keys []string, args []string) *Cmd {
@@ -43,7 +43,14 @@ func (s *Script) EvalSha(c scripter, keys []string, args []string) *Cmd {
 	return c.EvalSha(s.hash, keys, args)
 }
 
+// Run optimistically uses EVALSHA to run the script. If script does
+// not exist it is retried using EVAL. ClusterClient keeps track of
+// scripts loaded on each node, so script body is sent at most once
+// per node.
 func (s *Script) Run(c scripter, keys []string, args []string) *Cmd {
+	if cluster, ok := c.(*ClusterClient); ok {
+		return cluster.runScript(s, keys, args)
+	}
 	r := s.EvalSha(c, keys, args)
 	if err := r.Err(); err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT ") {
 		return s.Eval(c, keys, args)
@@ -0,0 +1,231 @@
+package redis
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSemaphoreTimeout is returned by Semaphore.Acquire when slot is
+	// not acquired in time.
+	ErrSemaphoreTimeout = errors.New("redis: semaphore acquire timeout")
+
+	errInvalidPriority = errors.New("redis: semaphore priority must be in range [-100, 100]")
+)
+
+// Holders are stored in a sorted set scored by expiration time. Fair
+// semaphores also keep a queue of waiters scored by priority and
+// enqueue time and a sorted set with the last time each waiter polled,
+// so dead waiters don't block the queue.
+var semaphoreAcquireScript = NewScript(`
+redis.replicate_commands()
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local id, limit, ttl = ARGV[1], tonumber(ARGV[2]), tonumber(ARGV[3])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+if redis.call("ZSCORE", KEYS[1], id) then
+  redis.call("ZADD", KEYS[1], now + ttl, id)
+  return 1
+end
+
+if ARGV[4] == "1" then
+  local dead = redis.call("ZRANGEBYSCORE", KEYS[3], "-inf", now - ttl)
+  if #dead > 0 then
+    redis.call("ZREM", KEYS[2], unpack(dead))
+    redis.call("ZREM", KEYS[3], unpack(dead))
+  end
+  if not redis.call("ZSCORE", KEYS[2], id) then
+    redis.call("ZADD", KEYS[2], now - tonumber(ARGV[5]) * 1e13, id)
+  end
+  redis.call("ZADD", KEYS[3], now, id)
+
+  local free = limit - redis.call("ZCARD", KEYS[1])
+  if redis.call("ZRANK", KEYS[2], id) >= free then
+    return 0
+  end
+  redis.call("ZREM", KEYS[2], id)
+  redis.call("ZREM", KEYS[3], id)
+elseif redis.call("ZCARD", KEYS[1]) >= limit then
+  return 0
+end
+
+redis.call("ZADD", KEYS[1], now + ttl, id)
+return 1
+`)
+
+var semaphoreRefreshScript = NewScript(`
+redis.replicate_commands()
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local score = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if not score or tonumber(score) <= now then
+  return 0
+end
+redis.call("ZADD", KEYS[1], now + tonumber(ARGV[2]), ARGV[1])
+return 1
+`)
+
+var semaphoreLeaveScript = NewScript(`
+for _, key in ipairs(KEYS) do
+  redis.call("ZREM", key, ARGV[1])
+end
+return 0
+`)
+
+// SemaphoreOptions are used to configure a semaphore.
+type SemaphoreOptions struct {
+	// How long a slot is held without refresh. Slots of holders that
+	// died without releasing them are freed after TTL.
+	// Default is 10 seconds.
+	TTL time.Duration
+	// Fair makes waiters acquire slots in FIFO order. Waiters with
+	// higher priority are served first.
+	Fair bool
+	// How often Acquire retries to acquire a slot. Must be less than
+	// TTL for fair semaphores.
+	// Default is 100 milliseconds.
+	PollInterval time.Duration
+	// HashTag wraps the key in a hash tag unless it already has one,
+	// so keys of fair semaphores are stored in the same cluster slot.
+	HashTag bool
+	// Source of time used for Acquire timeout and polling.
+	// Default is the system clock.
+	Clock Clock
+}
+
+func (opt *SemaphoreOptions) getTTL() time.Duration {
+	if opt.TTL == 0 {
+		return 10 * time.Second
+	}
+	return opt.TTL
+}
+
+func (opt *SemaphoreOptions) getPollInterval() time.Duration {
+	if opt.PollInterval == 0 {
+		return 100 * time.Millisecond
+	}
+	return opt.PollInterval
+}
+
+func (opt *SemaphoreOptions) getClock() Clock {
+	if opt.Clock == nil {
+		return systemClock{}
+	}
+	return opt.Clock
+}
+
+// Semaphore is a distributed counting semaphore that caps the number of
+// concurrent holders across processes. Holders are stored in the key
+// and waiters of fair semaphores in "<key>:queue" and
+// "<key>:polled" keys, so in cluster the key must have a hash tag or
+// HashTag option must be set.
+type Semaphore struct {
+	client scripter
+	keys   []string
+	limit  int
+	opt    *SemaphoreOptions
+}
+
+// NewSemaphore returns a semaphore allowing up to limit holders.
+func NewSemaphore(client scripter, key string, limit int, opt *SemaphoreOptions) *Semaphore {
+	if opt == nil {
+		opt = &SemaphoreOptions{}
+	}
+	return &Semaphore{
+		client: client,
+		keys:   helperKeys(key, opt.HashTag, "queue", "polled"),
+		limit:  limit,
+		opt:    opt,
+	}
+}
+
+func (s *Semaphore) tryAcquire(token string, priority int) (bool, error) {
+	fair := "0"
+	if s.opt.Fair {
+		fair = "1"
+	}
+	args := []string{
+		token,
+		formatInt(int64(s.limit)),
+		formatMs(s.opt.getTTL()),
+		fair,
+		formatInt(int64(priority)),
+	}
+	n, err := semaphoreAcquireScript.Run(s.client, s.keys, args).Result()
+	if err != nil {
+		return false, err
+	}
+	return n.(int64) == 1, nil
+}
+
+// TryAcquire makes a single attempt to acquire a slot. It returns a
+// token that must be passed to Refresh and Release or an empty string
+// if there are no free slots.
+func (s *Semaphore) TryAcquire() (string, error) {
+	token := newRandomToken()
+	ok, err := s.tryAcquire(token, 0)
+	if err != nil || !ok {
+		s.leave(token)
+		return "", err
+	}
+	return token, nil
+}
+
+// Acquire blocks until a slot is acquired or timeout expires. Priority
+// is used only by fair semaphores and must be in range [-100, 100].
+// It returns a token that must be passed to Refresh and Release.
+func (s *Semaphore) Acquire(priority int, timeout time.Duration) (string, error) {
+	if priority < -100 || priority > 100 {
+		return "", errInvalidPriority
+	}
+
+	clock := s.opt.getClock()
+	token := newRandomToken()
+	deadline := clock.Now().Add(timeout)
+	for {
+		ok, err := s.tryAcquire(token, priority)
+		if err != nil {
+			s.leave(token)
+			return "", err
+		}
+		if ok {
+			return token, nil
+		}
+		if clock.Now().After(deadline) {
+			s.leave(token)
+			return "", ErrSemaphoreTimeout
+		}
+		clock.Sleep(s.opt.getPollInterval())
+	}
+}
+
+// Refresh extends the slot held by token for another TTL. It returns
+// false if the slot has already expired.
+func (s *Semaphore) Refresh(token string) (bool, error) {
+	args := []string{token, formatMs(s.opt.getTTL())}
+	n, err := semaphoreRefreshScript.Run(s.client, s.keys[:1], args).Result()
+	if err != nil {
+		return false, err
+	}
+	return n.(int64) == 1, nil
+}
+
+// Release frees the slot held by token.
+func (s *Semaphore) Release(token string) error {
+	return s.leave(token)
+}
+
+func (s *Semaphore) leave(token string) error {
+	return semaphoreLeaveScript.Run(s.client, s.keys, []string{token}).Err()
+}
+
+func newRandomToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
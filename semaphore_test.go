@@ -0,0 +1,131 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("Semaphore", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should limit holders", func() {
+		sem := redis.NewSemaphore(client, "sem", 2, nil)
+
+		token1, err := sem.TryAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token1).NotTo(BeEmpty())
+
+		token2, err := sem.TryAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token2).NotTo(BeEmpty())
+
+		token3, err := sem.TryAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token3).To(BeEmpty())
+
+		Expect(sem.Release(token1)).NotTo(HaveOccurred())
+
+		token3, err = sem.TryAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token3).NotTo(BeEmpty())
+	})
+
+	It("should free slots of dead holders", func() {
+		sem := redis.NewSemaphore(client, "sem", 1, &redis.SemaphoreOptions{
+			TTL:          100 * time.Millisecond,
+			PollInterval: 10 * time.Millisecond,
+		})
+
+		token, err := sem.TryAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).NotTo(BeEmpty())
+
+		ok, err := sem.Refresh(token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		_, err = sem.Acquire(0, 10*time.Millisecond)
+		Expect(err).To(Equal(redis.ErrSemaphoreTimeout))
+
+		token2, err := sem.Acquire(0, time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token2).NotTo(BeEmpty())
+
+		ok, err = sem.Refresh(token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should serve waiters of fair semaphore by priority", func() {
+		sem := redis.NewSemaphore(client, "sem", 1, &redis.SemaphoreOptions{
+			Fair:         true,
+			PollInterval: 10 * time.Millisecond,
+		})
+
+		token, err := sem.Acquire(0, time.Second)
+		Expect(err).NotTo(HaveOccurred())
+
+		acquired := make(chan int, 2)
+		for _, priority := range []int{0, 10} {
+			go func(priority int) {
+				defer GinkgoRecover()
+				token, err := sem.Acquire(priority, 5*time.Second)
+				Expect(err).NotTo(HaveOccurred())
+				acquired <- priority
+				time.Sleep(50 * time.Millisecond)
+				Expect(sem.Release(token)).NotTo(HaveOccurred())
+			}(priority)
+		}
+
+		Eventually(func() int64 {
+			return client.ZCard("sem:queue").Val()
+		}).Should(Equal(int64(2)))
+		Expect(sem.Release(token)).NotTo(HaveOccurred())
+
+		Eventually(acquired).Should(Receive(Equal(10)))
+		Eventually(acquired).Should(Receive(Equal(0)))
+	})
+
+	It("should reject invalid priority", func() {
+		sem := redis.NewSemaphore(client, "sem", 1, nil)
+		_, err := sem.Acquire(1000, time.Second)
+		Expect(err).To(MatchError("redis: semaphore priority must be in range [-100, 100]"))
+	})
+
+	It("should wrap keys in hash tag", func() {
+		sem := redis.NewSemaphore(client, "sem", 1, &redis.SemaphoreOptions{
+			Fair:    true,
+			HashTag: true,
+		})
+		token, err := sem.TryAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).NotTo(BeEmpty())
+
+		keys, err := client.Keys("*").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]string{"{sem}"}))
+
+		sem = redis.NewSemaphore(client, "{tag}sem", 1, &redis.SemaphoreOptions{
+			HashTag: true,
+		})
+		token, err = sem.TryAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).NotTo(BeEmpty())
+		Expect(client.Exists("{tag}sem").Val()).To(BeTrue())
+	})
+})
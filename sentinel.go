@@ -18,39 +18,85 @@ type FailoverOptions struct {
 	MasterName string
 	// A seed list of host:port addresses of sentinel nodes.
 	SentinelAddrs []string
+	// Optional provider of sentinel addresses used in addition to
+	// SentinelAddrs. Addresses are re-queried every time the master
+	// address is looked up.
+	Endpoints EndpointProvider
+	// Name of DNS SRV record used to discover addresses of sentinels
+	// in addition to SentinelAddrs. Ignored when Endpoints is set.
+	DiscoverSRV string
+	// Specifies how long addresses discovered via DNS SRV are cached.
+	// Default is 30 seconds.
+	SRVRefreshInterval time.Duration
 
 	// Following options are copied from Options struct.
 
-	Password string
-	DB       int64
+	Password   string
+	DB         int64
+	ClientName string
 
 	DialTimeout  time.Duration
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 
+	AdaptiveTimeout *AdaptiveTimeout
+
 	PoolSize    int
 	PoolTimeout time.Duration
 	IdleTimeout time.Duration
 
+	OfflineQueueSize    int
+	OfflineQueueTimeout time.Duration
+
+	MinReconnectBackoff time.Duration
+	MaxReconnectBackoff time.Duration
+
 	MaxRetries int
+
+	OnProcess func(*ProcessInfo)
+	KeyPolicy *KeyPolicy
+
+	DisableDestructiveCommands bool
+
+	Clock      Clock
+	CodecHooks *CodecHooks
+	Encoding   *Encoding
 }
 
 func (opt *FailoverOptions) options() *Options {
 	return &Options{
 		Addr: "FailoverClient",
 
-		DB:       opt.DB,
-		Password: opt.Password,
+		DB:         opt.DB,
+		Password:   opt.Password,
+		ClientName: opt.ClientName,
 
 		DialTimeout:  opt.DialTimeout,
 		ReadTimeout:  opt.ReadTimeout,
 		WriteTimeout: opt.WriteTimeout,
 
+		AdaptiveTimeout: opt.AdaptiveTimeout,
+
 		PoolSize:    opt.PoolSize,
 		PoolTimeout: opt.PoolTimeout,
 		IdleTimeout: opt.IdleTimeout,
 
+		OfflineQueueSize:    opt.OfflineQueueSize,
+		OfflineQueueTimeout: opt.OfflineQueueTimeout,
+
+		MinReconnectBackoff: opt.MinReconnectBackoff,
+		MaxReconnectBackoff: opt.MaxReconnectBackoff,
+
 		MaxRetries: opt.MaxRetries,
+
+		OnProcess: opt.OnProcess,
+		KeyPolicy: opt.KeyPolicy,
+
+		DisableDestructiveCommands: opt.DisableDestructiveCommands,
+
+		Clock:      opt.Clock,
+		CodecHooks: opt.CodecHooks,
+		Encoding:   opt.Encoding,
 	}
 }
 
@@ -61,10 +107,17 @@ func NewFailoverClient(failoverOpt *FailoverOptions) *Client {
 	failover := &sentinelFailover{
 		masterName:    failoverOpt.MasterName,
 		sentinelAddrs: failoverOpt.SentinelAddrs,
+		endpoints: endpointProvider(
+			failoverOpt.Endpoints, failoverOpt.DiscoverSRV, failoverOpt.SRVRefreshInterval,
+		),
 
 		opt: opt,
 	}
-	return newClient(opt, failover.Pool())
+	pool := failover.Pool()
+	client := newClient(opt, pool)
+	client.state = newConnState(opt, pool)
+	client.offline = newOfflineQueue(opt, pool, client.state)
+	return client
 }
 
 //------------------------------------------------------------------------------
@@ -109,6 +162,7 @@ func (c *sentinelClient) Sentinels(name string) *SliceCmd {
 type sentinelFailover struct {
 	masterName    string
 	sentinelAddrs []string
+	endpoints     EndpointProvider
 
 	opt *Options
 
@@ -152,6 +206,15 @@ func (d *sentinelFailover) MasterAddr() (string, error) {
 		}
 	}
 
+	if d.endpoints != nil {
+		addrs, err := d.endpoints.Addrs()
+		if err != nil {
+			log.Printf("redis-sentinel: EndpointProvider.Addrs failed: %s", err)
+		} else {
+			d.sentinelAddrs = mergeAddrs(d.sentinelAddrs, addrs)
+		}
+	}
+
 	for i, sentinelAddr := range d.sentinelAddrs {
 		sentinel := newSentinel(&Options{
 			Addr: sentinelAddr,
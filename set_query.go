@@ -0,0 +1,183 @@
+package redis
+
+import "time"
+
+var setQueryStepScript = NewScript(`
+local n = redis.call(ARGV[1], KEYS[1], unpack(KEYS, 2))
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+if ARGV[3] == "1" then
+  redis.call("DEL", KEYS[2])
+end
+return n
+`)
+
+// SetQueryOptions are used to configure a set query.
+type SetQueryOptions struct {
+	// Expiration of temporary keys holding intermediate and final
+	// results. Results must be read before they expire.
+	// Default is 1 minute.
+	TempTTL time.Duration
+	// Number of members fetched by SetQueryReader at a time. It is a
+	// hint passed to SSCAN as COUNT.
+	// Default is 100.
+	PageSize int64
+}
+
+func (opt *SetQueryOptions) getTempTTL() time.Duration {
+	if opt.TempTTL == 0 {
+		return time.Minute
+	}
+	return opt.TempTTL
+}
+
+func (opt *SetQueryOptions) getPageSize() int64 {
+	if opt.PageSize == 0 {
+		return 100
+	}
+	return opt.PageSize
+}
+
+type setQueryClient interface {
+	scripter
+	SScan(key string, cursor int64, match string, count int64) *ScanCmd
+	Del(keys ...string) *IntCmd
+}
+
+type setQueryStep struct {
+	cmd  string
+	keys []string
+}
+
+// SetQuery chains set algebra operations, e.g. to filter items by tags
+// stored as sets. Every operation is applied to the result of the
+// previous one and materialized with SUNIONSTORE, SINTERSTORE or
+// SDIFFSTORE in a temporary key "{<tag>}:setquery:<random>" that
+// expires after TempTTL, where tag is the hash tag of the first key.
+// Temporary keys hash to the same slot as the first key, so with
+// ClusterClient all keys of the query must hash to that slot too.
+type SetQuery struct {
+	client setQueryClient
+	key    string
+	steps  []setQueryStep
+	opt    *SetQueryOptions
+}
+
+// NewSetQuery returns a query starting with the set stored at key.
+func NewSetQuery(client setQueryClient, key string, opt *SetQueryOptions) *SetQuery {
+	if opt == nil {
+		opt = &SetQueryOptions{}
+	}
+	return &SetQuery{
+		client: client,
+		key:    key,
+		opt:    opt,
+	}
+}
+
+func (q *SetQuery) step(cmd string, keys []string) *SetQuery {
+	q.steps = append(q.steps, setQueryStep{cmd: cmd, keys: keys})
+	return q
+}
+
+// Union adds members of sets stored at keys to the result.
+func (q *SetQuery) Union(keys ...string) *SetQuery {
+	return q.step("sunionstore", keys)
+}
+
+// Inter keeps members of the result that are members of all sets
+// stored at keys.
+func (q *SetQuery) Inter(keys ...string) *SetQuery {
+	return q.step("sinterstore", keys)
+}
+
+// Diff removes members of sets stored at keys from the result.
+func (q *SetQuery) Diff(keys ...string) *SetQuery {
+	return q.step("sdiffstore", keys)
+}
+
+func (q *SetQuery) tempKey() string {
+	return "{" + hashKey(q.key) + "}:setquery:" + newRandomToken()
+}
+
+// Exec materializes the query and returns a reader of the result. The
+// query can be executed again, e.g. to refresh expired results.
+func (q *SetQuery) Exec() (*SetQueryReader, error) {
+	key := q.key
+	ttl := formatInt(int64(q.opt.getTempTTL() / time.Millisecond))
+	for i, step := range q.steps {
+		dest := q.tempKey()
+		keys := append([]string{dest, key}, step.keys...)
+		delPrev := "0"
+		if i > 0 {
+			delPrev = "1"
+		}
+		err := setQueryStepScript.Run(q.client, keys, []string{step.cmd, ttl, delPrev}).Err()
+		if err != nil {
+			return nil, err
+		}
+		key = dest
+	}
+	return &SetQueryReader{
+		client:   q.client,
+		key:      key,
+		temp:     len(q.steps) > 0,
+		pageSize: q.opt.getPageSize(),
+	}, nil
+}
+
+// SetQueryReader reads members of the query result page by page using
+// SSCAN. A member may be returned more than once.
+type SetQueryReader struct {
+	client   setQueryClient
+	key      string
+	temp     bool
+	pageSize int64
+
+	cursor int64
+	done   bool
+	page   []string
+	err    error
+}
+
+// Key returns the key holding the result.
+func (r *SetQueryReader) Key() string {
+	return r.key
+}
+
+// Next fetches the next page of members. It returns false when all
+// members are read or an error occurs.
+func (r *SetQueryReader) Next() bool {
+	for !r.done && r.err == nil {
+		cursor, page, err := r.client.SScan(r.key, r.cursor, "", r.pageSize).Result()
+		if err != nil {
+			r.err = err
+			return false
+		}
+		r.cursor = cursor
+		r.done = cursor == 0
+		if len(page) > 0 {
+			r.page = page
+			return true
+		}
+	}
+	return false
+}
+
+// Val returns the page fetched by the last call to Next.
+func (r *SetQueryReader) Val() []string {
+	return r.page
+}
+
+// Err returns the error occurred while fetching pages.
+func (r *SetQueryReader) Err() error {
+	return r.err
+}
+
+// Close deletes the temporary key holding the result. Keys queried
+// without set operations are not deleted.
+func (r *SetQueryReader) Close() error {
+	if !r.temp {
+		return nil
+	}
+	return r.client.Del(r.key).Err()
+}
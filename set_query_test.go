@@ -0,0 +1,81 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("SetQuery", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+
+		Expect(client.SAdd("tag:go", "a", "b", "c").Err()).NotTo(HaveOccurred())
+		Expect(client.SAdd("tag:redis", "b", "c", "d").Err()).NotTo(HaveOccurred())
+		Expect(client.SAdd("tag:db", "e").Err()).NotTo(HaveOccurred())
+		Expect(client.SAdd("tag:old", "c").Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	readAll := func(r *redis.SetQueryReader) []string {
+		var members []string
+		for r.Next() {
+			members = append(members, r.Val()...)
+		}
+		Expect(r.Err()).NotTo(HaveOccurred())
+		return members
+	}
+
+	It("should chain set operations", func() {
+		r, err := redis.NewSetQuery(client, "tag:go", nil).
+			Inter("tag:redis").
+			Union("tag:db").
+			Diff("tag:old").
+			Exec()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readAll(r)).To(ConsistOf("b", "e"))
+
+		ttl, err := client.PTTL(r.Key()).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ttl).To(BeNumerically(">", 0))
+		Expect(ttl).To(BeNumerically("<=", time.Minute))
+
+		keys, err := client.Keys("{tag:go}:setquery:*").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]string{r.Key()}))
+
+		Expect(r.Close()).NotTo(HaveOccurred())
+		Expect(client.Exists(r.Key()).Val()).To(BeFalse())
+	})
+
+	It("should read results in pages", func() {
+		r, err := redis.NewSetQuery(client, "tag:go", &redis.SetQueryOptions{
+			PageSize: 1,
+		}).Union("tag:redis").Exec()
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close()
+
+		Expect(readAll(r)).To(ConsistOf("a", "b", "c", "d"))
+	})
+
+	It("should read the key without operations", func() {
+		r, err := redis.NewSetQuery(client, "tag:go", nil).Exec()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Key()).To(Equal("tag:go"))
+		Expect(readAll(r)).To(ConsistOf("a", "b", "c"))
+
+		Expect(r.Close()).NotTo(HaveOccurred())
+		Expect(client.Exists("tag:go").Val()).To(BeTrue())
+	})
+})
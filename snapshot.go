@@ -0,0 +1,108 @@
+package redis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ServerSnapshot is the state of a server captured by
+// Client.ServerSnapshot.
+type ServerSnapshot struct {
+	Time time.Time
+	// Info contains fields of INFO ALL.
+	Info map[string]string
+	// Config contains parameters returned by CONFIG GET *.
+	Config map[string]string
+	// Cluster contains fields of CLUSTER INFO. It is nil when cluster
+	// support is disabled.
+	Cluster map[string]string
+}
+
+// SnapshotChange is a field that differs between two snapshots. Old is
+// empty when the field was added and New is empty when it was removed.
+type SnapshotChange struct {
+	// Section is "info", "config" or "cluster".
+	Section  string
+	Field    string
+	Old, New string
+}
+
+func (c SnapshotChange) String() string {
+	return fmt.Sprintf("%s %s: %q -> %q", c.Section, c.Field, c.Old, c.New)
+}
+
+// ServerSnapshot captures INFO ALL, CONFIG GET * and CLUSTER INFO of
+// the server, e.g. to compare server state before and after an
+// incident using DiffSnapshots.
+func (c *Client) ServerSnapshot() (*ServerSnapshot, error) {
+	snap := &ServerSnapshot{Time: time.Now()}
+
+	info, err := c.Info("all").Result()
+	if err != nil {
+		return nil, err
+	}
+	snap.Info = parseInfo(info)
+
+	config, err := c.ConfigGet("*").Result()
+	if err != nil {
+		return nil, err
+	}
+	snap.Config = make(map[string]string, len(config)/2)
+	for i := 0; i+1 < len(config); i += 2 {
+		snap.Config[fmt.Sprint(config[i])] = fmt.Sprint(config[i+1])
+	}
+
+	cluster, err := c.ClusterInfo().Result()
+	if err != nil && !isClusterDisabledError(err) {
+		return nil, err
+	}
+	if err == nil {
+		snap.Cluster = parseInfo(cluster)
+	}
+
+	return snap, nil
+}
+
+func isClusterDisabledError(err error) bool {
+	if _, ok := err.(redisError); !ok {
+		return false
+	}
+	return strings.Contains(err.Error(), "cluster support disabled")
+}
+
+// DiffSnapshots returns fields that differ between snapshots a and b
+// sorted by section and field.
+func DiffSnapshots(a, b *ServerSnapshot) []SnapshotChange {
+	var changes []SnapshotChange
+	changes = diffSnapshotSection(changes, "info", a.Info, b.Info)
+	changes = diffSnapshotSection(changes, "config", a.Config, b.Config)
+	changes = diffSnapshotSection(changes, "cluster", a.Cluster, b.Cluster)
+	return changes
+}
+
+func diffSnapshotSection(changes []SnapshotChange, section string, a, b map[string]string) []SnapshotChange {
+	var fields []string
+	for field, old := range a {
+		if b[field] != old {
+			fields = append(fields, field)
+		}
+	}
+	for field := range b {
+		if _, ok := a[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		changes = append(changes, SnapshotChange{
+			Section: section,
+			Field:   field,
+			Old:     a[field],
+			New:     b[field],
+		})
+	}
+	return changes
+}
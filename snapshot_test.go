@@ -0,0 +1,59 @@
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("ServerSnapshot", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.ConfigSet("maxmemory-policy", "noeviction").Err()).NotTo(HaveOccurred())
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should capture server state", func() {
+		snap, err := client.ServerSnapshot()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snap.Info).To(HaveKey("redis_version"))
+		Expect(snap.Config).To(HaveKeyWithValue("maxmemory-policy", "noeviction"))
+		Expect(snap.Cluster).To(BeNil())
+	})
+
+	It("should diff snapshots", func() {
+		a, err := client.ServerSnapshot()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.ConfigSet("maxmemory-policy", "allkeys-lru").Err()).NotTo(HaveOccurred())
+		b, err := client.ServerSnapshot()
+		Expect(err).NotTo(HaveOccurred())
+
+		changes := redis.DiffSnapshots(a, b)
+		Expect(changes).To(ContainElement(redis.SnapshotChange{
+			Section: "config",
+			Field:   "maxmemory-policy",
+			Old:     "noeviction",
+			New:     "allkeys-lru",
+		}))
+	})
+
+	It("should report added and removed fields", func() {
+		a := &redis.ServerSnapshot{Info: map[string]string{"a": "1", "b": "2"}}
+		b := &redis.ServerSnapshot{Info: map[string]string{"b": "3", "c": "4"}}
+		Expect(redis.DiffSnapshots(a, b)).To(Equal([]redis.SnapshotChange{
+			{Section: "info", Field: "a", Old: "1"},
+			{Section: "info", Field: "b", Old: "2", New: "3"},
+			{Section: "info", Field: "c", New: "4"},
+		}))
+	})
+})
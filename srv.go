@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var lookupSRV = net.LookupSRV
+
+// srvResolver resolves addresses published in DNS SRV record and
+// caches them for the refresh interval.
+type srvResolver struct {
+	name     string
+	interval time.Duration
+
+	mu        sync.Mutex
+	addrs     []string
+	expiresAt time.Time
+}
+
+func newSRVResolver(name string, interval time.Duration) *srvResolver {
+	if name == "" {
+		return nil
+	}
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+	return &srvResolver{
+		name:     name,
+		interval: interval,
+	}
+}
+
+// Addrs returns host:port addresses ordered by priority and randomized
+// by weight. DNS is re-queried when cached addresses are older than the
+// refresh interval; if the query fails cached addresses are returned.
+func (r *srvResolver) Addrs() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Now().Before(r.expiresAt) {
+		return r.addrs, nil
+	}
+
+	_, srvs, err := lookupSRV("", "", r.name)
+	if err != nil {
+		if r.addrs != nil {
+			log.Printf("redis: LookupSRV %q failed: %s", r.name, err)
+			return r.addrs, nil
+		}
+		return nil, err
+	}
+
+	addrs := make([]string, len(srvs))
+	for i, srv := range srvs {
+		host := strings.TrimSuffix(srv.Target, ".")
+		addrs[i] = net.JoinHostPort(host, strconv.Itoa(int(srv.Port)))
+	}
+	r.addrs = addrs
+	r.expiresAt = time.Now().Add(r.interval)
+	return addrs, nil
+}
+
+// dial connects to the first reachable address.
+func (r *srvResolver) dial(network string, timeout time.Duration) (net.Conn, error) {
+	addrs, err := r.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		var cn net.Conn
+		cn, err = net.DialTimeout(network, addr, timeout)
+		if err == nil {
+			return cn, nil
+		}
+	}
+	if err == nil {
+		err = &net.AddrError{Err: "no SRV targets", Addr: r.name}
+	}
+	return nil, err
+}
+
+// mergeAddrs appends addresses from src missing in dst.
+func mergeAddrs(dst, src []string) []string {
+	for _, addr := range src {
+		if !contains(dst, addr) {
+			dst = append(dst, addr)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,105 @@
+package redis_test
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("DNS SRV discovery", func() {
+	var mu sync.Mutex
+	var lookups int
+	var targets []*net.SRV
+	var lookupErr error
+	var restore func()
+
+	BeforeEach(func() {
+		port, err := strconv.Atoi(redisPort)
+		Expect(err).NotTo(HaveOccurred())
+
+		lookups = 0
+		lookupErr = nil
+		targets = []*net.SRV{
+			// Unreachable target with the highest priority.
+			{Target: "127.0.0.1.", Port: 1, Priority: 1},
+			{Target: "127.0.0.1.", Port: uint16(port), Priority: 2},
+		}
+		restore = redis.SetLookupSRV(func(service, proto, name string) (string, []*net.SRV, error) {
+			Expect(name).To(Equal("_redis._tcp.example.com"))
+			mu.Lock()
+			defer mu.Unlock()
+			lookups++
+			return "", targets, lookupErr
+		})
+	})
+
+	AfterEach(func() {
+		restore()
+	})
+
+	It("should dial discovered targets in order", func() {
+		client := redis.NewClient(&redis.Options{
+			DiscoverSRV: "_redis._tcp.example.com",
+		})
+		defer client.Close()
+
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+		Expect(lookups).To(Equal(1))
+	})
+
+	It("should re-query DNS after refresh interval", func() {
+		client := redis.NewClient(&redis.Options{
+			DiscoverSRV:        "_redis._tcp.example.com",
+			SRVRefreshInterval: time.Millisecond,
+			IdleTimeout:        time.Millisecond,
+		})
+		defer client.Close()
+
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+		time.Sleep(10 * time.Millisecond)
+
+		// Cached addresses are used when DNS query fails.
+		lookupErr = errors.New("lookup failed")
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+		Expect(lookups).To(Equal(2))
+	})
+
+	It("should return lookup error", func() {
+		lookupErr = errors.New("lookup failed")
+		client := redis.NewClient(&redis.Options{
+			DiscoverSRV: "_redis._tcp.example.com",
+		})
+		defer client.Close()
+
+		Expect(client.Ping().Err()).To(MatchError("lookup failed"))
+	})
+
+	It("should notify about changed targets", func() {
+		endpoints := redis.NewSRVEndpointProvider("_redis._tcp.example.com", time.Millisecond)
+		addrs, err := endpoints.Addrs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addrs).To(Equal([]string{"127.0.0.1:1", "127.0.0.1:" + redisPort}))
+
+		changes := make(chan []string, 1)
+		stop := endpoints.Watch(func(addrs []string) {
+			changes <- addrs
+		})
+		defer stop()
+
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		targets = targets[1:]
+		mu.Unlock()
+		Eventually(changes).Should(Receive(Equal([]string{"127.0.0.1:" + redisPort})))
+
+		// Stop may be called more than once.
+		stop()
+	})
+})
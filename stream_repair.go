@@ -0,0 +1,94 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type streamRepairClient interface {
+	XInfoStream(stream string) *XInfoStreamCmd
+	XInfoGroups(stream string) *XInfoGroupsCmd
+	XGroupSetID(stream, group, start string) *StatusCmd
+}
+
+// RepairStreamGroup resets the last delivered ID of the consumer group
+// when it is ahead of the last ID of the stream, e.g. after the stream
+// was restored from an older backup or its ID was changed with
+// XSETID. Such groups never deliver new entries until their IDs
+// exceed the group ID. The group is reset to the last ID of the stream,
+// so delivered entries are not delivered again and entries added
+// concurrently are not skipped. It returns true if the group was reset.
+func (c *Client) RepairStreamGroup(stream, group string) (bool, error) {
+	return repairStreamGroup(c, stream, group)
+}
+
+// RepairStreamGroup resets the last delivered ID of the consumer group
+// when it is ahead of the last ID of the stream. See
+// Client.RepairStreamGroup.
+func (c *ClusterClient) RepairStreamGroup(stream, group string) (bool, error) {
+	return repairStreamGroup(c, stream, group)
+}
+
+func repairStreamGroup(c streamRepairClient, stream, group string) (bool, error) {
+	info, err := c.XInfoStream(stream).Result()
+	if err != nil {
+		return false, err
+	}
+	groups, err := c.XInfoGroups(stream).Result()
+	if err != nil {
+		return false, err
+	}
+
+	for _, g := range groups {
+		if g.Name != group {
+			continue
+		}
+		cmp, err := compareStreamIDs(g.LastDeliveredID, info.LastGeneratedID)
+		if err != nil {
+			return false, err
+		}
+		if cmp <= 0 {
+			return false, nil
+		}
+		if err := c.XGroupSetID(stream, group, info.LastGeneratedID).Err(); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, fmt.Errorf("redis: consumer group %q does not exist", group)
+}
+
+// compareStreamIDs compares stream IDs "<ms>-<seq>" and returns -1, 0
+// or 1 if a is less than, equal to or greater than b.
+func compareStreamIDs(a, b string) (int, error) {
+	ams, aseq, err := parseStreamID(a)
+	if err != nil {
+		return 0, err
+	}
+	bms, bseq, err := parseStreamID(b)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case ams < bms || ams == bms && aseq < bseq:
+		return -1, nil
+	case ams == bms && aseq == bseq:
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func parseStreamID(id string) (ms, seq uint64, err error) {
+	i := strings.IndexByte(id, '-')
+	if i < 0 {
+		ms, err = strconv.ParseUint(id, 10, 64)
+		return ms, 0, err
+	}
+	ms, err = strconv.ParseUint(id[:i], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	seq, err = strconv.ParseUint(id[i+1:], 10, 64)
+	return ms, seq, err
+}
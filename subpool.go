@@ -0,0 +1,59 @@
+package redis
+
+import "time"
+
+// SubPoolOptions override pool settings of a sub-pool. Zero values
+// inherit settings of the parent client.
+type SubPoolOptions struct {
+	// The maximum number of socket connections of the sub-pool.
+	PoolSize int
+	// Amount of time client waits for a connection of the sub-pool.
+	PoolTimeout time.Duration
+	// Amount of time after which idle connections are closed.
+	IdleTimeout time.Duration
+
+	// Deadlines for socket reads and writes.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// SubPool returns a client that uses a separate connection pool named
+// name, e.g. to keep blocking commands such as BLPOP from starving
+// latency critical requests of connections. The client shares all
+// other options with c and reports the name in ProcessInfo.Pool. The
+// sub-pool is created on the first call, later calls return the same
+// client and ignore opt. It is closed together with c.
+func (c *Client) SubPool(name string, opt *SubPoolOptions) *Client {
+	c.dbsMx.Lock()
+	defer c.dbsMx.Unlock()
+
+	if client, ok := c.pools[name]; ok {
+		return client
+	}
+	if c.pools == nil {
+		c.pools = make(map[string]*Client)
+	}
+
+	clientOpt := *c.opt
+	if opt != nil {
+		if opt.PoolSize != 0 {
+			clientOpt.PoolSize = opt.PoolSize
+		}
+		if opt.PoolTimeout != 0 {
+			clientOpt.PoolTimeout = opt.PoolTimeout
+		}
+		if opt.IdleTimeout != 0 {
+			clientOpt.IdleTimeout = opt.IdleTimeout
+		}
+		if opt.ReadTimeout != 0 {
+			clientOpt.ReadTimeout = opt.ReadTimeout
+		}
+		if opt.WriteTimeout != 0 {
+			clientOpt.WriteTimeout = opt.WriteTimeout
+		}
+	}
+	client := NewClient(&clientOpt)
+	client.poolName = name
+	c.pools[name] = client
+	return client
+}
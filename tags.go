@@ -0,0 +1,108 @@
+package redis
+
+import "log"
+
+// tagBatchSize is the number of keys popped from the tag set and
+// unlinked by InvalidateTag at a time.
+const tagBatchSize = 100
+
+// tagKey returns the name of the set storing keys tagged with tag.
+func tagKey(tag string) string {
+	return helperKeys("tag", false, tag)[1]
+}
+
+type tagClient interface {
+	SAdd(key string, members ...string) *IntCmd
+	SPopN(key string, count int64) *StringSliceCmd
+	Unlink(keys ...string) *IntCmd
+}
+
+// Tag associates the key with tags so it is deleted by InvalidateTag
+// together with other keys having the same tag. Tags are stored as
+// sets named "tag:<tag>".
+func (c *Client) Tag(key string, tags ...string) error {
+	_, err := c.Pipelined(func(pipe *Pipeline) error {
+		for _, tag := range tags {
+			pipe.SAdd(tagKey(tag), key)
+		}
+		return nil
+	})
+	return err
+}
+
+// InvalidateTag unlinks all keys associated with the tag and deletes
+// the tag. Keys are popped from the tag set and unlinked in batches to
+// not block the server for a long time. It returns the number of
+// unlinked keys.
+func (c *Client) InvalidateTag(tag string) (int64, error) {
+	return invalidateTag(c, tag, func(keys []string) [][]string {
+		return [][]string{keys}
+	})
+}
+
+// Tag associates the key with tags. See Client.Tag.
+func (c *ClusterClient) Tag(key string, tags ...string) error {
+	pipe := c.Pipeline()
+	defer pipe.Close()
+
+	for _, tag := range tags {
+		pipe.SAdd(tagKey(tag), key)
+	}
+	_, err := pipe.Exec()
+	return err
+}
+
+// InvalidateTag unlinks all keys associated with the tag and deletes
+// the tag. Keys are unlinked with a command per slot. See
+// Client.InvalidateTag.
+func (c *ClusterClient) InvalidateTag(tag string) (int64, error) {
+	return invalidateTag(c, tag, func(keys []string) [][]string {
+		var groups [][]string
+		slots := make(map[int]int)
+		for _, key := range keys {
+			slot := hashSlot(key)
+			i, ok := slots[slot]
+			if !ok {
+				i = len(groups)
+				slots[slot] = i
+				groups = append(groups, nil)
+			}
+			groups[i] = append(groups[i], key)
+		}
+		return groups
+	})
+}
+
+func invalidateTag(c tagClient, tag string, group func([]string) [][]string) (int64, error) {
+	key := tagKey(tag)
+	var total int64
+	for {
+		keys, err := c.SPopN(key, tagBatchSize).Result()
+		if err != nil {
+			return total, err
+		}
+		// Unknown tag or the previous batch emptied the set.
+		if len(keys) == 0 {
+			return total, nil
+		}
+
+		groups := group(keys)
+		for i, keys := range groups {
+			n, err := c.Unlink(keys...).Result()
+			if err != nil {
+				// Keep keys that were not unlinked in the tag set.
+				for _, keys := range groups[i:] {
+					if err := c.SAdd(key, keys...).Err(); err != nil {
+						log.Printf("redis: restoring keys of tag %q failed: %s", tag, err)
+					}
+				}
+				return total, err
+			}
+			total += n
+		}
+
+		if len(keys) < tagBatchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package redis_test
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("Tags", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should invalidate tagged keys", func() {
+		Expect(client.Set("user:1:profile", "hello", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Set("user:1:settings", "hello", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Set("user:2:profile", "hello", 0).Err()).NotTo(HaveOccurred())
+
+		Expect(client.Tag("user:1:profile", "user:1", "profiles")).NotTo(HaveOccurred())
+		Expect(client.Tag("user:1:settings", "user:1")).NotTo(HaveOccurred())
+		Expect(client.Tag("user:2:profile", "user:2", "profiles")).NotTo(HaveOccurred())
+
+		n, err := client.InvalidateTag("user:1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(2)))
+
+		Expect(client.Exists("user:1:profile").Val()).To(BeFalse())
+		Expect(client.Exists("user:1:settings").Val()).To(BeFalse())
+		Expect(client.Exists("user:2:profile").Val()).To(BeTrue())
+		Expect(client.Exists("tag:user:1").Val()).To(BeFalse())
+	})
+
+	It("should invalidate tags in batches", func() {
+		for i := 0; i < 250; i++ {
+			key := "key" + strconv.Itoa(i)
+			Expect(client.Set(key, "hello", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Tag(key, "big")).NotTo(HaveOccurred())
+		}
+
+		n, err := client.InvalidateTag("big")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(250)))
+		Expect(client.DbSize().Val()).To(Equal(int64(0)))
+	})
+
+	It("should invalidate tags with a multiple of batch size keys", func() {
+		for i := 0; i < 100; i++ {
+			key := "key" + strconv.Itoa(i)
+			Expect(client.Set(key, "hello", 0).Err()).NotTo(HaveOccurred())
+			Expect(client.Tag(key, "big")).NotTo(HaveOccurred())
+		}
+
+		n, err := client.InvalidateTag("big")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(100)))
+		Expect(client.DbSize().Val()).To(Equal(int64(0)))
+	})
+
+	It("should ignore unknown tag", func() {
+		n, err := client.InvalidateTag("unknown")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(0)))
+	})
+})
@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// consumeTokenScript emulates GETDEL on servers older than Redis 6.2.
+var consumeTokenScript = NewScript(`
+local v = redis.call("GET", KEYS[1])
+if v then
+  redis.call("DEL", KEYS[1])
+end
+return v
+`)
+
+// ConsumeToken atomically gets and deletes the value stored at key,
+// so only the first caller receives the value and others get Nil. It
+// is useful for one-time links, password reset tokens and dedup
+// receipts. GETDEL is used when the server supports it and Lua script
+// otherwise.
+func (c *Client) ConsumeToken(key string) (string, error) {
+	if atomic.LoadInt32(&c.noGetDel) == 0 {
+		val, err := c.GetDel(key).Result()
+		if !isUnknownCommandError(err) {
+			return val, err
+		}
+		atomic.StoreInt32(&c.noGetDel, 1)
+	}
+
+	v, err := consumeTokenScript.Run(c, []string{key}, nil).Result()
+	if err != nil {
+		return "", err
+	}
+	val, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("redis: got %T, expected string", v)
+	}
+	return val, nil
+}
+
+func isUnknownCommandError(err error) bool {
+	if _, ok := err.(redisError); !ok {
+		return false
+	}
+	return strings.HasPrefix(err.Error(), "ERR unknown command")
+}
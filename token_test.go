@@ -0,0 +1,65 @@
+package redis_test
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("ConsumeToken", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should return token only once", func() {
+		Expect(client.Set("token", "secret", 0).Err()).NotTo(HaveOccurred())
+
+		val, err := client.ConsumeToken("token")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(Equal("secret"))
+
+		_, err = client.ConsumeToken("token")
+		Expect(err).To(Equal(redis.Nil))
+	})
+
+	It("should return token to a single concurrent caller", func() {
+		Expect(client.Set("token", "secret", 0).Err()).NotTo(HaveOccurred())
+
+		const n = 10
+		var mu sync.Mutex
+		var got []string
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				val, err := client.ConsumeToken("token")
+				if err == redis.Nil {
+					return
+				}
+				Expect(err).NotTo(HaveOccurred())
+
+				mu.Lock()
+				got = append(got, val)
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		Expect(got).To(Equal([]string{"secret"}))
+	})
+})
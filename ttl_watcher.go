@@ -0,0 +1,281 @@
+package redis
+
+import (
+	"container/heap"
+	"log"
+	"sync"
+	"time"
+)
+
+// ttlWatcherRetryDelay is the delay before keys are checked again
+// after PTTL failed.
+const ttlWatcherRetryDelay = time.Second
+
+// TTLWatcherOptions are used to configure a TTL watcher.
+type TTLWatcherOptions struct {
+	// How long before expiration keys are reported.
+	// Default is 10 seconds.
+	Before time.Duration
+	// Maximum time between checks of TTL of a watched key, so changes
+	// of expiration are noticed.
+	// Default is 1 minute.
+	RefreshInterval time.Duration
+}
+
+func (opt *TTLWatcherOptions) getBefore() time.Duration {
+	if opt.Before == 0 {
+		return 10 * time.Second
+	}
+	return opt.Before
+}
+
+func (opt *TTLWatcherOptions) getRefreshInterval() time.Duration {
+	if opt.RefreshInterval == 0 {
+		return time.Minute
+	}
+	return opt.RefreshInterval
+}
+
+type ttlEntry struct {
+	key      string
+	notifyAt time.Time
+	index    int
+}
+
+// ttlHeap orders entries by notification time and implements
+// heap.Interface.
+type ttlHeap []*ttlEntry
+
+func (h ttlHeap) Len() int           { return len(h) }
+func (h ttlHeap) Less(i, j int) bool { return h[i].notifyAt.Before(h[j].notifyAt) }
+
+func (h ttlHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *ttlHeap) Push(x interface{}) {
+	e := x.(*ttlEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *ttlHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// TTLWatcher notifies the application shortly before watched keys
+// expire, e.g. to refresh cached credentials. Keys are kept in a local
+// heap ordered by notification time and their TTLs are checked with
+// pipelined PTTL commands when they are due. A key is reported once:
+// with the remaining TTL when it expires within Before, or with zero
+// TTL when it does not exist. Keys without expiration are checked
+// every RefreshInterval. Reported keys must be watched again to get
+// further notifications.
+type TTLWatcher struct {
+	client *Client
+	fn     func(key string, ttl time.Duration)
+	opt    *TTLWatcherOptions
+
+	mu      sync.Mutex
+	entries map[string]*ttlEntry
+	heap    ttlHeap
+
+	wakeup chan struct{}
+	closed chan struct{}
+	done   chan struct{}
+}
+
+// NewTTLWatcher returns a watcher calling fn for keys about to
+// expire. fn is called from a single goroutine.
+func NewTTLWatcher(client *Client, fn func(key string, ttl time.Duration), opt *TTLWatcherOptions) *TTLWatcher {
+	if opt == nil {
+		opt = &TTLWatcherOptions{}
+	}
+	w := &TTLWatcher{
+		client:  client,
+		fn:      fn,
+		opt:     opt,
+		entries: make(map[string]*ttlEntry),
+		wakeup:  make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Watch starts watching keys. Keys that are already watched are
+// checked again.
+func (w *TTLWatcher) Watch(keys ...string) {
+	now := w.client.opt.getClock().Now()
+	w.mu.Lock()
+	for _, key := range keys {
+		w.schedule(key, now)
+	}
+	w.mu.Unlock()
+	w.wake()
+}
+
+// Unwatch stops watching keys.
+func (w *TTLWatcher) Unwatch(keys ...string) {
+	w.mu.Lock()
+	for _, key := range keys {
+		if e, ok := w.entries[key]; ok {
+			heap.Remove(&w.heap, e.index)
+			delete(w.entries, key)
+		}
+	}
+	w.mu.Unlock()
+}
+
+// Len returns the number of watched keys.
+func (w *TTLWatcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.entries)
+}
+
+// Close stops the watcher.
+func (w *TTLWatcher) Close() error {
+	select {
+	case <-w.closed:
+		return errClosed
+	default:
+	}
+	close(w.closed)
+	<-w.done
+	return nil
+}
+
+// schedule sets the notification time of the key. w.mu must be held.
+func (w *TTLWatcher) schedule(key string, at time.Time) {
+	if e, ok := w.entries[key]; ok {
+		e.notifyAt = at
+		heap.Fix(&w.heap, e.index)
+		return
+	}
+	e := &ttlEntry{key: key, notifyAt: at}
+	w.entries[key] = e
+	heap.Push(&w.heap, e)
+}
+
+func (w *TTLWatcher) wake() {
+	select {
+	case w.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (w *TTLWatcher) run() {
+	defer close(w.done)
+
+	clock := w.client.opt.getClock()
+	for {
+		now := clock.Now()
+		var due []string
+		wait := time.Duration(-1)
+
+		w.mu.Lock()
+		for len(w.heap) > 0 {
+			e := w.heap[0]
+			if e.notifyAt.After(now) {
+				wait = e.notifyAt.Sub(now)
+				break
+			}
+			due = append(due, e.key)
+			// Keys are rescheduled or removed by check.
+			e.notifyAt = now.Add(w.opt.getRefreshInterval())
+			heap.Fix(&w.heap, 0)
+		}
+		w.mu.Unlock()
+
+		if len(due) > 0 {
+			w.check(due)
+			continue
+		}
+
+		var timerC <-chan time.Time
+		if wait >= 0 {
+			timerC = after(clock, wait)
+		}
+		select {
+		case <-timerC:
+		case <-w.wakeup:
+		case <-w.closed:
+			return
+		}
+	}
+}
+
+// check fetches TTLs of due keys and notifies about keys expiring
+// within Before.
+func (w *TTLWatcher) check(keys []string) {
+	cmds := make([]*DurationCmd, len(keys))
+	_, err := w.client.Pipelined(func(pipe *Pipeline) error {
+		for i, key := range keys {
+			cmds[i] = pipe.PTTL(key)
+		}
+		return nil
+	})
+	now := w.client.opt.getClock().Now()
+	if err != nil {
+		log.Printf("redis: TTLWatcher PTTL failed: %s", err)
+		w.mu.Lock()
+		for _, key := range keys {
+			if _, ok := w.entries[key]; ok {
+				w.schedule(key, now.Add(ttlWatcherRetryDelay))
+			}
+		}
+		w.mu.Unlock()
+		return
+	}
+
+	type notification struct {
+		key string
+		ttl time.Duration
+	}
+	var notifications []notification
+
+	before := w.opt.getBefore()
+	refresh := w.opt.getRefreshInterval()
+	w.mu.Lock()
+	for i, key := range keys {
+		e, ok := w.entries[key]
+		if !ok {
+			// Unwatched while PTTL was in flight.
+			continue
+		}
+
+		ttl := cmds[i].Val()
+		switch {
+		case ttl == -2*time.Millisecond:
+			// Key does not exist.
+			ttl = 0
+		case ttl < 0:
+			// Key has no expiration.
+			w.schedule(key, now.Add(refresh))
+			continue
+		case ttl > before:
+			next := ttl - before
+			if next > refresh {
+				next = refresh
+			}
+			w.schedule(key, now.Add(next))
+			continue
+		}
+
+		heap.Remove(&w.heap, e.index)
+		delete(w.entries, key)
+		notifications = append(notifications, notification{key: key, ttl: ttl})
+	}
+	w.mu.Unlock()
+
+	for _, n := range notifications {
+		w.fn(n.key, n.ttl)
+	}
+}
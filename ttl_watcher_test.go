@@ -0,0 +1,88 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("TTLWatcher", func() {
+	type expiring struct {
+		key string
+		ttl time.Duration
+	}
+
+	var client *redis.Client
+	var watcher *redis.TTLWatcher
+	var ch chan expiring
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+
+		ch = make(chan expiring, 10)
+		watcher = redis.NewTTLWatcher(client, func(key string, ttl time.Duration) {
+			ch <- expiring{key, ttl}
+		}, &redis.TTLWatcherOptions{
+			Before:          time.Second,
+			RefreshInterval: 100 * time.Millisecond,
+		})
+	})
+
+	AfterEach(func() {
+		Expect(watcher.Close()).NotTo(HaveOccurred())
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should notify before keys expire", func() {
+		Expect(client.Set("soon", "v", 1500*time.Millisecond).Err()).NotTo(HaveOccurred())
+		Expect(client.Set("later", "v", time.Hour).Err()).NotTo(HaveOccurred())
+		Expect(client.Set("forever", "v", 0).Err()).NotTo(HaveOccurred())
+
+		start := time.Now()
+		watcher.Watch("soon", "later", "forever")
+
+		var e expiring
+		Eventually(ch, "2s").Should(Receive(&e))
+		Expect(e.key).To(Equal("soon"))
+		Expect(e.ttl).To(BeNumerically("~", time.Second, 200*time.Millisecond))
+		Expect(time.Since(start)).To(BeNumerically(">=", 400*time.Millisecond))
+
+		Consistently(ch, "300ms").ShouldNot(Receive())
+		Expect(watcher.Len()).To(Equal(2))
+	})
+
+	It("should notify about missing keys", func() {
+		watcher.Watch("missing")
+
+		var e expiring
+		Eventually(ch).Should(Receive(&e))
+		Expect(e).To(Equal(expiring{"missing", 0}))
+		Expect(watcher.Len()).To(Equal(0))
+	})
+
+	It("should reschedule keys with changed expiration", func() {
+		Expect(client.Set("key", "v", time.Hour).Err()).NotTo(HaveOccurred())
+		watcher.Watch("key")
+		Consistently(ch, "200ms").ShouldNot(Receive())
+
+		Expect(client.PExpire("key", 500*time.Millisecond).Err()).NotTo(HaveOccurred())
+		var e expiring
+		Eventually(ch, "1s").Should(Receive(&e))
+		Expect(e.key).To(Equal("key"))
+	})
+
+	It("should not notify about unwatched keys", func() {
+		Expect(client.Set("key", "v", 2*time.Second).Err()).NotTo(HaveOccurred())
+		watcher.Watch("key")
+		watcher.Unwatch("key")
+
+		Expect(watcher.Len()).To(Equal(0))
+		Consistently(ch, "1500ms").ShouldNot(Receive())
+	})
+})
@@ -0,0 +1,132 @@
+//go:build go1.18
+// +build go1.18
+
+package redis
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Codec marshals values of type T stored by TypedClient.
+type Codec[T any] interface {
+	Marshal(v T) ([]byte, error)
+	Unmarshal(b []byte, v *T) error
+}
+
+// JSONCodec encodes values using encoding/json.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Marshal(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec[T]) Unmarshal(b []byte, v *T) error {
+	return json.Unmarshal(b, v)
+}
+
+type typedCommander interface {
+	Get(key string) *StringCmd
+	Set(key string, value interface{}, expiration time.Duration) *StatusCmd
+	MGet(keys ...string) *SliceCmd
+	HGetAllMap(key string) *StringStringMapCmd
+}
+
+// TypedClient binds values of type T to a client, so values don't have
+// to be unmarshaled by hand. It works with Client, ClusterClient and
+// other clients having the same commands. Requires Go 1.18.
+type TypedClient[T any] struct {
+	client typedCommander
+	codec  Codec[T]
+}
+
+// NewTypedClient returns a typed client using codec. When codec is nil
+// values are encoded like other command arguments and decoded like
+// Scan does, see Encoding.
+func NewTypedClient[T any](client typedCommander, codec Codec[T]) *TypedClient[T] {
+	return &TypedClient[T]{
+		client: client,
+		codec:  codec,
+	}
+}
+
+func (c *TypedClient[T]) marshal(v T) (interface{}, error) {
+	if c.codec == nil {
+		return v, nil
+	}
+	return c.codec.Marshal(v)
+}
+
+// unmarshal decodes a reply. Without codec it is decoded using cdc of
+// the client that processed the command.
+func (c *TypedClient[T]) unmarshal(cdc *codec, b []byte, v *T) error {
+	if c.codec == nil {
+		return cdc.scan(b, v)
+	}
+	return c.codec.Unmarshal(b, v)
+}
+
+// Get returns the value of the key. Nil error is returned when the key
+// does not exist.
+func (c *TypedClient[T]) Get(key string) (T, error) {
+	var v T
+	cmd := c.client.Get(key)
+	b, err := cmd.Bytes()
+	if err != nil {
+		return v, err
+	}
+	err = c.unmarshal(cmd.codec, b, &v)
+	return v, err
+}
+
+// Set sets the value of the key. Zero expiration means the key has no
+// expiration time.
+func (c *TypedClient[T]) Set(key string, v T, expiration time.Duration) error {
+	val, err := c.marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(key, val, expiration).Err()
+}
+
+// MGet returns values of the keys in order. Values of keys that don't
+// exist are nil.
+func (c *TypedClient[T]) MGet(keys ...string) ([]*T, error) {
+	cmd := c.client.MGet(keys...)
+	vals, err := cmd.Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*T, len(vals))
+	for i, val := range vals {
+		s, ok := val.(string)
+		if !ok {
+			continue
+		}
+		v := new(T)
+		if err := c.unmarshal(cmd.codec, []byte(s), v); err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+// HGetAll returns all fields of the hash stored at key with their
+// values.
+func (c *TypedClient[T]) HGetAll(key string) (map[string]T, error) {
+	cmd := c.client.HGetAllMap(key)
+	m, err := cmd.Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]T, len(m))
+	for field, s := range m {
+		var v T
+		if err := c.unmarshal(cmd.codec, []byte(s), &v); err != nil {
+			return nil, err
+		}
+		res[field] = v
+	}
+	return res, nil
+}
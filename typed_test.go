@@ -0,0 +1,71 @@
+//go:build go1.18
+// +build go1.18
+
+package redis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+type typedUser struct {
+	Name string
+	Age  int
+}
+
+var _ = Describe("TypedClient", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should get and set values using codec", func() {
+		users := redis.NewTypedClient[typedUser](client, redis.JSONCodec[typedUser]{})
+
+		_, err := users.Get("user:1")
+		Expect(err).To(Equal(redis.Nil))
+
+		Expect(users.Set("user:1", typedUser{Name: "alice", Age: 30}, 0)).NotTo(HaveOccurred())
+		Expect(users.Set("user:2", typedUser{Name: "bob", Age: 40}, 0)).NotTo(HaveOccurred())
+		Expect(client.Get("user:1").Val()).To(Equal(`{"Name":"alice","Age":30}`))
+
+		user, err := users.Get("user:1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(user).To(Equal(typedUser{Name: "alice", Age: 30}))
+
+		vals, err := users.MGet("user:1", "user:3", "user:2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vals).To(HaveLen(3))
+		Expect(*vals[0]).To(Equal(typedUser{Name: "alice", Age: 30}))
+		Expect(vals[1]).To(BeNil())
+		Expect(*vals[2]).To(Equal(typedUser{Name: "bob", Age: 40}))
+	})
+
+	It("should use default encoding without codec", func() {
+		counters := redis.NewTypedClient[int64](client, nil)
+
+		Expect(counters.Set("counter", 42, 0)).NotTo(HaveOccurred())
+		n, err := counters.Get("counter")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(42)))
+
+		Expect(client.HMSet("hash", "a", "1", "b", "2").Err()).NotTo(HaveOccurred())
+		m, err := counters.HGetAll("hash")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m).To(Equal(map[string]int64{"a": 1, "b": 2}))
+
+		Expect(client.Set("counter", "hello", 0).Err()).NotTo(HaveOccurred())
+		_, err = counters.Get("counter")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,139 @@
+package redis
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	errWindowTooLong = errors.New("redis: window is longer than UniqueWindow retention")
+	errInvalidBucket = errors.New("redis: UniqueWindow bucket must be positive whole seconds")
+)
+
+// UniqueWindowOptions are used to configure a unique window.
+type UniqueWindowOptions struct {
+	// Size of buckets. It must be whole seconds.
+	// Default is 1 minute.
+	Bucket time.Duration
+	// The longest window that can be counted. Older buckets expire.
+	// Default is 24 hours.
+	Window time.Duration
+}
+
+func (opt *UniqueWindowOptions) getBucket() time.Duration {
+	if opt.Bucket == 0 {
+		return time.Minute
+	}
+	return opt.Bucket
+}
+
+func (opt *UniqueWindowOptions) getWindow() time.Duration {
+	if opt.Window == 0 {
+		return 24 * time.Hour
+	}
+	return opt.Window
+}
+
+// UniqueWindow counts approximately unique members, e.g. visitors,
+// seen within a sliding window. Members are added to a HyperLogLog per
+// bucket stored in key "<name>:<bucket start>" and windows are counted
+// by merging buckets into a temporary key "<name>:tmp".
+type UniqueWindow struct {
+	client *Client
+	name   string
+	opt    *UniqueWindowOptions
+
+	// Set when options are invalid and returned by all methods.
+	err error
+}
+
+// NewUniqueWindow returns a unique window storing buckets in keys
+// prefixed with name. Methods of the window fail when the bucket is
+// not a positive whole number of seconds.
+func NewUniqueWindow(client *Client, name string, opt *UniqueWindowOptions) *UniqueWindow {
+	if opt == nil {
+		opt = &UniqueWindowOptions{}
+	}
+	w := &UniqueWindow{
+		client: client,
+		name:   name,
+		opt:    opt,
+	}
+	if bucket := opt.getBucket(); bucket < time.Second || bucket%time.Second != 0 {
+		w.err = errInvalidBucket
+	}
+	return w
+}
+
+func (w *UniqueWindow) bucketKey(start int64) string {
+	return w.name + ":" + formatInt(start)
+}
+
+func (w *UniqueWindow) bucketStart(tm time.Time) int64 {
+	sec := int64(w.opt.getBucket() / time.Second)
+	return tm.Unix() / sec * sec
+}
+
+// Add adds members to the current bucket.
+func (w *UniqueWindow) Add(members ...string) error {
+	return w.AddAt(w.client.opt.getClock().Now(), members...)
+}
+
+// AddAt adds members to the bucket containing tm.
+func (w *UniqueWindow) AddAt(tm time.Time, members ...string) error {
+	if w.err != nil {
+		return w.err
+	}
+	key := w.bucketKey(w.bucketStart(tm))
+	_, err := w.client.Pipelined(func(pipe *Pipeline) error {
+		pipe.PFAdd(key, members...)
+		pipe.Expire(key, w.opt.getWindow()+w.opt.getBucket())
+		return nil
+	})
+	return err
+}
+
+// CountLast returns the approximate number of unique members added
+// within the window ending now. The window is rounded up to whole
+// buckets and must not be longer than the Window option.
+func (w *UniqueWindow) CountLast(window time.Duration) (int64, error) {
+	return w.CountLastAt(w.client.opt.getClock().Now(), window)
+}
+
+// CountLastAt is like CountLast, but for the window ending at tm.
+func (w *UniqueWindow) CountLastAt(tm time.Time, window time.Duration) (int64, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	if window > w.opt.getWindow() {
+		return 0, errWindowTooLong
+	}
+
+	bucket := w.opt.getBucket()
+	n := int64((window + bucket - 1) / bucket)
+	if n < 1 {
+		n = 1
+	}
+	sec := int64(bucket / time.Second)
+	end := w.bucketStart(tm)
+	keys := make([]string, 0, n)
+	for i := int64(0); i < n; i++ {
+		keys = append(keys, w.bucketKey(end-i*sec))
+	}
+
+	tmp := w.name + ":tmp"
+	multi := w.client.Multi()
+	defer multi.Close()
+
+	var count *IntCmd
+	_, err := multi.Exec(func() error {
+		multi.PFMerge(tmp, keys...)
+		count = multi.PFCount(tmp)
+		multi.Del(tmp)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
@@ -0,0 +1,73 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("UniqueWindow", func() {
+	var client *redis.Client
+	tm := time.Date(2024, 1, 1, 10, 30, 15, 0, time.UTC)
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should count unique members in windows", func() {
+		visitors := redis.NewUniqueWindow(client, "visitors", nil)
+		Expect(visitors.AddAt(tm, "a", "b")).NotTo(HaveOccurred())
+		Expect(visitors.AddAt(tm.Add(time.Minute), "b", "c")).NotTo(HaveOccurred())
+		Expect(visitors.AddAt(tm.Add(2*time.Minute), "d", "d")).NotTo(HaveOccurred())
+
+		end := tm.Add(2 * time.Minute)
+		for window, want := range map[time.Duration]int64{
+			30 * time.Second: 1,
+			time.Minute:      1,
+			2 * time.Minute:  3,
+			time.Hour:        4,
+		} {
+			n, err := visitors.CountLastAt(end, window)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(want))
+		}
+
+		Expect(client.Exists("visitors:tmp").Val()).To(BeFalse())
+	})
+
+	It("should expire buckets", func() {
+		visitors := redis.NewUniqueWindow(client, "visitors", &redis.UniqueWindowOptions{
+			Bucket: time.Minute,
+			Window: 10 * time.Minute,
+		})
+		Expect(visitors.AddAt(tm, "a")).NotTo(HaveOccurred())
+
+		keys, err := client.Keys("visitors:*").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]string{"visitors:1704105000"}))
+		Expect(client.TTL(keys[0]).Val()).To(Equal(11 * time.Minute))
+
+		_, err = visitors.CountLast(time.Hour)
+		Expect(err).To(MatchError("redis: window is longer than UniqueWindow retention"))
+	})
+
+	It("should reject buckets that are not whole seconds", func() {
+		visitors := redis.NewUniqueWindow(client, "visitors", &redis.UniqueWindowOptions{
+			Bucket: 500 * time.Millisecond,
+		})
+		err := visitors.AddAt(tm, "a")
+		Expect(err).To(MatchError("redis: UniqueWindow bucket must be positive whole seconds"))
+		_, err = visitors.CountLastAt(tm, time.Minute)
+		Expect(err).To(MatchError("redis: UniqueWindow bucket must be positive whole seconds"))
+	})
+})
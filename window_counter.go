@@ -0,0 +1,194 @@
+package redis
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	errUnknownWindow = errors.New("redis: window is not configured in WindowCounter")
+	errInvalidWindow = errors.New("redis: WindowCounter windows must be positive whole seconds")
+)
+
+// WindowCounterOptions are used to configure a window counter.
+type WindowCounterOptions struct {
+	// Sizes of windows counted by the counter. Sizes must be whole
+	// seconds.
+	// Default is one minute, one hour and one day.
+	Windows []time.Duration
+	// Number of past windows of each size kept in Redis.
+	// Default is 60.
+	Retention int
+	// Rollup stores counts of Retention consecutive windows as fields
+	// of a single hash instead of separate keys, which uses less
+	// memory.
+	Rollup bool
+}
+
+func (opt *WindowCounterOptions) getWindows() []time.Duration {
+	if len(opt.Windows) == 0 {
+		return []time.Duration{time.Minute, time.Hour, 24 * time.Hour}
+	}
+	return opt.Windows
+}
+
+func (opt *WindowCounterOptions) getRetention() int {
+	if opt.Retention == 0 {
+		return 60
+	}
+	return opt.Retention
+}
+
+// WindowCount is a count of events in the window starting at Start.
+type WindowCount struct {
+	Start time.Time
+	Count int64
+}
+
+// WindowCounter counts events in fixed time windows, e.g. per minute,
+// per hour and per day. Each window is stored in a key
+// "<name>:<window seconds>:<window start>" that expires after
+// Retention windows. With Rollup windows are stored as fields of hash
+// "<name>:<window seconds>:<rollup start>".
+type WindowCounter struct {
+	client *Client
+	name   string
+	opt    *WindowCounterOptions
+
+	// Set when options are invalid and returned by all methods.
+	err error
+}
+
+// NewWindowCounter returns a counter storing counts in keys prefixed
+// with name. Methods of the counter fail when windows are not positive
+// whole seconds.
+func NewWindowCounter(client *Client, name string, opt *WindowCounterOptions) *WindowCounter {
+	if opt == nil {
+		opt = &WindowCounterOptions{}
+	}
+	c := &WindowCounter{
+		client: client,
+		name:   name,
+		opt:    opt,
+	}
+	for _, window := range opt.getWindows() {
+		if window < time.Second || window%time.Second != 0 {
+			c.err = errInvalidWindow
+		}
+	}
+	return c
+}
+
+// location returns the key and, with Rollup, hash field storing the
+// count of the window containing tm.
+func (c *WindowCounter) location(window time.Duration, tm time.Time) (key, field string) {
+	sec := int64(window / time.Second)
+	start := tm.Unix() / sec * sec
+	prefix := c.name + ":" + formatInt(sec) + ":"
+	if !c.opt.Rollup {
+		return prefix + formatInt(start), ""
+	}
+	rollup := sec * int64(c.opt.getRetention())
+	return prefix + formatInt(tm.Unix()/rollup*rollup), formatInt(start)
+}
+
+// ttl returns expiration of keys storing counts of the window.
+func (c *WindowCounter) ttl(window time.Duration) time.Duration {
+	ttl := window * time.Duration(c.opt.getRetention()+1)
+	if c.opt.Rollup {
+		ttl *= 2
+	}
+	return ttl
+}
+
+// Incr increments counts of current windows by n.
+func (c *WindowCounter) Incr(n int64) error {
+	return c.IncrAt(c.client.opt.getClock().Now(), n)
+}
+
+// IncrAt increments counts of windows containing tm by n.
+func (c *WindowCounter) IncrAt(tm time.Time, n int64) error {
+	if c.err != nil {
+		return c.err
+	}
+	_, err := c.client.Pipelined(func(pipe *Pipeline) error {
+		for _, window := range c.opt.getWindows() {
+			key, field := c.location(window, tm)
+			if field != "" {
+				pipe.HIncrBy(key, field, n)
+			} else {
+				pipe.IncrBy(key, n)
+			}
+			pipe.Expire(key, c.ttl(window))
+		}
+		return nil
+	})
+	return err
+}
+
+// Count returns count of the window containing tm.
+func (c *WindowCounter) Count(window time.Duration, tm time.Time) (int64, error) {
+	counts, err := c.Range(window, tm, tm)
+	if err != nil {
+		return 0, err
+	}
+	return counts[0].Count, nil
+}
+
+// Range returns counts of windows from the window containing from to
+// the window containing to. Windows without events have zero count.
+func (c *WindowCounter) Range(window time.Duration, from, to time.Time) ([]WindowCount, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if !c.hasWindow(window) {
+		return nil, errUnknownWindow
+	}
+
+	sec := int64(window / time.Second)
+	start := from.Unix() / sec * sec
+
+	pipe := c.client.Pipeline()
+	defer pipe.Close()
+
+	var counts []WindowCount
+	var cmds []*StringCmd
+	for t := start; t <= to.Unix(); t += sec {
+		tm := time.Unix(t, 0)
+		key, field := c.location(window, tm)
+		if field != "" {
+			cmds = append(cmds, pipe.HGet(key, field))
+		} else {
+			cmds = append(cmds, pipe.Get(key))
+		}
+		counts = append(counts, WindowCount{Start: tm})
+	}
+	if len(cmds) == 0 {
+		return nil, nil
+	}
+
+	_, err := pipe.Exec()
+	if err != nil && err != Nil {
+		return nil, err
+	}
+	for i, cmd := range cmds {
+		n, err := cmd.Int64()
+		if err == Nil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		counts[i].Count = n
+	}
+	return counts, nil
+}
+
+func (c *WindowCounter) hasWindow(window time.Duration) bool {
+	for _, w := range c.opt.getWindows() {
+		if w == window {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package redis_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"gopkg.in/redis.v3"
+)
+
+var _ = Describe("WindowCounter", func() {
+	var client *redis.Client
+	tm := time.Date(2024, 1, 1, 10, 30, 15, 0, time.UTC)
+
+	BeforeEach(func() {
+		client = redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		})
+		Expect(client.FlushDb().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	for _, rollup := range []bool{false, true} {
+		rollup := rollup
+
+		It("should count events in windows", func() {
+			counter := redis.NewWindowCounter(client, "hits", &redis.WindowCounterOptions{
+				Rollup: rollup,
+			})
+			Expect(counter.IncrAt(tm, 1)).NotTo(HaveOccurred())
+			Expect(counter.IncrAt(tm.Add(10*time.Second), 2)).NotTo(HaveOccurred())
+			Expect(counter.IncrAt(tm.Add(time.Minute), 4)).NotTo(HaveOccurred())
+
+			n, err := counter.Count(time.Minute, tm)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(3)))
+
+			n, err = counter.Count(time.Hour, tm)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(7)))
+
+			n, err = counter.Count(24*time.Hour, tm.Add(24*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+
+			counts, err := counter.Range(time.Minute, tm.Add(-time.Minute), tm.Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+			start := time.Date(2024, 1, 1, 10, 29, 0, 0, time.UTC)
+			Expect(counts).To(HaveLen(3))
+			for i, want := range []int64{0, 3, 4} {
+				Expect(counts[i].Start.Equal(start.Add(time.Duration(i) * time.Minute))).To(BeTrue())
+				Expect(counts[i].Count).To(Equal(want))
+			}
+		})
+	}
+
+	It("should expire windows", func() {
+		counter := redis.NewWindowCounter(client, "hits", &redis.WindowCounterOptions{
+			Windows:   []time.Duration{time.Minute},
+			Retention: 10,
+		})
+		Expect(counter.IncrAt(tm, 1)).NotTo(HaveOccurred())
+
+		keys, err := client.Keys("hits:*").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]string{"hits:60:1704105000"}))
+
+		ttl, err := client.TTL(keys[0]).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ttl).To(Equal(11 * time.Minute))
+	})
+
+	It("should reject unknown windows", func() {
+		counter := redis.NewWindowCounter(client, "hits", nil)
+		_, err := counter.Count(time.Second, tm)
+		Expect(err).To(MatchError("redis: window is not configured in WindowCounter"))
+	})
+
+	It("should reject windows that are not whole seconds", func() {
+		for _, window := range []time.Duration{-time.Second, 500 * time.Millisecond, 1500 * time.Millisecond} {
+			counter := redis.NewWindowCounter(client, "hits", &redis.WindowCounterOptions{
+				Windows: []time.Duration{time.Minute, window},
+			})
+			err := counter.IncrAt(tm, 1)
+			Expect(err).To(MatchError("redis: WindowCounter windows must be positive whole seconds"))
+			_, err = counter.Count(time.Minute, tm)
+			Expect(err).To(MatchError("redis: WindowCounter windows must be positive whole seconds"))
+		}
+	})
+})